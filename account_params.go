@@ -0,0 +1,61 @@
+package okex
+
+/*
+ OKEX account (wallet) api request params
+*/
+
+/*
+ Page of the wallet ledger.
+ Currency: eg: btc, empty for all.
+ Type: 1 deposit, 2 withdrawal, 37 transfer in ... empty for all.
+*/
+type AccountLedgerParams struct {
+	CursorPage
+	Currency string
+	Type     string
+}
+
+func (p *AccountLedgerParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	if p.Currency != "" {
+		params["currency"] = p.Currency
+	}
+	if p.Type != "" {
+		params["type"] = p.Type
+	}
+	return params
+}
+
+/*
+ Withdrawal of Amount of Currency to ToAddress.
+ Destination: 2 OKCoin, 3 OKEx, 4 digital currency address.
+ Fee: the network fee, @see GetAccountWithdrawalFee
+*/
+type AccountWithdrawalParams struct {
+	Currency    string  `json:"currency"`
+	Amount      Decimal `json:"amount"`
+	Destination string  `json:"destination"`
+	ToAddress   string  `json:"to_address"`
+	TradePwd    string  `json:"trade_pwd"`
+	Fee         Decimal `json:"fee"`
+}
+
+/*
+ Transfer of Amount of Currency between two accounts.
+ From, To: 0 sub account, 1 spot, 3 futures, 4 c2c, 5 margin, 6 wallet, 8 piggy bank, 9 swap, 12 option ...
+ SubAccount: the sub account of a transfer from or to 0.
+ InstrumentId: the margin pair or contract underlying transferred from, eg: btc-usdt.
+ ToInstrumentId: the margin pair or contract underlying transferred to.
+*/
+type AccountTransferParams struct {
+	Currency       string  `json:"currency"`
+	Amount         Decimal `json:"amount"`
+	From           string  `json:"from"`
+	To             string  `json:"to"`
+	SubAccount     string  `json:"sub_account,omitempty"`
+	InstrumentId   string  `json:"instrument_id,omitempty"`
+	ToInstrumentId string  `json:"to_instrument_id,omitempty"`
+}
@@ -0,0 +1,266 @@
+package okex
+
+/*
+ Multi-account client pool.
+
+ Every account gets its own Client (and so its own rate limiter and clock),
+ while all of them share one http transport. The ForEach helpers run a call
+ for every account concurrently and collect the results and errors by account.
+*/
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// AccountCredentials of one (sub-)account, field names follow conf/test_config.example.yaml
+type AccountCredentials struct {
+	Name       string `mapstructure:"name"`
+	ApiKey     string `mapstructure:"access_key"`
+	SecretKey  string `mapstructure:"secret_key"`
+	Passphrase string `mapstructure:"passphrase"`
+	// Signs instead of SecretKey, can't be loaded from a config file. @see Config.Signer
+	Signer Signer `mapstructure:"-"`
+}
+
+/*
+ Load credentials from a viper config file, either a list of accounts
+
+	accounts:
+	  - name: main
+	    access_key: ""
+	    secret_key: ""
+	    passphrase: ""
+
+ or a single account named "default" with access_key, secret_key, passphrase at the top level.
+*/
+func LoadAccountCredentials(path string) ([]AccountCredentials, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var accounts []AccountCredentials
+	if v.IsSet("accounts") {
+		if err := v.UnmarshalKey("accounts", &accounts); err != nil {
+			return nil, err
+		}
+		return accounts, nil
+	}
+	var account AccountCredentials
+	if err := v.Unmarshal(&account); err != nil {
+		return nil, err
+	}
+	if account.Name == "" {
+		account.Name = "default"
+	}
+	return []AccountCredentials{account}, nil
+}
+
+type AccountPool struct {
+	config   Config
+	accounts map[string]AccountCredentials
+	clients  map[string]*Client
+	names    []string
+}
+
+/*
+ Create a client per account from the shared config, whose credentials are ignored.
+ Account names must be unique and non-empty.
+*/
+func NewAccountPool(config Config, accounts []AccountCredentials) (*AccountPool, error) {
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+	pool := &AccountPool{
+		config:   config,
+		accounts: make(map[string]AccountCredentials),
+		clients:  make(map[string]*Client),
+	}
+	for _, account := range accounts {
+		if account.Name == "" {
+			return nil, fmt.Errorf("okex: account without name")
+		}
+		if _, ok := pool.clients[account.Name]; ok {
+			return nil, fmt.Errorf("okex: duplicate account %s", account.Name)
+		}
+		accountConfig := config
+		accountConfig.ApiKey = account.ApiKey
+		accountConfig.SecretKey = account.SecretKey
+		accountConfig.Passphrase = account.Passphrase
+		accountConfig.Signer = account.Signer
+		accountConfig.HTTPClient = httpClient
+		pool.accounts[account.Name] = account
+		pool.clients[account.Name] = NewClient(accountConfig)
+		pool.names = append(pool.names, account.Name)
+	}
+	sort.Strings(pool.names)
+	return pool, nil
+}
+
+// Names of all accounts, sorted
+func (pool *AccountPool) Names() []string {
+	return append([]string(nil), pool.names...)
+}
+
+// Client of the account, nil if unknown
+func (pool *AccountPool) Client(name string) *Client {
+	return pool.clients[name]
+}
+
+/*
+ SwapWS logged in as the account with the clock of its Client, on the pool's
+ WSEndpoint and simulated mode, debug mode if the pool's IsPrint is set
+*/
+func (pool *AccountPool) NewSwapWS(name string, options ...WSOption) (*SwapWS, error) {
+	account, ok := pool.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("okex: unknown account %s", name)
+	}
+	ws := NewSwapWS(pool.config.WSEndpoint, account.ApiKey, account.SecretKey, account.Passphrase,
+		pool.config.IsPrint, pool.wsOptions(account, options)...)
+	ws.SetClock(pool.clients[name].Clock())
+	return ws, nil
+}
+
+/*
+ FuturesWS logged in as the account with the clock of its Client, on the pool's
+ WSEndpoint and simulated mode, debug mode if the pool's IsPrint is set
+*/
+func (pool *AccountPool) NewFuturesWS(name string, options ...WSOption) (*FuturesWS, error) {
+	account, ok := pool.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("okex: unknown account %s", name)
+	}
+	ws := NewFuturesWS(pool.config.WSEndpoint, account.ApiKey, account.SecretKey, account.Passphrase,
+		pool.config.IsPrint, pool.wsOptions(account, options)...)
+	ws.SetClock(pool.clients[name].Clock())
+	return ws, nil
+}
+
+/*
+ SpotWS logged in as the account with the clock of its Client, on the pool's
+ WSEndpoint and simulated mode, debug mode if the pool's IsPrint is set
+*/
+func (pool *AccountPool) NewSpotWS(name string, options ...WSOption) (*SpotWS, error) {
+	account, ok := pool.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("okex: unknown account %s", name)
+	}
+	ws := NewSpotWS(pool.config.WSEndpoint, account.ApiKey, account.SecretKey, account.Passphrase,
+		pool.config.IsPrint, pool.wsOptions(account, options)...)
+	ws.SetClock(pool.clients[name].Clock())
+	return ws, nil
+}
+
+/*
+ OptionWS logged in as the account with the clock of its Client, on the pool's
+ WSEndpoint and simulated mode, debug mode if the pool's IsPrint is set
+*/
+func (pool *AccountPool) NewOptionWS(name string, options ...WSOption) (*OptionWS, error) {
+	account, ok := pool.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("okex: unknown account %s", name)
+	}
+	ws := NewOptionWS(pool.config.WSEndpoint, account.ApiKey, account.SecretKey, account.Passphrase,
+		pool.config.IsPrint, pool.wsOptions(account, options)...)
+	ws.SetClock(pool.clients[name].Clock())
+	return ws, nil
+}
+
+func (pool *AccountPool) wsOptions(account AccountCredentials, options []WSOption) []WSOption {
+	var defaults []WSOption
+	if pool.config.Simulated {
+		defaults = append(defaults, WithWSSimulated())
+	}
+	if account.Signer != nil {
+		defaults = append(defaults, WithWSSigner(account.Signer))
+	}
+	if pool.config.Logger != nil {
+		defaults = append(defaults, WithWSLogger(pool.config.Logger))
+	}
+	return append(defaults, options...)
+}
+
+// AccountPoolError collects the errors of a ForEach call by account name
+type AccountPoolError struct {
+	Errors map[string]error
+}
+
+func (e *AccountPoolError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, name+": "+e.Errors[name].Error())
+	}
+	return fmt.Sprintf("okex: %d account(s) failed: %s", len(names), strings.Join(msgs, "; "))
+}
+
+/*
+ Run fn for every account concurrently and wait for all of them.
+ Returns the results of the succeeded accounts, and an *AccountPoolError if any failed.
+*/
+func (pool *AccountPool) ForEach(ctx context.Context,
+	fn func(ctx context.Context, name string, client *Client) (interface{}, error)) (map[string]interface{}, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]interface{})
+	errs := make(map[string]error)
+	for _, name := range pool.names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			result, err := fn(ctx, name, pool.clients[name])
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err
+				return
+			}
+			results[name] = result
+		}(name)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return results, &AccountPoolError{Errors: errs}
+	}
+	return results, nil
+}
+
+/*
+ GetSwapPositions of every account
+*/
+func (pool *AccountPool) GetSwapPositions(ctx context.Context) (map[string]*SwapPositionList, error) {
+	results, err := pool.ForEach(ctx, func(ctx context.Context, name string, client *Client) (interface{}, error) {
+		return client.GetSwapPositionsCtx(ctx)
+	})
+	positions := make(map[string]*SwapPositionList, len(results))
+	for name, result := range results {
+		positions[name] = result.(*SwapPositionList)
+	}
+	return positions, err
+}
+
+/*
+ GetFuturesAccounts of every account
+*/
+func (pool *AccountPool) GetFuturesAccounts(ctx context.Context) (map[string]GetFuturesAccountsResult, error) {
+	results, err := pool.ForEach(ctx, func(ctx context.Context, name string, client *Client) (interface{}, error) {
+		return client.GetFuturesAccountsCtx(ctx)
+	})
+	accounts := make(map[string]GetFuturesAccountsResult, len(results))
+	for name, result := range results {
+		accounts[name] = result.(GetFuturesAccountsResult)
+	}
+	return accounts, err
+}
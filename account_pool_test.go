@@ -0,0 +1,101 @@
+package okex
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAccountCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "okex-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "accounts.yaml")
+	ioutil.WriteFile(path, []byte(`
+accounts:
+  - name: main
+    access_key: "key-main"
+    secret_key: "secret-main"
+    passphrase: "pass-main"
+  - name: sub1
+    access_key: "key-sub1"
+    secret_key: "secret-sub1"
+    passphrase: "pass-sub1"
+`), 0600)
+	accounts, err := LoadAccountCredentials(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(accounts))
+	assert.Equal(t, "sub1", accounts[1].Name)
+	assert.Equal(t, "key-sub1", accounts[1].ApiKey)
+	assert.Equal(t, "pass-sub1", accounts[1].Passphrase)
+
+	accounts, err = LoadAccountCredentials("conf/test_config.example.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(accounts))
+	assert.Equal(t, "default", accounts[0].Name)
+}
+
+func TestAccountPool_ForEach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(OK_ACCESS_KEY) == "key-sub2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":30006,"message":"Invalid OK-ACCESS-KEY"}`))
+			return
+		}
+		w.Write([]byte(`[{"margin_mode":"crossed","timestamp":"2020-04-12T10:24:19.913Z","holding":[]}]`))
+	}))
+	defer server.Close()
+
+	var config Config
+	config.Endpoint = server.URL
+	pool, err := NewAccountPool(config, []AccountCredentials{
+		{Name: "sub2", ApiKey: "key-sub2", SecretKey: "s", Passphrase: "p"},
+		{Name: "main", ApiKey: "key-main", SecretKey: "s", Passphrase: "p"},
+		{Name: "sub1", ApiKey: "key-sub1", SecretKey: "s", Passphrase: "p"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"main", "sub1", "sub2"}, pool.Names())
+	assert.True(t, pool.Client("main").HttpClient == pool.Client("sub1").HttpClient)
+
+	positions, err := pool.GetSwapPositions(context.Background())
+	assert.Equal(t, 2, len(positions))
+	assert.Equal(t, "crossed", (*positions["sub1"])[0].MarginMode)
+	var poolErr *AccountPoolError
+	assert.True(t, errors.As(err, &poolErr))
+	assert.Equal(t, 1, len(poolErr.Errors))
+	assert.True(t, errors.Is(poolErr.Errors["sub2"], ErrAuth))
+
+	_, err = NewAccountPool(config, []AccountCredentials{{Name: "main"}, {Name: "main"}})
+	assert.Error(t, err)
+}
+
+func TestAccountPool_NewWS(t *testing.T) {
+	var config Config
+	config.Endpoint = "http://127.0.0.1:1/"
+	config.IsPrint = true
+	pool, err := NewAccountPool(config, []AccountCredentials{{Name: "main", ApiKey: "k", SecretKey: "s", Passphrase: "p"}})
+	assert.Nil(t, err)
+	clock := pool.Client("main").Clock()
+
+	swap, err := pool.NewSwapWS("main")
+	assert.Nil(t, err)
+	assert.True(t, swap.clock == clock)
+	assert.True(t, swap.debugMode)
+	futures, _ := pool.NewFuturesWS("main")
+	assert.True(t, futures.clock == clock)
+	spot, _ := pool.NewSpotWS("main")
+	assert.True(t, spot.clock == clock)
+	option, _ := pool.NewOptionWS("main")
+	assert.True(t, option.clock == clock)
+
+	_, err = pool.NewSwapWS("unknown")
+	assert.Error(t, err)
+}
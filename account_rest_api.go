@@ -1,5 +1,7 @@
 package okex
 
+import "context"
+
 /*
 获取平台所有币种列表。并非所有币种都可被用于交易。在ISO 4217标准中未被定义的币种代码可能使用的是自定义代码。
 
@@ -7,13 +9,17 @@ HTTP请求
 GET /api/account/v3/currencies
 
 */
-func (client *Client) GetAccountCurrencies() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountCurrencies() ([]AccountCurrency, error) {
+	return client.GetAccountCurrenciesCtx(context.Background())
+}
 
-	if _, _, err := client.Request(GET, ACCOUNT_CURRENCIES, nil, &r); err != nil {
+func (client *Client) GetAccountCurrenciesCtx(ctx context.Context) ([]AccountCurrency, error) {
+	var r []AccountCurrency
+
+	if _, _, err := client.RequestCtx(ctx, GET, ACCOUNT_CURRENCIES, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -23,13 +29,17 @@ func (client *Client) GetAccountCurrencies() (*[]map[string]interface{}, error)
 HTTP请求
 GET /api/account/v3/wallet
 */
-func (client *Client) GetAccountWallet() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountWallet() ([]AccountWallet, error) {
+	return client.GetAccountWalletCtx(context.Background())
+}
 
-	if _, _, err := client.Request(GET, ACCOUNT_WALLET, nil, &r); err != nil {
+func (client *Client) GetAccountWalletCtx(ctx context.Context) ([]AccountWallet, error) {
+	var r []AccountWallet
+
+	if _, _, err := client.RequestCtx(ctx, GET, ACCOUNT_WALLET, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -42,15 +52,19 @@ GET /api/account/v3/wallet/<currency>
 请求示例
 GET /api/account/v3/wallet/btc
 */
-func (client *Client) GetAccountWalletByCurrency(currency string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountWalletByCurrency(currency string) ([]AccountWallet, error) {
+	return client.GetAccountWalletByCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetAccountWalletByCurrencyCtx(ctx context.Context, currency string) ([]AccountWallet, error) {
+	var r []AccountWallet
 
 	uri := GetCurrencyUri(ACCOUNT_WALLET_CURRENCY, currency)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -60,8 +74,12 @@ func (client *Client) GetAccountWalletByCurrency(currency string) (*[]map[string
 HTTP请求
 GET /api/account/v3/wallet/<currency>
 */
-func (client *Client) GetAccountWithdrawalFeeByCurrency(currency *string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountWithdrawalFeeByCurrency(currency *string) ([]AccountWithdrawalFee, error) {
+	return client.GetAccountWithdrawalFeeByCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetAccountWithdrawalFeeByCurrencyCtx(ctx context.Context, currency *string) ([]AccountWithdrawalFee, error) {
+	var r []AccountWithdrawalFee
 
 	uri := ACCOUNT_WITHRAWAL_FEE
 	if currency != nil && len(*currency) > 0 {
@@ -70,10 +88,10 @@ func (client *Client) GetAccountWithdrawalFeeByCurrency(currency *string) (*[]ma
 		uri = BuildParams(uri, params)
 	}
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -82,13 +100,17 @@ func (client *Client) GetAccountWithdrawalFeeByCurrency(currency *string) (*[]ma
 HTTP请求
 GET /api/account/v3/withdrawal/history
 */
-func (client *Client) GetAccountWithdrawalHistory() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountWithdrawalHistory() ([]AccountWithdrawal, error) {
+	return client.GetAccountWithdrawalHistoryCtx(context.Background())
+}
+
+func (client *Client) GetAccountWithdrawalHistoryCtx(ctx context.Context) ([]AccountWithdrawal, error) {
+	var r []AccountWithdrawal
 
-	if _, _, err := client.Request(GET, ACCOUNT_WITHRAWAL_HISTORY, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, ACCOUNT_WITHRAWAL_HISTORY, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -97,15 +119,19 @@ func (client *Client) GetAccountWithdrawalHistory() (*[]map[string]interface{},
 HTTP请求
 GET /api/account/v3/withdrawal/history/<currency>
 */
-func (client *Client) GetAccountWithdrawalHistoryByCurrency(currency string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountWithdrawalHistoryByCurrency(currency string) ([]AccountWithdrawal, error) {
+	return client.GetAccountWithdrawalHistoryByCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetAccountWithdrawalHistoryByCurrencyCtx(ctx context.Context, currency string) ([]AccountWithdrawal, error) {
+	var r []AccountWithdrawal
 
 	uri := GetCurrencyUri(ACCOUNT_WITHRAWAL_HISTORY_CURRENCY, currency)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -118,17 +144,21 @@ GET /api/account/v3/deposit/address
 请求示例
 GET /api/account/v3/deposit/address?currency=btc
 */
-func (client *Client) GetAccountDepositAddress(currency string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountDepositAddress(currency string) ([]AccountDepositAddress, error) {
+	return client.GetAccountDepositAddressCtx(context.Background(), currency)
+}
+
+func (client *Client) GetAccountDepositAddressCtx(ctx context.Context, currency string) ([]AccountDepositAddress, error) {
+	var r []AccountDepositAddress
 	params := NewParams()
 	params["currency"] = currency
 
 	uri := BuildParams(ACCOUNT_DEPOSIT_ADDRESS, params)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -138,13 +168,17 @@ func (client *Client) GetAccountDepositAddress(currency string) (*[]map[string]i
 HTTP请求
 GET /api/account/v3/deposit/history
 */
-func (client *Client) GetAccountDepositHistory() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountDepositHistory() ([]AccountDeposit, error) {
+	return client.GetAccountDepositHistoryCtx(context.Background())
+}
 
-	if _, _, err := client.Request(GET, ACCOUNT_DEPOSIT_HISTORY, nil, &r); err != nil {
+func (client *Client) GetAccountDepositHistoryCtx(ctx context.Context) ([]AccountDeposit, error) {
+	var r []AccountDeposit
+
+	if _, _, err := client.RequestCtx(ctx, GET, ACCOUNT_DEPOSIT_HISTORY, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -154,15 +188,19 @@ func (client *Client) GetAccountDepositHistory() (*[]map[string]interface{}, err
 HTTP
 GET /api/account/v3/deposit/history/<currency>
 */
-func (client *Client) GetAccountDepositHistoryByCurrency(currency string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetAccountDepositHistoryByCurrency(currency string) ([]AccountDeposit, error) {
+	return client.GetAccountDepositHistoryByCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetAccountDepositHistoryByCurrencyCtx(ctx context.Context, currency string) ([]AccountDeposit, error) {
+	var r []AccountDeposit
 
 	uri := GetCurrencyUri(ACCOUNT_DEPOSIT_HISTORY_CURRENCY, currency)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -175,17 +213,18 @@ GET /api/account/v3/ledger
 请求示例
 GET /api/account/v3/ledger?type=2&currency=btc&from=4&limit=10
 */
-func (client *Client) GetAccountLeger(optionalParams *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-	uri := ACCOUNT_LEDGER
-	if optionalParams != nil && len(*optionalParams) > 0 {
-		uri = BuildParams(uri, *optionalParams)
-	}
+func (client *Client) GetAccountLeger(params *AccountLedgerParams) ([]AccountLedger, error) {
+	return client.GetAccountLegerCtx(context.Background(), params)
+}
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+func (client *Client) GetAccountLegerCtx(ctx context.Context, params *AccountLedgerParams) ([]AccountLedger, error) {
+	var r []AccountLedger
+	uri := pageUri(ACCOUNT_LEDGER, params.params())
+
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -195,26 +234,14 @@ func (client *Client) GetAccountLeger(optionalParams *map[string]string) (*[]map
 HTTP请求
 POST /api/account/v3/withdrawal
 */
-func (client *Client) PostAccountWithdrawal(
-	currency, to_address, trade_pwd string, destination int32, amount, fee float32) ([]byte, *map[string]interface{}, error) {
-
-	r := map[string]interface{}{}
-
-	withdrawlInfo := map[string]interface{}{}
-	withdrawlInfo["amount"] = amount
-	withdrawlInfo["destination"] = destination
-	withdrawlInfo["fee"] = fee
-	withdrawlInfo["currency"] = currency
-	withdrawlInfo["to_address"] = to_address
-	withdrawlInfo["trade_pwd"] = trade_pwd
-
-	var respBody []byte
-	var err error
-	if respBody, _, err = client.Request(GET, ACCOUNT_WITHRAWAL, withdrawlInfo, &r); err != nil {
-		return respBody, nil, err
-	}
+func (client *Client) PostAccountWithdrawal(params AccountWithdrawalParams) ([]byte, AccountWithdrawalResult, error) {
+	return client.PostAccountWithdrawalCtx(context.Background(), params)
+}
 
-	return respBody, &r, nil
+func (client *Client) PostAccountWithdrawalCtx(ctx context.Context, params AccountWithdrawalParams) ([]byte, AccountWithdrawalResult, error) {
+	var r AccountWithdrawalResult
+	respBody, _, err := client.RequestCtx(ctx, POST, ACCOUNT_WITHRAWAL, params, &r)
+	return respBody, r, err
 }
 
 /*
@@ -225,32 +252,12 @@ OKEx站内在钱包账户、交易账户和子账户之间进行资金划转。
 HTTP请求
 POST /api/account/v3/transfer
 */
-func (client *Client) PostAccountTransfer(
-	currency string, from, to int32, amount float32, optionalParams map[string]string) ([]byte, *map[string]interface{}, error) {
-
-	r := map[string]interface{}{}
-
-	transferInfo := map[string]interface{}{}
-	transferInfo["currency"] = currency
-	transferInfo["from"] = from
-	transferInfo["to"] = to
-	transferInfo["amount"] = amount
-
-	if optionalParams != nil && len(optionalParams) > 0 {
-		if from == 0 || to == 0 {
-			transferInfo["sub_account"] = optionalParams["sub_account"]
-		}
-		transferInfo["instrument_id"] = optionalParams["instrument_id"]
-		if to == 5 || to == 3 { // 杠杆转入币对 / usdt保证金合约underlying
-			transferInfo["to_instrument_id"] = optionalParams["to_instrument_id"]
-		}
-	}
-
-	var respBody []byte
-	var err error
-	if respBody, _, err = client.Request(POST, ACCOUNT_TRANSFER, transferInfo, &r); err != nil {
-		return respBody, nil, err
-	}
+func (client *Client) PostAccountTransfer(params AccountTransferParams) ([]byte, AccountTransferResult, error) {
+	return client.PostAccountTransferCtx(context.Background(), params)
+}
 
-	return respBody, &r, nil
+func (client *Client) PostAccountTransferCtx(ctx context.Context, params AccountTransferParams) ([]byte, AccountTransferResult, error) {
+	var r AccountTransferResult
+	respBody, _, err := client.RequestCtx(ctx, POST, ACCOUNT_TRANSFER, params, &r)
+	return respBody, r, err
 }
@@ -2,6 +2,8 @@ package okex
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -87,10 +89,7 @@ func TestGetAccountLeger(t *testing.T) {
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
-	optionals := NewParams()
-	optionals["type"] = "37"
-
-	ac, err = c.GetAccountLeger(&optionals)
+	ac, err = c.GetAccountLeger(&AccountLedgerParams{Type: "37"})
 	assert.True(t, err == nil)
 	jstr, _ = Struct2JsonString(ac)
 	println(jstr)
@@ -107,9 +106,9 @@ func TestGetAccountWalletByCurrency(t *testing.T) {
 
 func TestPostAccountWithdrawal(t *testing.T) {
 	c := NewTestClient()
-	_, ac, err := c.PostAccountWithdrawal("btc", "17DKe3kkkkiiiiTvAKKi2vMPbm1Bz3CMKw", "123456",
-		4, 1, 0.0005)
-	assert.True(t, ac != nil && err == nil)
+	_, ac, err := c.PostAccountWithdrawal(AccountWithdrawalParams{Currency: "btc", Amount: "1", Destination: "4",
+		ToAddress: "17DKe3kkkkiiiiTvAKKi2vMPbm1Bz3CMKw", TradePwd: "123456", Fee: "0.0005"})
+	assert.True(t, ac.Result && err == nil)
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
@@ -117,9 +116,72 @@ func TestPostAccountWithdrawal(t *testing.T) {
 
 func TestPostAccountTransfer(t *testing.T) {
 	c := NewTestClient()
-	_, ac, err := c.PostAccountTransfer("eos", 6, 5, 0.0001, nil)
-	assert.True(t, ac != nil && err == nil)
+	_, ac, err := c.PostAccountTransfer(AccountTransferParams{Currency: "eos", Amount: "0.0001", From: "6", To: "5", ToInstrumentId: "eos-usdt"})
+	assert.True(t, ac.Result && err == nil)
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
 }
+
+func TestClient_AccountTypedResults(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ACCOUNT_WALLET:
+			w.Write([]byte(`[{"available":"37.11827078","balance":"37.11827078","currency":"EOS","hold":"0"}]`))
+		case ACCOUNT_LEDGER:
+			assert.Equal(t, "btc", r.URL.Query().Get("currency"))
+			assert.Equal(t, "37", r.URL.Query().Get("type"))
+			assert.Equal(t, "", r.URL.Query().Get("limit"))
+			w.Write([]byte(`[{"amount":"0.00051843","balance":"0.00100941","currency":"BTC","fee":"0.00000000",` +
+				`"ledger_id":"8987285","timestamp":"2018-10-12T11:01:14.000Z","typename":"Get from activity"}]`))
+		case ACCOUNT_DEPOSIT_HISTORY:
+			w.Write([]byte(`[{"amount":"0.01044408","txid":"1915737_3_0_0_WALLET","currency":"BTC","to":"","deposit_id":1,` +
+				`"timestamp":"2018-09-30T02:45:50.000Z","status":"2"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	wallet, err := c.GetAccountWallet()
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("37.11827078"), wallet[0].Available)
+
+	ledger, err := c.GetAccountLeger(&AccountLedgerParams{Currency: "btc", Type: "37"})
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.00051843"), ledger[0].Amount)
+	assert.Equal(t, "Get from activity", ledger[0].Typename)
+
+	deposits, err := c.GetAccountDepositHistory()
+	assert.Nil(t, err)
+	assert.Equal(t, "2", deposits[0].Status.String())
+	assert.Equal(t, "1", deposits[0].DepositID.String())
+}
+
+func TestClient_AccountTypedParams(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case POST + " " + ACCOUNT_WITHRAWAL:
+			assert.Equal(t, `{"currency":"btc","amount":"0.1","destination":"4","to_address":"17DKe3kkkkiiiiTvAKKi2vMPbm1Bz3CMKw",`+
+				`"trade_pwd":"123456","fee":"0.0005"}`, string(body))
+			w.Write([]byte(`{"amount":"0.1","withdrawal_id":"67485","currency":"btc","result":true}`))
+		case POST + " " + ACCOUNT_TRANSFER:
+			assert.Equal(t, `{"currency":"usdt","amount":"0.1","from":"6","to":"5","to_instrument_id":"btc-usdt"}`, string(body))
+			w.Write([]byte(`{"transfer_id":"754147","currency":"usdt","from":"6","amount":"0.1","to":"5","result":true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	_, withdrawal, err := c.PostAccountWithdrawal(AccountWithdrawalParams{Currency: "btc", Amount: "0.1", Destination: "4",
+		ToAddress: "17DKe3kkkkiiiiTvAKKi2vMPbm1Bz3CMKw", TradePwd: "123456", Fee: "0.0005"})
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.1"), withdrawal.Amount)
+
+	_, transfer, err := c.PostAccountTransfer(AccountTransferParams{Currency: "usdt", Amount: "0.1", From: "6", To: "5",
+		ToInstrumentId: "btc-usdt"})
+	assert.Nil(t, err)
+	assert.True(t, transfer.Result)
+}
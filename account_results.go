@@ -0,0 +1,94 @@
+package okex
+
+import (
+	sjson "encoding/json"
+	"time"
+)
+
+/*
+ OKEX account (wallet) api results
+*/
+
+type AccountCurrency struct {
+	Currency      string       `json:"currency"`
+	Name          string       `json:"name"`
+	CanDeposit    sjson.Number `json:"can_deposit"`  // 1: 可充值 0: 不可充值
+	CanWithdraw   sjson.Number `json:"can_withdraw"` // 1: 可提币 0: 不可提币
+	MinWithdrawal Decimal      `json:"min_withdrawal"`
+}
+
+type AccountWallet struct {
+	Currency  string  `json:"currency"`
+	Balance   Decimal `json:"balance"`
+	Hold      Decimal `json:"hold"`
+	Available Decimal `json:"available"`
+}
+
+type AccountWithdrawalFee struct {
+	Currency string  `json:"currency"`
+	MinFee   Decimal `json:"min_fee"`
+	MaxFee   Decimal `json:"max_fee"`
+}
+
+type AccountWithdrawal struct {
+	WithdrawalID sjson.Number `json:"withdrawal_id"`
+	Currency     string       `json:"currency"`
+	Amount       Decimal      `json:"amount"`
+	Fee          string       `json:"fee"` // eg: 0.01000000eos
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	Tag          string       `json:"tag"`
+	PaymentID    string       `json:"payment_id"`
+	Memo         string       `json:"memo"`
+	Txid         string       `json:"txid"`
+	Timestamp    time.Time    `json:"timestamp"`
+	Status       sjson.Number `json:"status"` // -3:撤销中 -2:已撤销 -1:失败 0:等待提现 1:提现中 2:已汇出 3:邮箱确认 4:人工审核中 5:等待身份认证
+}
+
+type AccountDepositAddress struct {
+	Address     string       `json:"address"`
+	Tag         string       `json:"tag"`
+	PaymentID   string       `json:"payment_id"`
+	Memo        string       `json:"memo"`
+	Currency    string       `json:"currency"`
+	CanDeposit  sjson.Number `json:"can_deposit"`
+	CanWithdraw sjson.Number `json:"can_withdraw"`
+	To          sjson.Number `json:"to"` // 1: 币币 6: 资金账户
+}
+
+type AccountDeposit struct {
+	DepositID sjson.Number `json:"deposit_id"`
+	Currency  string       `json:"currency"`
+	Amount    Decimal      `json:"amount"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Txid      string       `json:"txid"`
+	Timestamp time.Time    `json:"timestamp"`
+	Status    sjson.Number `json:"status"` // 0:等待确认 1:确认到账 2:充值成功
+}
+
+type AccountLedger struct {
+	LedgerID  string    `json:"ledger_id"`
+	Currency  string    `json:"currency"`
+	Balance   Decimal   `json:"balance"`
+	Amount    Decimal   `json:"amount"`
+	Fee       Decimal   `json:"fee"`
+	Typename  string    `json:"typename"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type AccountWithdrawalResult struct {
+	WithdrawalID sjson.Number `json:"withdrawal_id"`
+	Currency     string       `json:"currency"`
+	Amount       Decimal      `json:"amount"`
+	Result       bool         `json:"result"`
+}
+
+type AccountTransferResult struct {
+	TransferID sjson.Number `json:"transfer_id"`
+	Currency   string       `json:"currency"`
+	From       sjson.Number `json:"from"`
+	To         sjson.Number `json:"to"`
+	Amount     Decimal      `json:"amount"`
+	Result     bool         `json:"result"`
+}
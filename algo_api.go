@@ -0,0 +1,153 @@
+package okex
+
+/*
+ OKEX swap, futures and spot algo orders (策略委托): trigger, trail, iceberg and twap.
+
+	result, err := client.PostSwapAlgoOrder(okex.AlgoOrderParams{
+		InstrumentId: "BTC-USD-SWAP", OrderType: okex.AlgoOrderTypeTrigger, Type: "3", Size: "1",
+		TriggerPrice: "9000", AlgoType: okex.AlgoTypeMarket,
+	})
+
+ The params are checked before sending, and the trigger price and size against
+ the instrument registry when one is set (@see Client.ValidateOrdersWith).
+ An answer with a result other than success returns its result and an error.
+
+ 限速规则：下单 40次/2s，撤单 20次/2s，查询 20次/2s
+*/
+
+import (
+	"context"
+	"fmt"
+)
+
+/*
+ Swap
+*/
+
+func (client *Client) PostSwapAlgoOrder(params AlgoOrderParams) (AlgoOrderResult, error) {
+	return client.PostSwapAlgoOrderCtx(context.Background(), params)
+}
+
+func (client *Client) PostSwapAlgoOrderCtx(ctx context.Context, params AlgoOrderParams) (AlgoOrderResult, error) {
+	return client.postAlgoOrder(ctx, SWAP_ORDER_ALGO, params, false)
+}
+
+func (client *Client) CancelSwapAlgoOrders(params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.CancelSwapAlgoOrdersCtx(context.Background(), params)
+}
+
+func (client *Client) CancelSwapAlgoOrdersCtx(ctx context.Context, params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.cancelAlgoOrders(ctx, SWAP_CANCEL_ALGOS, params)
+}
+
+// The algo orders of the instrument, newest first
+func (client *Client) GetSwapAlgoOrders(instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	return client.GetSwapAlgoOrdersCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetSwapAlgoOrdersCtx(ctx context.Context, instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	return client.getAlgoOrders(ctx, GetInstrumentIdUri(SWAP_INSTRUMENT_ORDER_ALGO, instrumentId), params.params())
+}
+
+/*
+ Futures
+*/
+
+func (client *Client) PostFuturesAlgoOrder(params AlgoOrderParams) (AlgoOrderResult, error) {
+	return client.PostFuturesAlgoOrderCtx(context.Background(), params)
+}
+
+func (client *Client) PostFuturesAlgoOrderCtx(ctx context.Context, params AlgoOrderParams) (AlgoOrderResult, error) {
+	return client.postAlgoOrder(ctx, FUTURES_ORDER_ALGO, params, false)
+}
+
+func (client *Client) CancelFuturesAlgoOrders(params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.CancelFuturesAlgoOrdersCtx(context.Background(), params)
+}
+
+func (client *Client) CancelFuturesAlgoOrdersCtx(ctx context.Context, params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.cancelAlgoOrders(ctx, FUTURES_CANCEL_ALGOS, params)
+}
+
+// The algo orders of the instrument, newest first
+func (client *Client) GetFuturesAlgoOrders(instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	return client.GetFuturesAlgoOrdersCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetFuturesAlgoOrdersCtx(ctx context.Context, instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	return client.getAlgoOrders(ctx, GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_ALGO, instrumentId), params.params())
+}
+
+/*
+ Spot, and margin with Mode 2
+*/
+
+func (client *Client) PostSpotAlgoOrder(params AlgoOrderParams) (AlgoOrderResult, error) {
+	return client.PostSpotAlgoOrderCtx(context.Background(), params)
+}
+
+func (client *Client) PostSpotAlgoOrderCtx(ctx context.Context, params AlgoOrderParams) (AlgoOrderResult, error) {
+	if params.Mode == "" {
+		params.Mode = "1"
+	}
+	return client.postAlgoOrder(ctx, SPOT_ORDER_ALGO, params, true)
+}
+
+func (client *Client) CancelSpotAlgoOrders(params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.CancelSpotAlgoOrdersCtx(context.Background(), params)
+}
+
+func (client *Client) CancelSpotAlgoOrdersCtx(ctx context.Context, params AlgoCancelParams) (AlgoCancelResult, error) {
+	return client.cancelAlgoOrders(ctx, SPOT_CANCEL_BATCH_ALGOS, params)
+}
+
+// The algo orders of the instrument, newest first
+func (client *Client) GetSpotAlgoOrders(instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	return client.GetSpotAlgoOrdersCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetSpotAlgoOrdersCtx(ctx context.Context, instrumentId string, params *AlgoListParams) ([]AlgoOrder, error) {
+	query := params.params()
+	query["instrument_id"] = instrumentId
+	return client.getAlgoOrders(ctx, SPOT_ALGO, query)
+}
+
+func (client *Client) postAlgoOrder(ctx context.Context, uri string, params AlgoOrderParams, spot bool) (AlgoOrderResult, error) {
+	if err := params.validate(spot); err != nil {
+		return AlgoOrderResult{}, err
+	}
+	if err := client.validateOrder(ctx, params.InstrumentId, params.TriggerPrice, params.Size, ""); err != nil {
+		return AlgoOrderResult{}, err
+	}
+	body, _, err := client.RequestCtx(ctx, POST, uri, params, nil)
+	if err != nil {
+		return AlgoOrderResult{}, err
+	}
+	result := parseAlgoOrderResult(body)
+	if !result.Result {
+		return result, fmt.Errorf("okex: algo order of %s failed, error code %s, message %q",
+			params.InstrumentId, result.ErrorCode, result.ErrorMessage)
+	}
+	return result, nil
+}
+
+func (client *Client) cancelAlgoOrders(ctx context.Context, uri string, params AlgoCancelParams) (AlgoCancelResult, error) {
+	body, _, err := client.RequestCtx(ctx, POST, uri, params, nil)
+	if err != nil {
+		return AlgoCancelResult{}, err
+	}
+	result := parseAlgoCancelResult(body)
+	if !result.Result {
+		return result, fmt.Errorf("okex: cancel algo orders of %s failed, error code %s, message %q",
+			params.InstrumentId, result.ErrorCode, result.ErrorMessage)
+	}
+	return result, nil
+}
+
+func (client *Client) getAlgoOrders(ctx context.Context, uri string, params map[string]string) ([]AlgoOrder, error) {
+	body, _, err := client.RequestCtx(ctx, GET, BuildParams(uri, params), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseAlgoOrders(body)
+}
@@ -0,0 +1,112 @@
+package okex
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient_SwapAlgoOrders(t *testing.T) {
+	requests := 0
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case SWAP_ORDER_ALGO:
+			assert.Equal(t, "9000", gjson.GetBytes(body, "trigger_price").String())
+			assert.False(t, gjson.GetBytes(body, "callback_rate").Exists())
+			w.Write([]byte(`{"code":"0","data":{"algo_id":"314","instrument_id":"BTC-USD-SWAP","order_type":"1","result":"success"},` +
+				`"detailMsg":"","error_code":"0","error_message":"","msg":""}`))
+		case SWAP_CANCEL_ALGOS:
+			assert.Equal(t, "314", gjson.GetBytes(body, "algo_ids.0").String())
+			w.Write([]byte(`{"code":"0","data":{"algo_ids":"314,315","instrument_id":"BTC-USD-SWAP","order_type":"1","result":"success"},` +
+				`"error_code":"0","error_message":""}`))
+		case "/api/swap/v3/order_algo/BTC-USD-SWAP":
+			assert.Equal(t, "1", r.URL.Query().Get("order_type"))
+			assert.Equal(t, "2", r.URL.Query().Get("status"))
+			w.Write([]byte(`{"orderStrategyVOS":[{"algo_id":"314","instrument_id":"BTC-USD-SWAP","order_type":"1","status":"2",` +
+				`"size":"1","trigger_price":"9000","algo_type":"2","order_id":"66","real_price":"8999.5","real_amount":"1","type":"3"},` +
+				`{"algo_id":"315","instrument_id":"BTC-USD-SWAP","order_type":"1","status":"1","order_id":"-1","type":"3"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	_, err := c.PostSwapAlgoOrder(AlgoOrderParams{InstrumentId: "BTC-USD-SWAP", OrderType: AlgoOrderTypeTrigger, Type: "3",
+		Size: "1", AlgoType: AlgoTypeLimit, TriggerPrice: "9000"})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	_, err = c.PostSwapAlgoOrder(AlgoOrderParams{InstrumentId: "BTC-USD-SWAP", OrderType: AlgoOrderTypeTrail, Size: "1",
+		CallbackRate: "0.01", TriggerPrice: "9000"})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Equal(t, 0, requests)
+
+	result, err := c.PostSwapAlgoOrder(AlgoOrderParams{InstrumentId: "BTC-USD-SWAP", OrderType: AlgoOrderTypeTrigger, Type: "3",
+		Size: "1", AlgoType: AlgoTypeMarket, TriggerPrice: "9000"})
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoOrderResult{InstrumentId: "BTC-USD-SWAP", AlgoId: "314", OrderType: "1", Result: true}, result)
+
+	cancelled, err := c.CancelSwapAlgoOrders(AlgoCancelParams{InstrumentId: "BTC-USD-SWAP", AlgoIds: []string{"314", "315"},
+		OrderType: AlgoOrderTypeTrigger})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"314", "315"}, cancelled.AlgoIds)
+
+	orders, err := c.GetSwapAlgoOrders("BTC-USD-SWAP", &AlgoListParams{OrderType: AlgoOrderTypeTrigger, Status: AlgoStatusEffective})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(orders))
+	assert.True(t, orders[0].Triggered())
+	assert.True(t, orders[0].Done())
+	assert.Equal(t, "66", orders[0].OrderId)
+	assert.Equal(t, Decimal("8999.5"), orders[0].RealPrice)
+	assert.True(t, orders[1].Pending())
+	assert.Equal(t, "", orders[1].OrderId)
+}
+
+func TestClient_FuturesAndSpotAlgoOrders(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FUTURES_ORDER_ALGO:
+			w.Write([]byte(`{"algo_id":"","instrument_id":"BTC-USD-200925","order_type":"3","result":false,` +
+				`"error_code":"32015","error_message":"Risk rate lower than 100% before opening position"}`))
+		case "/api/futures/v3/order_algo/BTC-USD-200925":
+			w.Write([]byte(`{"iceberg":[{"algo_id":"7","instrument_id":"BTC-USD-200925","order_type":3,"status":4,` +
+				`"algo_variance":"0.001","avg_amount":"10","price_limit":"9500","size":"100"}]}`))
+		case SPOT_ORDER_ALGO:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "1", gjson.GetBytes(body, "mode").String())
+			assert.Equal(t, "buy", gjson.GetBytes(body, "side").String())
+			w.Write([]byte(`{"algo_id":"8","client_oid":"","instrument_id":"BTC-USDT","order_type":"4","result":true,"error_code":"0"}`))
+		case SPOT_ALGO:
+			assert.Equal(t, "BTC-USDT", r.URL.Query().Get("instrument_id"))
+			w.Write([]byte(`{"twap":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.PostFuturesAlgoOrder(AlgoOrderParams{InstrumentId: "BTC-USD-200925", OrderType: AlgoOrderTypeIceberg, Type: "1",
+		Size: "100", AlgoVariance: "0.001", AvgAmount: "10", PriceLimit: "9500"})
+	assert.NotNil(t, err)
+	assert.False(t, result.Result)
+	assert.Equal(t, "32015", result.ErrorCode)
+
+	orders, err := c.GetFuturesAlgoOrders("BTC-USD-200925", &AlgoListParams{OrderType: AlgoOrderTypeIceberg, Status: AlgoStatusPartiallyEffective})
+	assert.Nil(t, err)
+	assert.Equal(t, "3", orders[0].OrderType)
+	assert.True(t, orders[0].Triggered())
+	assert.False(t, orders[0].Done())
+
+	result, err = c.PostSpotAlgoOrder(AlgoOrderParams{InstrumentId: "BTC-USDT", OrderType: AlgoOrderTypeTWAP, Side: "buy", Size: "1",
+		SweepRange: "0.001", SweepRatio: "0.1", SingleLimit: "0.1", PriceLimit: "10000", TimeInterval: "10"})
+	assert.Nil(t, err)
+	assert.Equal(t, "8", result.AlgoId)
+
+	orders, err = c.GetSpotAlgoOrders("BTC-USDT", &AlgoListParams{OrderType: AlgoOrderTypeTWAP, Status: AlgoStatusPending})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(orders))
+}
@@ -0,0 +1,146 @@
+package okex
+
+/*
+ OKEX swap, futures and spot algo (策略委托) order request params
+*/
+
+import "fmt"
+
+const (
+	AlgoOrderTypeTrigger = "1" // 计划委托
+	AlgoOrderTypeTrail   = "2" // 跟踪委托
+	AlgoOrderTypeIceberg = "3" // 冰山委托
+	AlgoOrderTypeTWAP    = "4" // 时间加权委托
+)
+
+const (
+	AlgoStatusPending            = "1" // 待生效
+	AlgoStatusEffective          = "2" // 已生效
+	AlgoStatusCancelled          = "3" // 已撤销
+	AlgoStatusPartiallyEffective = "4" // 部分生效
+	AlgoStatusPaused             = "5" // 暂停生效
+	AlgoStatusFailed             = "6" // 委托失败
+)
+
+const (
+	AlgoTypeLimit  = "1"
+	AlgoTypeMarket = "2"
+)
+
+/*
+ An algo order. Size is in contracts for swap/futures and in base currency for spot.
+
+ Type (swap/futures): 1 open long, 2 open short, 3 close long, 4 close short.
+ Side (spot): buy, sell. Mode (spot): 1 spot, 2 margin, default 1.
+
+ Fields by OrderType:
+  trigger: TriggerPrice, AlgoPrice (the order price once triggered), AlgoType (1 limit, 2 market)
+  trail:   CallbackRate (0.001-0.05), TriggerPrice (activation price)
+  iceberg: AlgoVariance (0.0001-0.01), AvgAmount, PriceLimit
+  twap:    SweepRange (0.00005-0.01), SweepRatio (0.01-1), SingleLimit, PriceLimit, TimeInterval (5-120 seconds)
+*/
+type AlgoOrderParams struct {
+	InstrumentId string  `json:"instrument_id"`
+	OrderType    string  `json:"order_type"`
+	Type         string  `json:"type,omitempty"`
+	Side         string  `json:"side,omitempty"`
+	Mode         string  `json:"mode,omitempty"`
+	Size         Decimal `json:"size"`
+
+	TriggerPrice Decimal `json:"trigger_price,omitempty"`
+	AlgoPrice    Decimal `json:"algo_price,omitempty"`
+	AlgoType     string  `json:"algo_type,omitempty"`
+
+	CallbackRate Decimal `json:"callback_rate,omitempty"`
+
+	AlgoVariance Decimal `json:"algo_variance,omitempty"`
+	AvgAmount    Decimal `json:"avg_amount,omitempty"`
+	PriceLimit   Decimal `json:"price_limit,omitempty"`
+
+	SweepRange   Decimal `json:"sweep_range,omitempty"`
+	SweepRatio   Decimal `json:"sweep_ratio,omitempty"`
+	SingleLimit  Decimal `json:"single_limit,omitempty"`
+	TimeInterval string  `json:"time_interval,omitempty"`
+}
+
+/*
+ Check the fields required by the order type are set, spot orders need Side
+ and swap/futures orders Type. The error wraps ErrInvalidOrder.
+*/
+func (p AlgoOrderParams) validate(spot bool) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s: algo order %s", ErrInvalidOrder, p.InstrumentId, fmt.Sprintf(format, args...))
+	}
+	if p.InstrumentId == "" {
+		return invalid("without instrument_id")
+	}
+	if spot && p.Side != "buy" && p.Side != "sell" {
+		return invalid("illegal side %q", p.Side)
+	}
+	if !spot && p.Type != "1" && p.Type != "2" && p.Type != "3" && p.Type != "4" {
+		return invalid("illegal type %q", p.Type)
+	}
+	type field struct {
+		name  string
+		value Decimal
+	}
+	required := []field{{"size", p.Size}}
+	switch p.OrderType {
+	case AlgoOrderTypeTrigger:
+		required = append(required, field{"trigger_price", p.TriggerPrice})
+		if p.AlgoType != AlgoTypeMarket {
+			required = append(required, field{"algo_price", p.AlgoPrice})
+		}
+	case AlgoOrderTypeTrail:
+		required = append(required, field{"callback_rate", p.CallbackRate}, field{"trigger_price", p.TriggerPrice})
+	case AlgoOrderTypeIceberg:
+		required = append(required, field{"algo_variance", p.AlgoVariance}, field{"avg_amount", p.AvgAmount},
+			field{"price_limit", p.PriceLimit})
+	case AlgoOrderTypeTWAP:
+		if p.TimeInterval == "" {
+			return invalid("without time_interval")
+		}
+		required = append(required, field{"sweep_range", p.SweepRange}, field{"sweep_ratio", p.SweepRatio},
+			field{"single_limit", p.SingleLimit}, field{"price_limit", p.PriceLimit})
+	default:
+		return invalid("illegal order_type %q", p.OrderType)
+	}
+	for _, f := range required {
+		if !f.value.Valid() || f.value.Sign() <= 0 {
+			return invalid("illegal %s %q", f.name, f.value)
+		}
+	}
+	return nil
+}
+
+// Algo orders of one instrument and order type to cancel, max 10 (swap/futures) or 6 (spot) ids
+type AlgoCancelParams struct {
+	InstrumentId string   `json:"instrument_id"`
+	AlgoIds      []string `json:"algo_ids"`
+	OrderType    string   `json:"order_type"`
+}
+
+/*
+ Query of the algo order list. OrderType is required, and one of Status and AlgoId.
+*/
+type AlgoListParams struct {
+	CursorPage
+	OrderType string
+	Status    string
+	AlgoId    string
+}
+
+func (p *AlgoListParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	params["order_type"] = p.OrderType
+	if p.Status != "" {
+		params["status"] = p.Status
+	}
+	if p.AlgoId != "" {
+		params["algo_id"] = p.AlgoId
+	}
+	return params
+}
@@ -0,0 +1,212 @@
+package okex
+
+/*
+ OKEX swap, futures and spot algo order results
+*/
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Result of placing an algo order
+type AlgoOrderResult struct {
+	InstrumentId string `json:"instrument_id"`
+	AlgoId       string `json:"algo_id"`
+	OrderType    string `json:"order_type"`
+	ClientOid    string `json:"client_oid"`
+	Result       bool   `json:"result"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// Result of cancelling algo orders
+type AlgoCancelResult struct {
+	InstrumentId string   `json:"instrument_id"`
+	AlgoIds      []string `json:"algo_ids"`
+	OrderType    string   `json:"order_type"`
+	Result       bool     `json:"result"`
+	ErrorCode    string   `json:"error_code"`
+	ErrorMessage string   `json:"error_message"`
+}
+
+/*
+ An algo order of the order list. OrderId, RealPrice and RealAmount are set
+ once the algo order has placed its order, @see Triggered.
+*/
+type AlgoOrder struct {
+	InstrumentId string  `json:"instrument_id"`
+	AlgoId       string  `json:"algo_id"`
+	OrderType    string  `json:"order_type"`
+	Type         string  `json:"type"` // swap/futures
+	Side         string  `json:"side"` // spot
+	Mode         string  `json:"mode"` // spot
+	Status       string  `json:"status"`
+	Size         Decimal `json:"size"`
+	Leverage     Decimal `json:"leverage"`
+	TriggerPrice Decimal `json:"trigger_price"`
+	AlgoPrice    Decimal `json:"algo_price"`
+	AlgoType     string  `json:"algo_type"`
+	CallbackRate Decimal `json:"callback_rate"`
+	AlgoVariance Decimal `json:"algo_variance"`
+	AvgAmount    Decimal `json:"avg_amount"`
+	PriceLimit   Decimal `json:"price_limit"`
+	SweepRange   Decimal `json:"sweep_range"`
+	SweepRatio   Decimal `json:"sweep_ratio"`
+	SingleLimit  Decimal `json:"single_limit"`
+	TimeInterval string  `json:"time_interval"`
+	OrderId      string  `json:"order_id"`
+	RealPrice    Decimal `json:"real_price"`
+	RealAmount   Decimal `json:"real_amount"`
+	Timestamp    string  `json:"timestamp"`
+	RejectedTime string  `json:"rejected_time"`
+}
+
+// Status and type fields come as json strings or numbers
+func (o *AlgoOrder) UnmarshalJSON(data []byte) error {
+	row := gjson.ParseBytes(data)
+	s := func(key string) string { return row.Get(key).String() }
+	d := func(key string) Decimal { return Decimal(row.Get(key).String()) }
+	*o = AlgoOrder{
+		InstrumentId: s("instrument_id"),
+		AlgoId:       s("algo_id"),
+		OrderType:    s("order_type"),
+		Type:         s("type"),
+		Side:         s("side"),
+		Mode:         s("mode"),
+		Status:       s("status"),
+		Size:         d("size"),
+		Leverage:     d("leverage"),
+		TriggerPrice: d("trigger_price"),
+		AlgoPrice:    d("algo_price"),
+		AlgoType:     s("algo_type"),
+		CallbackRate: d("callback_rate"),
+		AlgoVariance: d("algo_variance"),
+		AvgAmount:    d("avg_amount"),
+		PriceLimit:   d("price_limit"),
+		SweepRange:   d("sweep_range"),
+		SweepRatio:   d("sweep_ratio"),
+		SingleLimit:  d("single_limit"),
+		TimeInterval: s("time_interval"),
+		OrderId:      s("order_id"),
+		RealPrice:    d("real_price"),
+		RealAmount:   d("real_amount"),
+		Timestamp:    s("timestamp"),
+		RejectedTime: s("rejected_time"),
+	}
+	// a trigger order not triggered yet reports order_id "-1" or "0"
+	if o.OrderId == "-1" || o.OrderId == "0" {
+		o.OrderId = ""
+	}
+	return nil
+}
+
+// Waiting for its trigger (pending or paused)
+func (o AlgoOrder) Pending() bool {
+	return o.Status == AlgoStatusPending || o.Status == AlgoStatusPaused
+}
+
+// Triggered, it has placed (part of) its order
+func (o AlgoOrder) Triggered() bool {
+	return o.Status == AlgoStatusEffective || o.Status == AlgoStatusPartiallyEffective
+}
+
+// Done: effective, cancelled or failed, the status won't change any more
+func (o AlgoOrder) Done() bool {
+	return o.Status == AlgoStatusEffective || o.Status == AlgoStatusCancelled || o.Status == AlgoStatusFailed
+}
+
+/*
+ The algo api answers in several forms, eg:
+	{"code":"0","data":{"algo_id":"1","instrument_id":"BTC-USD-SWAP","order_type":"1","result":"success"},"error_code":"0"}
+	{"algo_id":"1","instrument_id":"BTC-USD-200925","order_type":"1","result":true,"error_code":"0"}
+ algoData returns the object holding the result.
+*/
+func algoData(body []byte) gjson.Result {
+	ret := gjson.ParseBytes(body)
+	if data := ret.Get("data"); data.IsObject() {
+		return data
+	}
+	return ret
+}
+
+// "success", "true" and true are a success
+func algoSuccess(result gjson.Result) bool {
+	return result.String() == "success" || result.Bool()
+}
+
+// The first error code and message of the answer which is not 0
+func algoError(body []byte, data gjson.Result) (string, string) {
+	ret := gjson.ParseBytes(body)
+	for _, r := range []gjson.Result{data, ret} {
+		if code := r.Get("error_code").String(); code != "" && code != "0" {
+			return code, r.Get("error_message").String()
+		}
+		if code := r.Get("code").String(); code != "" && code != "0" {
+			return code, r.Get("message").String() + r.Get("msg").String()
+		}
+	}
+	return "", ""
+}
+
+func parseAlgoOrderResult(body []byte) AlgoOrderResult {
+	data := algoData(body)
+	r := AlgoOrderResult{
+		InstrumentId: data.Get("instrument_id").String(),
+		AlgoId:       data.Get("algo_id").String(),
+		OrderType:    data.Get("order_type").String(),
+		ClientOid:    data.Get("client_oid").String(),
+	}
+	r.ErrorCode, r.ErrorMessage = algoError(body, data)
+	r.Result = algoSuccess(data.Get("result")) && r.ErrorCode == ""
+	return r
+}
+
+func parseAlgoCancelResult(body []byte) AlgoCancelResult {
+	data := algoData(body)
+	r := AlgoCancelResult{
+		InstrumentId: data.Get("instrument_id").String(),
+		OrderType:    data.Get("order_type").String(),
+	}
+	// an array, or comma separated ids
+	ids := data.Get("algo_ids")
+	if ids.IsArray() {
+		for _, id := range ids.Array() {
+			r.AlgoIds = append(r.AlgoIds, id.String())
+		}
+	} else if ids.String() != "" {
+		r.AlgoIds = strings.Split(ids.String(), ",")
+	}
+	r.ErrorCode, r.ErrorMessage = algoError(body, data)
+	r.Result = algoSuccess(data.Get("result")) && r.ErrorCode == ""
+	return r
+}
+
+/*
+ The order list is keyed by the api:
+	swap:         {"orderStrategyVOS":[...]}
+	futures/spot: {"trigger":[...]}, {"track":[...]}, {"iceberg":[...]}, {"twap":[...]}
+*/
+func parseAlgoOrders(body []byte) ([]AlgoOrder, error) {
+	rows := gjson.ParseBytes(body)
+	if !rows.IsArray() {
+		ret := rows
+		rows = gjson.Result{}
+		for _, key := range []string{"orderStrategyVOS", "trigger", "track", "iceberg", "twap"} {
+			if v := ret.Get(key); v.IsArray() {
+				rows = v
+				break
+			}
+		}
+	}
+	var orders []AlgoOrder
+	for _, row := range rows.Array() {
+		var o AlgoOrder
+		if err := o.UnmarshalJSON([]byte(row.Raw)); err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
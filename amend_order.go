@@ -0,0 +1,344 @@
+package okex
+
+/*
+ Order amendment (修改订单): change the price and/or size of an open order in
+ place, keeping its queue position when only the size goes down.
+
+	result, err := client.AmendSwapOrder("BTC-USD-SWAP", okex.AmendOrderParams{OrderId: id, NewPrice: "9001.5"})
+
+ AmendOrReplace* cancel the order and place a new one instead when the
+ instrument does not support amending, and remember that for the instrument.
+ The new order keeps the side, type, order_type and client_oid of the old one,
+ margin orders are replaced by AmendOrReplaceMarginOrder.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+)
+
+/*
+ Error codes answered for an instrument without order amendment, a 404 counts as well. 可按需追加
+  30037: endpoint is offline or unavailable (接口已下线或无法使用)
+*/
+var AmendUnsupportedCodes = map[int]bool{
+	30037: true,
+}
+
+/*
+ Amend an open order, by OrderId or ClientOid. NewSize is the whole size of the
+ amended order including the filled part. At least one of NewPrice and NewSize.
+ CancelOnFail "1" cancels the order when the amendment fails.
+ RequestId is returned in the result.
+ MatchPrice is only used by the replacement of a swap or futures order, the
+ order details do not report it.
+*/
+type AmendOrderParams struct {
+	OrderId      string  `json:"order_id,omitempty"`
+	ClientOid    string  `json:"client_oid,omitempty"`
+	NewPrice     Decimal `json:"new_price,omitempty"`
+	NewSize      Decimal `json:"new_size,omitempty"`
+	CancelOnFail string  `json:"cancel_on_fail,omitempty"`
+	RequestId    string  `json:"request_id,omitempty"`
+	MatchPrice   string  `json:"-"`
+}
+
+func (p AmendOrderParams) id() string {
+	if p.OrderId != "" {
+		return p.OrderId
+	}
+	return p.ClientOid
+}
+
+func (p AmendOrderParams) validate(instrumentId string) error {
+	if p.OrderId == "" && p.ClientOid == "" {
+		return fmt.Errorf("%w: %s: amend without order_id or client_oid", ErrInvalidOrder, instrumentId)
+	}
+	if p.NewPrice == "" && p.NewSize == "" {
+		return fmt.Errorf("%w: %s: amend without new_price or new_size", ErrInvalidOrder, instrumentId)
+	}
+	return nil
+}
+
+/*
+ Result of an amendment. Replaced is set when AmendOrReplace* cancelled the
+ order and placed a new one, OrderId is the new order then.
+*/
+type AmendOrderResult struct {
+	BaseSwapOrderResult
+	RequestId string `json:"request_id"`
+	Replaced  bool   `json:"-"`
+}
+
+/*
+ Swap
+*/
+
+func (client *Client) AmendSwapOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendSwapOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendSwapOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrder(ctx, GetInstrumentIdUri(SWAP_AMEND_ORDER, instrumentId), instrumentId, params)
+}
+
+// Amend the order, or cancel it and place a new one if the instrument has no amendment
+func (client *Client) AmendOrReplaceSwapOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendOrReplaceSwapOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendOrReplaceSwapOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrReplace(ctx, instrumentId, params, client.AmendSwapOrderCtx, func() (AmendOrderResult, error) {
+		if _, cancelled, err := client.PostSwapCancelOrderCtx(ctx, instrumentId, params.id()); err != nil {
+			return AmendOrderResult{}, err
+		} else if cancelled.Result != "true" {
+			return AmendOrderResult{}, fmt.Errorf("okex: cancel %s %s failed, error code %s, message %q",
+				instrumentId, params.id(), cancelled.ErrorCode, cancelled.ErrorMessage)
+		}
+		// read the order after cancelling, it may have filled meanwhile
+		order, err := client.GetSwapOrderByOrderIdCtx(ctx, instrumentId, params.id())
+		if err != nil {
+			return AmendOrderResult{}, err
+		}
+		size, price := replacement(params, Decimal(Int64ToString(order.Size)), order.FilledQty, order.Price)
+		if size.Sign() <= 0 {
+			return AmendOrderResult{}, fmt.Errorf("okex: %s %s filled, nothing to replace", instrumentId, params.id())
+		}
+		_, placed, err := client.PostSwapOrderCtx(ctx, instrumentId, BasePlaceOrderInfo{
+			ClientOid:  order.ClientOid,
+			Type:       strconv.FormatFloat(order.Type, 'f', -1, 64),
+			OrderType:  order.OrderType,
+			MatchPrice: params.MatchPrice,
+			Price:      price,
+			Size:       size,
+		})
+		return AmendOrderResult{BaseSwapOrderResult: placed.BaseSwapOrderResult, RequestId: params.RequestId, Replaced: true}, err
+	})
+}
+
+/*
+ Futures
+*/
+
+func (client *Client) AmendFuturesOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendFuturesOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendFuturesOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrder(ctx, GetInstrumentIdUri(FUTURES_AMEND_ORDER, instrumentId), instrumentId, params)
+}
+
+// Amend the order, or cancel it and place a new one if the instrument has no amendment
+func (client *Client) AmendOrReplaceFuturesOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendOrReplaceFuturesOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendOrReplaceFuturesOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrReplace(ctx, instrumentId, params, client.AmendFuturesOrderCtx, func() (AmendOrderResult, error) {
+		if _, cancelled, err := client.CancelFuturesInstrumentOrderCtx(ctx, instrumentId, params.id()); err != nil {
+			return AmendOrderResult{}, err
+		} else if !cancelled.Result.Result {
+			return AmendOrderResult{}, fmt.Errorf("okex: cancel %s %s failed", instrumentId, params.id())
+		}
+		order, err := client.GetFuturesOrderCtx(ctx, instrumentId, params.id())
+		if err != nil {
+			return AmendOrderResult{}, err
+		}
+		size, price := replacement(params, Decimal(Int64ToString(order.Size)), order.FilledQty, order.Price)
+		if size.Sign() <= 0 {
+			return AmendOrderResult{}, fmt.Errorf("okex: %s %s filled, nothing to replace", instrumentId, params.id())
+		}
+		_, placed, err := client.FuturesOrderCtx(ctx, FuturesNewOrderParams{
+			InstrumentId: instrumentId,
+			Leverage:     order.Leverage.String(),
+			FuturesBatchNewOrderItem: FuturesBatchNewOrderItem{
+				ClientOid:  order.ClientOId,
+				Type:       Int2String(order.Type),
+				OrderType:  Int2String(order.OrderType),
+				MatchPrice: params.MatchPrice,
+				Price:      price,
+				Size:       size,
+			},
+		})
+		result := AmendOrderResult{RequestId: params.RequestId, Replaced: true}
+		result.OrderId, result.ClientOid = placed.OrderId, placed.ClientOid
+		result.Result = strconv.FormatBool(placed.Result.Result)
+		return result, err
+	})
+}
+
+/*
+ Spot
+*/
+
+func (client *Client) AmendSpotOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendSpotOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendSpotOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrder(ctx, GetInstrumentIdUri(SPOT_AMEND_ORDER, instrumentId), instrumentId, params)
+}
+
+// Amend the limit order, or cancel it and place a new one if the instrument has no amendment
+func (client *Client) AmendOrReplaceSpotOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendOrReplaceSpotOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendOrReplaceSpotOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrReplace(ctx, instrumentId, params, client.AmendSpotOrderCtx, func() (AmendOrderResult, error) {
+		if _, cancelled, err := client.PostSpotCancelOrdersCtx(ctx, instrumentId, params.id()); err != nil {
+			return AmendOrderResult{}, err
+		} else if !cancelled.Result {
+			return AmendOrderResult{}, fmt.Errorf("okex: cancel %s %s failed, error code %s, message %q",
+				instrumentId, params.id(), cancelled.ErrorCode, cancelled.ErrorMessage)
+		}
+		order, err := client.GetSpotOrdersByIdCtx(ctx, instrumentId, params.id())
+		if err != nil {
+			return AmendOrderResult{}, err
+		}
+		size, price := replacement(params, order.Size, order.FilledSize, order.Price)
+		if size.Sign() <= 0 {
+			return AmendOrderResult{}, fmt.Errorf("okex: %s %s filled, nothing to replace", instrumentId, params.id())
+		}
+		_, placed, err := client.PostSpotOrdersCtx(ctx, SpotOrderItem{
+			ClientOid:     order.ClientOid,
+			Type:          order.Type,
+			Side:          order.Side,
+			InstrumentId:  instrumentId,
+			OrderType:     order.OrderType,
+			Price:         price,
+			Size:          size,
+			MarginTrading: "1",
+		})
+		return spotReplaced(params, placed, err)
+	})
+}
+
+/*
+ Margin, amended by the spot api
+*/
+
+// Amend the limit margin order, or cancel it and place a new margin order if the instrument has no amendment
+func (client *Client) AmendOrReplaceMarginOrder(instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.AmendOrReplaceMarginOrderCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) AmendOrReplaceMarginOrderCtx(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	return client.amendOrReplace(ctx, instrumentId, params, client.AmendSpotOrderCtx, func() (AmendOrderResult, error) {
+		if _, cancelled, err := client.PostMarginCancelOrdersByIdCtx(ctx, instrumentId, params.id()); err != nil {
+			return AmendOrderResult{}, err
+		} else if !cancelled.Result {
+			return AmendOrderResult{}, fmt.Errorf("okex: cancel %s %s failed, error code %s, message %q",
+				instrumentId, params.id(), cancelled.ErrorCode, cancelled.ErrorMessage)
+		}
+		order, err := client.GetMarginOrdersByIdCtx(ctx, instrumentId, params.id())
+		if err != nil {
+			return AmendOrderResult{}, err
+		}
+		size, price := replacement(params, Decimal(order.Size), Decimal(order.FilledSize), Decimal(order.Price))
+		if size.Sign() <= 0 {
+			return AmendOrderResult{}, fmt.Errorf("okex: %s %s filled, nothing to replace", instrumentId, params.id())
+		}
+		_, placed, err := client.PostMarginOrdersCtx(ctx, SpotOrderItem{
+			ClientOid:    order.ClientOid,
+			Type:         order.Type,
+			Side:         order.Side,
+			InstrumentId: instrumentId,
+			OrderType:    order.OrderType.String(),
+			Price:        price,
+			Size:         size,
+		})
+		return spotReplaced(params, SpotNewOrderResult(placed), err)
+	})
+}
+
+func spotReplaced(params AmendOrderParams, placed SpotNewOrderResult, err error) (AmendOrderResult, error) {
+	result := AmendOrderResult{RequestId: params.RequestId, Replaced: true}
+	result.OrderId, result.ClientOid = placed.OrderID, placed.ClientOid
+	result.ErrorCode, result.ErrorMessage = placed.ErrorCode, placed.ErrorMessage
+	result.Result = strconv.FormatBool(placed.Result)
+	return result, err
+}
+
+/*
+ Post the amendment. An answer with an error code or a result other than true
+ returns its result and an *APIError.
+*/
+func (client *Client) amendOrder(ctx context.Context, uri, instrumentId string, params AmendOrderParams) (AmendOrderResult, error) {
+	if err := params.validate(instrumentId); err != nil {
+		return AmendOrderResult{}, err
+	}
+	if err := client.validateOrder(ctx, instrumentId, params.NewPrice, params.NewSize, ""); err != nil {
+		return AmendOrderResult{}, err
+	}
+	body, _, err := client.RequestCtx(ctx, POST, uri, params, nil)
+	if err != nil {
+		return AmendOrderResult{}, err
+	}
+	ret := gjson.ParseBytes(body)
+	var result AmendOrderResult
+	result.OrderId = ret.Get("order_id").String()
+	result.ClientOid = ret.Get("client_oid").String()
+	result.RequestId = ret.Get("request_id").String()
+	result.ErrorCode = ret.Get("error_code").String()
+	result.ErrorMessage = ret.Get("error_message").String()
+	// "true" or true
+	result.Result = strconv.FormatBool(ret.Get("result").Bool())
+	if (result.ErrorCode != "" && result.ErrorCode != "0") || result.Result != "true" {
+		return result, newAPIError(POST, uri, http.StatusOK, body)
+	}
+	return result, nil
+}
+
+/*
+ Amend, or replace when the instrument answered once that it has no amendment.
+*/
+func (client *Client) amendOrReplace(ctx context.Context, instrumentId string, params AmendOrderParams,
+	amend func(ctx context.Context, instrumentId string, params AmendOrderParams) (AmendOrderResult, error),
+	replace func() (AmendOrderResult, error)) (AmendOrderResult, error) {
+	if err := params.validate(instrumentId); err != nil {
+		return AmendOrderResult{}, err
+	}
+	if _, unsupported := client.amendUnsupported.Load(instrumentId); !unsupported {
+		result, err := amend(ctx, instrumentId, params)
+		if err == nil || !isAmendUnsupported(err) {
+			return result, err
+		}
+		client.amendUnsupported.Store(instrumentId, true)
+		client.logger.Log(LogLevelInfo, "amend order unsupported, replacing orders", F("instrument_id", instrumentId), F("error", err))
+	}
+	if err := client.validateOrder(ctx, instrumentId, params.NewPrice, params.NewSize, ""); err != nil {
+		return AmendOrderResult{}, err
+	}
+	return replace()
+}
+
+func isAmendUnsupported(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.HTTPStatus == http.StatusNotFound || AmendUnsupportedCodes[apiErr.Code]
+}
+
+/*
+ The size and price of the order replacing one of size with filled filled:
+ the new (or the old) size less the filled part, at the new (or the old) price.
+*/
+func replacement(params AmendOrderParams, size, filled, price Decimal) (Decimal, Decimal) {
+	if params.NewSize != "" {
+		size = params.NewSize
+	}
+	if params.NewPrice != "" {
+		price = params.NewPrice
+	}
+	if filled != "" {
+		size = size.Sub(filled)
+	}
+	return size, price
+}
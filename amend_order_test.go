@@ -0,0 +1,169 @@
+package okex
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient_AmendSwapOrder(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/swap/v3/amend_order/BTC-USD-SWAP", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		if gjson.GetBytes(body, "order_id").String() == "2" {
+			w.Write([]byte(`{"order_id":"2","client_oid":"","request_id":"r2","error_code":"35060","error_message":"Order is completed","result":"false"}`))
+			return
+		}
+		assert.Equal(t, "9001.5", gjson.GetBytes(body, "new_price").String())
+		assert.False(t, gjson.GetBytes(body, "new_size").Exists())
+		w.Write([]byte(`{"order_id":"1","client_oid":"","request_id":"r1","error_code":"0","error_message":"","result":true}`))
+	})
+	defer server.Close()
+
+	result, err := c.AmendSwapOrder("BTC-USD-SWAP", AmendOrderParams{OrderId: "1", NewPrice: "9001.5", RequestId: "r1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "true", result.Result)
+	assert.Equal(t, "r1", result.RequestId)
+	assert.False(t, result.Replaced)
+
+	result, err = c.AmendSwapOrder("BTC-USD-SWAP", AmendOrderParams{OrderId: "2", NewSize: "3"})
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 35060, apiErr.Code)
+	assert.Equal(t, "35060", result.ErrorCode)
+
+	_, err = c.AmendSwapOrder("BTC-USD-SWAP", AmendOrderParams{OrderId: "1"})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	_, err = c.AmendSwapOrder("BTC-USD-SWAP", AmendOrderParams{NewPrice: "1"})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+}
+
+func TestClient_AmendOrReplaceSwapOrder(t *testing.T) {
+	var requests []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/swap/v3/amend_order/BTC-USD-SWAP":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":30030,"message":"endpoint request failed"}`))
+		case "/api/swap/v3/cancel_order/BTC-USD-SWAP/1":
+			w.Write([]byte(`{"order_id":"1","client_oid":"","error_code":"0","error_message":"","result":"true"}`))
+		case "/api/swap/v3/orders/BTC-USD-SWAP/1":
+			w.Write([]byte(`{"instrument_id":"BTC-USD-SWAP","order_id":"1","client_oid":"q1","size":"5","filled_qty":"2","price":"9000.0",` +
+				`"type":"1","order_type":"1","state":"-1"}`))
+		case SWAP_ORDER:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "3", gjson.GetBytes(body, "size").String())
+			assert.Equal(t, "9001.5", gjson.GetBytes(body, "price").String())
+			assert.Equal(t, "1", gjson.GetBytes(body, "type").String())
+			assert.Equal(t, "1", gjson.GetBytes(body, "order_type").String())
+			assert.Equal(t, "q1", gjson.GetBytes(body, "client_oid").String())
+			assert.Equal(t, "0", gjson.GetBytes(body, "match_price").String())
+			w.Write([]byte(`{"order_id":"7","client_oid":"","error_code":"0","error_message":"","result":"true"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	params := AmendOrderParams{OrderId: "1", NewPrice: "9001.5", RequestId: "r1", MatchPrice: "0"}
+	result, err := c.AmendOrReplaceSwapOrder("BTC-USD-SWAP", params)
+	assert.Nil(t, err)
+	assert.True(t, result.Replaced)
+	assert.Equal(t, "7", result.OrderId)
+	assert.Equal(t, "r1", result.RequestId)
+	assert.Equal(t, 4, len(requests))
+
+	// the instrument is not asked to amend again
+	requests = nil
+	_, err = c.AmendOrReplaceSwapOrder("BTC-USD-SWAP", params)
+	assert.Nil(t, err)
+	assert.Equal(t, "POST /api/swap/v3/cancel_order/BTC-USD-SWAP/1", requests[0])
+}
+
+func TestClient_AmendOrReplaceFuturesOrder(t *testing.T) {
+	var requests []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/futures/v3/amend_order/BTC-USD-200925":
+			w.Write([]byte(`{"order_id":"1","client_oid":"","request_id":"","error_code":"30037","error_message":"endpoint is offline or unavailable","result":"false"}`))
+		case "/api/futures/v3/cancel_order/BTC-USD-200925/1":
+			w.Write([]byte(`{"order_id":"1","client_oid":"f1","error_code":"0","error_message":"","result":true}`))
+		case "/api/futures/v3/orders/BTC-USD-200925/1":
+			w.Write([]byte(`{"instrument_id":"BTC-USD-200925","order_id":"1","client_oid":"f1","size":"4","filled_qty":"0","price":"9000.0",` +
+				`"type":"2","order_type":"2","state":"-1","leverage":"10"}`))
+		case FUTURES_ORDER:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "6", gjson.GetBytes(body, "size").String())
+			assert.Equal(t, "9000.0", gjson.GetBytes(body, "price").String())
+			assert.Equal(t, "2", gjson.GetBytes(body, "type").String())
+			assert.Equal(t, "2", gjson.GetBytes(body, "order_type").String())
+			assert.Equal(t, "f1", gjson.GetBytes(body, "client_oid").String())
+			assert.Equal(t, "10", gjson.GetBytes(body, "leverage").String())
+			w.Write([]byte(`{"order_id":"8","client_oid":"f1","error_code":"0","error_message":"","result":true}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.AmendOrReplaceFuturesOrder("BTC-USD-200925", AmendOrderParams{OrderId: "1", NewSize: "6"})
+	assert.Nil(t, err)
+	assert.True(t, result.Replaced)
+	assert.Equal(t, "8", result.OrderId)
+	assert.Equal(t, "f1", result.ClientOid)
+	assert.Equal(t, 4, len(requests))
+}
+
+func TestClient_AmendOrReplaceSpotOrder(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/spot/v3/amend_order/BTC-USDT", "/api/spot/v3/amend_order/ETH-USDT":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":30030,"message":"endpoint request failed"}`))
+		case "/api/spot/v3/cancel_orders/1":
+			assert.Equal(t, "BTC-USDT", gjson.GetBytes(readBody(r), "instrument_id").String())
+			w.Write([]byte(`{"order_id":"1","client_oid":"s1","error_code":"","error_message":"","result":true}`))
+		case "/api/spot/v3/orders/1":
+			w.Write([]byte(`{"instrument_id":"BTC-USDT","order_id":"1","client_oid":"s1","size":"0.5","filled_size":"0.1","price":"9000.0",` +
+				`"side":"sell","type":"limit","order_type":"1","state":"-1"}`))
+		case SPOT_ORDERS:
+			assert.Equal(t, `{"client_oid":"s1","type":"limit","side":"sell","instrument_id":"BTC-USDT","order_type":"1",`+
+				`"price":"9100","size":"0.4","margin_trading":"1"}`, string(readBody(r)))
+			w.Write([]byte(`{"order_id":"9","client_oid":"s1","error_code":"","error_message":"","result":true}`))
+		case "/api/margin/v3/cancel_orders/2":
+			w.Write([]byte(`{"order_id":"2","client_oid":"","error_code":"","error_message":"","result":true}`))
+		case "/api/margin/v3/orders/2":
+			w.Write([]byte(`{"instrument_id":"ETH-USDT","order_id":"2","client_oid":"","size":"3","filled_size":"0","price":"200.0",` +
+				`"side":"buy","type":"limit","order_type":"0","state":"-1"}`))
+		case MARGIN_ORDERS:
+			assert.Equal(t, `{"type":"limit","side":"buy","instrument_id":"ETH-USDT","order_type":"0",`+
+				`"price":"201","size":"3","margin_trading":"2"}`, string(readBody(r)))
+			w.Write([]byte(`{"order_id":"10","client_oid":"","error_code":"","error_message":"","result":true}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.AmendOrReplaceSpotOrder("BTC-USDT", AmendOrderParams{OrderId: "1", NewPrice: "9100"})
+	assert.Nil(t, err)
+	assert.True(t, result.Replaced)
+	assert.Equal(t, "9", result.OrderId)
+	assert.Equal(t, "true", result.Result)
+
+	result, err = c.AmendOrReplaceMarginOrder("ETH-USDT", AmendOrderParams{OrderId: "2", NewPrice: "201"})
+	assert.Nil(t, err)
+	assert.True(t, result.Replaced)
+	assert.Equal(t, "10", result.OrderId)
+}
+
+func readBody(r *http.Request) []byte {
+	body, _ := ioutil.ReadAll(r.Body)
+	return body
+}
@@ -0,0 +1,179 @@
+package okex
+
+/*
+ OKEX api error definition
+*/
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrorClass 错误分类，用于调用方判断是否重试、是否需要重新认证等
+type ErrorClass int
+
+const (
+	ErrorClassUnknown             ErrorClass = iota
+	ErrorClassBadRequest                     // 参数错误等一般性4xx错误
+	ErrorClassAuth                           // 签名、API Key、Passphrase 等认证错误
+	ErrorClassInvalidTimestamp               // 请求时间戳无效或已过期
+	ErrorClassRateLimit                      // 请求过于频繁
+	ErrorClassInsufficientBalance            // 余额/保证金不足
+	ErrorClassOrderNotFound                  // 订单不存在
+	ErrorClassServer                         // 服务端5xx错误
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorClassBadRequest:
+		return "bad_request"
+	case ErrorClassAuth:
+		return "auth"
+	case ErrorClassInvalidTimestamp:
+		return "invalid_timestamp"
+	case ErrorClassRateLimit:
+		return "rate_limit"
+	case ErrorClassInsufficientBalance:
+		return "insufficient_balance"
+	case ErrorClassOrderNotFound:
+		return "order_not_found"
+	case ErrorClassServer:
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+// Sentinel errors, one per ErrorClass, usable with errors.Is:
+//
+//	if errors.Is(err, okex.ErrRateLimit) { ... }
+var (
+	ErrBadRequest          = errors.New("okex: bad request")
+	ErrAuth                = errors.New("okex: authentication failed")
+	ErrInvalidTimestamp    = errors.New("okex: invalid timestamp")
+	ErrRateLimit           = errors.New("okex: rate limit exceeded")
+	ErrInsufficientBalance = errors.New("okex: insufficient balance")
+	ErrOrderNotFound       = errors.New("okex: order not found")
+	ErrServer              = errors.New("okex: server error")
+)
+
+var errorClassSentinels = map[ErrorClass]error{
+	ErrorClassBadRequest:          ErrBadRequest,
+	ErrorClassAuth:                ErrAuth,
+	ErrorClassInvalidTimestamp:    ErrInvalidTimestamp,
+	ErrorClassRateLimit:           ErrRateLimit,
+	ErrorClassInsufficientBalance: ErrInsufficientBalance,
+	ErrorClassOrderNotFound:       ErrOrderNotFound,
+	ErrorClassServer:              ErrServer,
+}
+
+// ErrorCodeClasses 已知的 OKEx 业务错误码分类，可按需追加
+var ErrorCodeClasses = map[int]ErrorClass{
+	30001: ErrorClassAuth,                // OK-ACCESS-KEY header is required
+	30002: ErrorClassAuth,                // OK-ACCESS-SIGN header is required
+	30003: ErrorClassInvalidTimestamp,    // OK-ACCESS-TIMESTAMP header is required
+	30004: ErrorClassAuth,                // OK-ACCESS-PASSPHRASE header is required
+	30005: ErrorClassInvalidTimestamp,    // invalid OK-ACCESS-TIMESTAMP
+	30006: ErrorClassAuth,                // invalid OK-ACCESS-KEY
+	30008: ErrorClassInvalidTimestamp,    // timestamp request expired
+	30012: ErrorClassAuth,                // invalid authorization
+	30013: ErrorClassAuth,                // invalid sign
+	30014: ErrorClassRateLimit,           // request too frequent
+	30015: ErrorClassAuth,                // invalid OK-ACCESS-PASSPHRASE
+	30026: ErrorClassRateLimit,           // requested too frequent
+	30027: ErrorClassAuth,                // login failure
+	32004: ErrorClassOrderNotFound,       // futures: no unfilled orders
+	33014: ErrorClassOrderNotFound,       // spot/margin: order does not exist
+	33017: ErrorClassInsufficientBalance, // spot/margin: insufficient balance
+	34008: ErrorClassInsufficientBalance, // account: insufficient balance
+	35029: ErrorClassOrderNotFound,       // swap: order does not exist
+}
+
+// APIError 非2xx响应的结构化错误
+type APIError struct {
+	HTTPStatus int    // http status code
+	Code       int    // OKEx code / error_code
+	Message    string // OKEx message / error_message
+	Method     string
+	Path       string // request path, including the query string
+	Body       []byte // raw response body
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("okex: %s %s: http status %d, code %d, message %q",
+		e.Method, e.Path, e.HTTPStatus, e.Code, e.Message)
+}
+
+// Class 按错误码优先、http状态码其次对错误进行分类
+func (e *APIError) Class() ErrorClass {
+	if class, ok := ErrorCodeClasses[e.Code]; ok {
+		return class
+	}
+	switch {
+	case e.HTTPStatus == http.StatusTooManyRequests:
+		return ErrorClassRateLimit
+	case e.HTTPStatus == http.StatusUnauthorized || e.HTTPStatus == http.StatusForbidden:
+		return ErrorClassAuth
+	case e.HTTPStatus >= 500:
+		return ErrorClassServer
+	case e.HTTPStatus >= 400:
+		return ErrorClassBadRequest
+	}
+	return ErrorClassUnknown
+}
+
+// Is lets errors.Is match an *APIError against the class sentinels.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := errorClassSentinels[e.Class()]
+	return ok && sentinel == target
+}
+
+// ErrorClassOf 返回 err 链上第一个 *APIError 的分类，没有则返回 ErrorClassUnknown
+func ErrorClassOf(err error) ErrorClass {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Class()
+	}
+	for class, sentinel := range errorClassSentinels {
+		if errors.Is(err, sentinel) {
+			return class
+		}
+	}
+	return ErrorClassUnknown
+}
+
+/*
+Build an APIError from a non-2xx response. The body is usually one of
+
+	{"code":30008,"message":"Timestamp request expired"}
+	{"error_code":"33014","error_message":"Order does not exist","code":"33014","message":"..."}
+*/
+func newAPIError(method, requestPath string, status int, body []byte) *APIError {
+	apiErr := &APIError{
+		HTTPStatus: status,
+		Method:     method,
+		Path:       requestPath,
+		Body:       body,
+	}
+	if !gjson.ValidBytes(body) {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+	ret := gjson.ParseBytes(body)
+	for _, key := range []string{"error_code", "code", "errorCode"} {
+		if v := ret.Get(key); v.Exists() && v.Int() != 0 {
+			apiErr.Code = int(v.Int())
+			break
+		}
+	}
+	for _, key := range []string{"error_message", "message", "msg"} {
+		if v := ret.Get(key); v.Exists() && v.String() != "" {
+			apiErr.Message = v.String()
+			break
+		}
+	}
+	return apiErr
+}
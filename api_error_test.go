@@ -0,0 +1,57 @@
+package okex
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError_FromResponse(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_code":"33014","error_message":"Order does not exist","code":"33014","message":"Order does not exist"}`))
+	})
+	defer server.Close()
+
+	_, err := c.GetSpotOrdersById("BTC-USDT", "123")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.HTTPStatus)
+	assert.Equal(t, 33014, apiErr.Code)
+	assert.Equal(t, "Order does not exist", apiErr.Message)
+	assert.Equal(t, GET, apiErr.Method)
+	assert.Contains(t, apiErr.Path, "/api/spot/v3/orders/123")
+	assert.Contains(t, string(apiErr.Body), "33014")
+	assert.Equal(t, ErrorClassOrderNotFound, apiErr.Class())
+	assert.True(t, errors.Is(err, ErrOrderNotFound))
+	assert.False(t, errors.Is(err, ErrRateLimit))
+}
+
+func TestAPIError_Class(t *testing.T) {
+	cases := []struct {
+		err   *APIError
+		class ErrorClass
+	}{
+		{&APIError{HTTPStatus: 401, Code: 30013}, ErrorClassAuth},
+		{&APIError{HTTPStatus: 400, Code: 30008}, ErrorClassInvalidTimestamp},
+		{&APIError{HTTPStatus: 429}, ErrorClassRateLimit},
+		{&APIError{HTTPStatus: 400, Code: 30014}, ErrorClassRateLimit},
+		{&APIError{HTTPStatus: 400, Code: 33017}, ErrorClassInsufficientBalance},
+		{&APIError{HTTPStatus: 502}, ErrorClassServer},
+		{&APIError{HTTPStatus: 400, Code: 30023}, ErrorClassBadRequest},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.class, c.err.Class(), c.err.Error())
+		assert.Equal(t, c.class, ErrorClassOf(c.err))
+	}
+	assert.Equal(t, ErrorClassUnknown, ErrorClassOf(errors.New("boom")))
+}
+
+func TestAPIError_NonJsonBody(t *testing.T) {
+	apiErr := newAPIError(GET, SWAP_POSITION, http.StatusBadGateway, []byte("<html>bad gateway</html>"))
+	assert.Equal(t, "<html>bad gateway</html>", apiErr.Message)
+	assert.Equal(t, 0, apiErr.Code)
+	assert.True(t, errors.Is(apiErr, ErrServer))
+}
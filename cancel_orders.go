@@ -0,0 +1,383 @@
+package okex
+
+/*
+ Cancel all open orders, or the open orders matching a predicate.
+
+ The open orders are listed page by page (swap/futures: the order list with
+ state 6, spot/margin: orders_pending), split into batches of at most
+ cancelBatchLimit orders and the batches cancelled in parallel. Every request
+ passes the client's rate limiter. The outcome of each order is reported:
+
+	outcomes, err := client.CancelSwapOrdersWhere("BTC-USD-SWAP", func(o okex.OpenOrder) bool {
+		return o.Type == "1" && o.Price.Cmp("9000") < 0
+	})
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// max orders of a cancel_batch_orders request, same for all markets
+	cancelBatchLimit = 10
+	// batches in flight at once
+	cancelConcurrency = 4
+	// swap/futures order list state: waiting and partially filled
+	openOrdersState = "6"
+)
+
+var errNotCancelled = errors.New("okex: order missing in the cancel result")
+
+// An open order of any market
+type OpenOrder struct {
+	InstrumentId string
+	OrderId      string
+	ClientOid    string
+	Side         string // spot/margin: buy, sell
+	Type         string // swap/futures: 1 open long, 2 open short, 3 close long, 4 close short. spot/margin: limit, market
+	Price        Decimal
+	Size         Decimal
+	FilledSize   Decimal
+	State        string // 0 waiting, 1 partially filled
+	Timestamp    time.Time
+}
+
+// The outcome of cancelling an order, Err is nil when the exchange accepted the cancel
+type CancelOutcome struct {
+	Order OpenOrder
+	Err   error
+}
+
+type cancelMarket struct {
+	// list the open orders of the instrument
+	list func(ctx context.Context, instrumentId string) *PageIterator
+	// cancel a batch of orders of one instrument, returns an error (or nil) per order
+	cancel func(ctx context.Context, instrumentId string, orders []OpenOrder) []error
+}
+
+/*
+ Swap
+*/
+
+// Cancel all open orders of the instrument
+func (client *Client) CancelAllSwapOrders(instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelSwapOrdersWhereCtx(context.Background(), instrumentId, nil)
+}
+
+func (client *Client) CancelAllSwapOrdersCtx(ctx context.Context, instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelSwapOrdersWhereCtx(ctx, instrumentId, nil)
+}
+
+// Cancel the open orders of the instrument matching predicate, nil matches all
+func (client *Client) CancelSwapOrdersWhere(instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.CancelSwapOrdersWhereCtx(context.Background(), instrumentId, predicate)
+}
+
+func (client *Client) CancelSwapOrdersWhereCtx(ctx context.Context, instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	if instrumentId == "" {
+		return nil, errors.New("okex: instrument_id is required")
+	}
+	return client.cancelWhere(ctx, cancelMarket{
+		list: func(ctx context.Context, instrumentId string) *PageIterator {
+			return client.IterSwapOrders(ctx, instrumentId, openOrdersState, IterOptions{})
+		},
+		cancel: func(ctx context.Context, instrumentId string, orders []OpenOrder) []error {
+			params := struct {
+				Ids []string `json:"ids"`
+			}{openOrderIds(orders)}
+			uri := GetInstrumentIdUri(SWAP_CANCEL_BATCH_ORDERS, instrumentId)
+			body, _, err := client.RequestCtx(ctx, POST, uri, params, nil)
+			return batchCancelErrors(orders, body, err, "ids")
+		},
+	}, instrumentId, predicate)
+}
+
+/*
+ Futures
+*/
+
+// Cancel all open orders of the instrument
+func (client *Client) CancelAllFuturesOrders(instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelFuturesOrdersWhereCtx(context.Background(), instrumentId, nil)
+}
+
+func (client *Client) CancelAllFuturesOrdersCtx(ctx context.Context, instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelFuturesOrdersWhereCtx(ctx, instrumentId, nil)
+}
+
+// Cancel the open orders of the instrument matching predicate, nil matches all
+func (client *Client) CancelFuturesOrdersWhere(instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.CancelFuturesOrdersWhereCtx(context.Background(), instrumentId, predicate)
+}
+
+func (client *Client) CancelFuturesOrdersWhereCtx(ctx context.Context, instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	if instrumentId == "" {
+		return nil, errors.New("okex: instrument_id is required")
+	}
+	return client.cancelWhere(ctx, cancelMarket{
+		list: func(ctx context.Context, instrumentId string) *PageIterator {
+			return client.IterFuturesOrders(ctx, instrumentId, openOrdersState, IterOptions{})
+		},
+		cancel: func(ctx context.Context, instrumentId string, orders []OpenOrder) []error {
+			params := struct {
+				OrderIds []string `json:"order_ids"`
+			}{openOrderIds(orders)}
+			uri := GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_BATCH_CANCEL, instrumentId)
+			body, _, err := client.RequestCtx(ctx, POST, uri, params, nil)
+			return batchCancelErrors(orders, body, err, "order_ids")
+		},
+	}, instrumentId, predicate)
+}
+
+/*
+ Spot
+*/
+
+// Cancel all open orders of the instrument, "" cancels the orders of all instruments
+func (client *Client) CancelAllSpotOrders(instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelSpotOrdersWhereCtx(context.Background(), instrumentId, nil)
+}
+
+func (client *Client) CancelAllSpotOrdersCtx(ctx context.Context, instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelSpotOrdersWhereCtx(ctx, instrumentId, nil)
+}
+
+// Cancel the open orders of the instrument ("" for all) matching predicate, nil matches all
+func (client *Client) CancelSpotOrdersWhere(instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.CancelSpotOrdersWhereCtx(context.Background(), instrumentId, predicate)
+}
+
+func (client *Client) CancelSpotOrdersWhereCtx(ctx context.Context, instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.cancelWhere(ctx, cancelMarket{
+		list: func(ctx context.Context, instrumentId string) *PageIterator {
+			return client.newPageIterator(ctx, SPOT_ORDERS_PENDING, "order_id", "", withParam(IterOptions{}, "instrument_id", instrumentId))
+		},
+		cancel: func(ctx context.Context, instrumentId string, orders []OpenOrder) []error {
+			_, result, err := client.PostSpotCancelBatchOrdersCtx(ctx,
+				[]SpotCancelOrdersItem{{InstrumentId: instrumentId, OrderIds: openOrderIds(orders)}})
+			results := make(map[string]error)
+			for _, rs := range result {
+				for _, r := range rs {
+					results[r.OrderID] = orderCancelError(r.Result, r.ErrorCode, r.ErrorMessage)
+				}
+			}
+			return spotCancelErrors(orders, results, err)
+		},
+	}, instrumentId, predicate)
+}
+
+/*
+ Margin
+*/
+
+// Cancel all open orders of the instrument, "" cancels the orders of all instruments
+func (client *Client) CancelAllMarginOrders(instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelMarginOrdersWhereCtx(context.Background(), instrumentId, nil)
+}
+
+func (client *Client) CancelAllMarginOrdersCtx(ctx context.Context, instrumentId string) ([]CancelOutcome, error) {
+	return client.CancelMarginOrdersWhereCtx(ctx, instrumentId, nil)
+}
+
+// Cancel the open orders of the instrument ("" for all) matching predicate, nil matches all
+func (client *Client) CancelMarginOrdersWhere(instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.CancelMarginOrdersWhereCtx(context.Background(), instrumentId, predicate)
+}
+
+func (client *Client) CancelMarginOrdersWhereCtx(ctx context.Context, instrumentId string, predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	return client.cancelWhere(ctx, cancelMarket{
+		list: func(ctx context.Context, instrumentId string) *PageIterator {
+			return client.newPageIterator(ctx, MARGIN_ORDERS_PENDING, "order_id", "", withParam(IterOptions{}, "instrument_id", instrumentId))
+		},
+		cancel: func(ctx context.Context, instrumentId string, orders []OpenOrder) []error {
+			_, result, err := client.PostMarginCancelBatchOrdersCtx(ctx,
+				[]SpotCancelOrdersItem{{InstrumentId: instrumentId, OrderIds: openOrderIds(orders)}})
+			results := make(map[string]error)
+			for _, rs := range result {
+				for _, r := range rs {
+					results[r.OrderID] = orderCancelError(r.Result, r.ErrorCode, r.ErrorMessage)
+				}
+			}
+			return spotCancelErrors(orders, results, err)
+		},
+	}, instrumentId, predicate)
+}
+
+/*
+ List the open orders, keep the matching ones and cancel them in batches per
+ instrument. The error reports how many orders were not cancelled, the
+ outcomes which ones and why.
+*/
+func (client *Client) cancelWhere(ctx context.Context, market cancelMarket, instrumentId string,
+	predicate func(OpenOrder) bool) ([]CancelOutcome, error) {
+	type batch struct {
+		instrumentId string
+		orders       []OpenOrder
+		offset       int
+	}
+
+	var instruments []string
+	byInstrument := make(map[string][]OpenOrder)
+	seen := make(map[string]bool)
+	it := market.list(ctx, instrumentId)
+	for it.Next() {
+		order := parseOpenOrder(gjson.ParseBytes(it.Raw()))
+		// a new order may push an order to the next page, which lists it twice
+		if seen[order.OrderId] {
+			continue
+		}
+		seen[order.OrderId] = true
+		if order.InstrumentId == "" {
+			order.InstrumentId = instrumentId
+		}
+		if predicate != nil && !predicate(order) {
+			continue
+		}
+		if _, ok := byInstrument[order.InstrumentId]; !ok {
+			instruments = append(instruments, order.InstrumentId)
+		}
+		byInstrument[order.InstrumentId] = append(byInstrument[order.InstrumentId], order)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	var batches []batch
+	total := 0
+	for _, id := range instruments {
+		orders := byInstrument[id]
+		for len(orders) > 0 {
+			n := len(orders)
+			if n > cancelBatchLimit {
+				n = cancelBatchLimit
+			}
+			batches = append(batches, batch{instrumentId: id, orders: orders[:n], offset: total})
+			total += n
+			orders = orders[n:]
+		}
+	}
+
+	outcomes := make([]CancelOutcome, total)
+	sem := make(chan struct{}, cancelConcurrency)
+	var wg sync.WaitGroup
+	for _, b := range batches {
+		wg.Add(1)
+		go func(b batch) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs := market.cancel(ctx, b.instrumentId, b.orders)
+			for i, order := range b.orders {
+				outcomes[b.offset+i] = CancelOutcome{Order: order, Err: errs[i]}
+			}
+		}(b)
+	}
+	wg.Wait()
+
+	failed := 0
+	var firstErr error
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = outcome.Err
+			}
+		}
+	}
+	if failed > 0 {
+		client.logger.Log(LogLevelWarn, "cancel orders failed", F("failed", failed), F("total", total), F("error", firstErr))
+		return outcomes, fmt.Errorf("okex: %d of %d orders not cancelled: %w", failed, total, firstErr)
+	}
+	return outcomes, nil
+}
+
+func parseOpenOrder(row gjson.Result) OpenOrder {
+	filled := row.Get("filled_qty").String()
+	if filled == "" {
+		filled = row.Get("filled_size").String()
+	}
+	ts, _ := time.Parse(time.RFC3339Nano, row.Get("timestamp").String())
+	return OpenOrder{
+		InstrumentId: strings.ToUpper(row.Get("instrument_id").String()),
+		OrderId:      row.Get("order_id").String(),
+		ClientOid:    row.Get("client_oid").String(),
+		Side:         row.Get("side").String(),
+		Type:         row.Get("type").String(),
+		Price:        Decimal(row.Get("price").String()),
+		Size:         Decimal(row.Get("size").String()),
+		FilledSize:   Decimal(filled),
+		State:        row.Get("state").String(),
+		Timestamp:    ts,
+	}
+}
+
+func openOrderIds(orders []OpenOrder) []string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.OrderId
+	}
+	return ids
+}
+
+func repeatError(err error, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+func orderCancelError(result bool, errorCode, errorMessage string) error {
+	if result && (errorCode == "" || errorCode == "0") {
+		return nil
+	}
+	return fmt.Errorf("okex: cancel rejected, error code %s, message %q", errorCode, errorMessage)
+}
+
+/*
+ Swap and futures answer a batch with its result and the ids accepted for
+ cancelling, eg: {"result":"true","ids":["1","2"],"instrument_id":"BTC-USD-SWAP"}
+*/
+func batchCancelErrors(orders []OpenOrder, body []byte, err error, idsField string) []error {
+	if err != nil {
+		return repeatError(err, len(orders))
+	}
+	ret := gjson.ParseBytes(body)
+	if !ret.Get("result").Bool() {
+		return repeatError(orderCancelError(false, ret.Get("error_code").String(), ret.Get("error_message").String()), len(orders))
+	}
+	accepted := make(map[string]bool)
+	for _, id := range ret.Get(idsField).Array() {
+		accepted[id.String()] = true
+	}
+	errs := make([]error, len(orders))
+	for i, order := range orders {
+		if !accepted[order.OrderId] {
+			errs[i] = errNotCancelled
+		}
+	}
+	return errs
+}
+
+// Spot and margin answer every order of a batch, @see SpotBatchOrdersResult
+func spotCancelErrors(orders []OpenOrder, results map[string]error, err error) []error {
+	if err != nil {
+		return repeatError(err, len(orders))
+	}
+	errs := make([]error, len(orders))
+	for i, order := range orders {
+		result, ok := results[order.OrderId]
+		if !ok {
+			result = errNotCancelled
+		}
+		errs[i] = result
+	}
+	return errs
+}
@@ -0,0 +1,109 @@
+package okex
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient_CancelAllSwapOrders(t *testing.T) {
+	var mu sync.Mutex
+	var batches []int
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/swap/v3/orders/BTC-USD-SWAP":
+			assert.Equal(t, "6", r.URL.Query().Get("state"))
+			var rows []string
+			for i := 1; i <= 23; i++ {
+				rows = append(rows, fmt.Sprintf(`{"instrument_id":"BTC-USD-SWAP","order_id":"%d","size":"1","filled_qty":"0","type":"1","state":"0"}`, i))
+			}
+			w.Write([]byte(`{"order_info":[` + strings.Join(rows, ",") + `]}`))
+		case "/api/swap/v3/cancel_batch_orders/BTC-USD-SWAP":
+			body, _ := ioutil.ReadAll(r.Body)
+			ids := gjson.GetBytes(body, "ids").Array()
+			mu.Lock()
+			batches = append(batches, len(ids))
+			mu.Unlock()
+			if ids[0].String() == "11" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error_code":"35013","error_message":"Invalid order type"}`))
+				return
+			}
+			var accepted []string
+			for _, id := range ids {
+				// order 3 was filled meanwhile
+				if id.String() != "3" {
+					accepted = append(accepted, `"`+id.String()+`"`)
+				}
+			}
+			w.Write([]byte(`{"result":"true","ids":[` + strings.Join(accepted, ",") + `],"instrument_id":"BTC-USD-SWAP"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	outcomes, err := c.CancelAllSwapOrders("BTC-USD-SWAP")
+	assert.True(t, errors.Is(err, errNotCancelled))
+	assert.ElementsMatch(t, []int{10, 10, 3}, batches)
+	assert.Equal(t, 23, len(outcomes))
+	failed := 0
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			failed++
+		}
+	}
+	assert.Equal(t, 11, failed)
+	assert.Equal(t, "3", outcomes[2].Order.OrderId)
+	assert.Equal(t, errNotCancelled, outcomes[2].Err)
+	assert.Nil(t, outcomes[0].Err)
+	assert.True(t, errors.Is(outcomes[10].Err, ErrBadRequest))
+	assert.Equal(t, Decimal("1"), outcomes[0].Order.Size)
+
+	_, err = c.CancelAllFuturesOrders("")
+	assert.NotNil(t, err)
+}
+
+func TestClient_CancelSpotOrdersWhere(t *testing.T) {
+	var cancelled []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SPOT_ORDERS_PENDING:
+			assert.Equal(t, "", r.URL.Query().Get("instrument_id"))
+			w.Write([]byte(`[{"instrument_id":"BTC-USDT","order_id":"1","side":"buy","price":"9000","size":"0.1","filled_size":"0"},` +
+				`{"instrument_id":"BTC-USDT","order_id":"2","side":"sell","price":"11000","size":"0.1","filled_size":"0"},` +
+				`{"instrument_id":"ETH-USDT","order_id":"3","side":"buy","price":"200","size":"1","filled_size":"0.5"}]`))
+		case SPOT_CANCEL_BATCH_ORDERS:
+			body, _ := ioutil.ReadAll(r.Body)
+			item := gjson.ParseBytes(body).Array()[0]
+			id := item.Get("order_ids.0").String()
+			cancelled = append(cancelled, item.Get("instrument_id").String()+" "+id)
+			if id == "3" {
+				w.Write([]byte(`{"eth-usdt":[{"client_oid":"","order_id":"3","result":false,"error_code":"33014","error_message":"Order does not exist"}]}`))
+				return
+			}
+			w.Write([]byte(`{"btc-usdt":[{"client_oid":"","order_id":"` + id + `","result":true,"error_code":"","error_message":""}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	outcomes, err := c.CancelSpotOrdersWhere("", func(o OpenOrder) bool {
+		return o.Side == "buy"
+	})
+	assert.NotNil(t, err)
+	assert.ElementsMatch(t, []string{"BTC-USDT 1", "ETH-USDT 3"}, cancelled)
+	assert.Equal(t, 2, len(outcomes))
+	assert.Nil(t, outcomes[0].Err)
+	assert.Equal(t, "ETH-USDT", outcomes[1].Order.InstrumentId)
+	assert.Equal(t, Decimal("0.5"), outcomes[1].Order.FilledSize)
+	assert.NotNil(t, outcomes[1].Err)
+}
@@ -0,0 +1,295 @@
+package okex
+
+/*
+ Candles (K线) of swap, futures and spot instruments.
+
+ All markets return the same Candle, whichever form the api answers with.
+ The candle endpoints return at most 200 bars per request. BackfillCandles
+ pages backwards through a whole time range and stitches the pages together.
+*/
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Granularity is the bar size of a candle in seconds, one of the CANDLES_* values
+type Granularity int
+
+// All granularities supported by the api
+func Granularities() []Granularity {
+	return []Granularity{CANDLES_1MIN, CANDLES_3MIN, CANDLES_5MIN, CANDLES_15MIN, CANDLES_30MIN, CANDLES_1HOUR,
+		CANDLES_2HOUR, CANDLES_4HOUR, CANDLES_6HOUR, CANDLES_12HOUR, CANDLES_1DAY, CANDLES_1WEEK}
+}
+
+func (g Granularity) Valid() bool {
+	for _, v := range Granularities() {
+		if g == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (g Granularity) Duration() time.Duration {
+	return time.Duration(g) * time.Second
+}
+
+// eg: 1m, 4h, 1d, 1w
+func (g Granularity) String() string {
+	switch {
+	case g >= CANDLES_1WEEK && g%CANDLES_1WEEK == 0:
+		return Int2String(int(g/CANDLES_1WEEK)) + "w"
+	case g >= CANDLES_1DAY && g%CANDLES_1DAY == 0:
+		return Int2String(int(g/CANDLES_1DAY)) + "d"
+	case g >= CANDLES_1HOUR && g%CANDLES_1HOUR == 0:
+		return Int2String(int(g/CANDLES_1HOUR)) + "h"
+	case g >= CANDLES_1MIN && g%CANDLES_1MIN == 0:
+		return Int2String(int(g/CANDLES_1MIN)) + "m"
+	default:
+		return Int2String(int(g)) + "s"
+	}
+}
+
+/*
+ One bar. Volume is in contracts for swap/futures and in base currency for spot,
+ CurrencyVolume (swap/futures only) is in the coin.
+*/
+type Candle struct {
+	Time           time.Time
+	Open           Decimal
+	High           Decimal
+	Low            Decimal
+	Close          Decimal
+	Volume         Decimal
+	CurrencyVolume Decimal
+}
+
+// CandleGap is a range of missing bars: [From, To)
+type CandleGap struct {
+	From time.Time
+	To   time.Time
+}
+
+func (c *Candle) UnmarshalJSON(data []byte) error {
+	candle, err := parseCandle(gjson.ParseBytes(data))
+	if err != nil {
+		return err
+	}
+	*c = candle
+	return nil
+}
+
+/*
+ Check the prices and volumes are numbers, low <= open, close <= high and volume >= 0
+*/
+func (c Candle) Validate() error {
+	for _, v := range []Decimal{c.Open, c.High, c.Low, c.Close, c.Volume} {
+		if v == "" || !v.Valid() {
+			return fmt.Errorf("okex: candle %s: illegal number %q", isoTimeOf(c.Time), v)
+		}
+	}
+	if c.Low.Cmp(c.High) > 0 || c.Open.Cmp(c.Low) < 0 || c.Open.Cmp(c.High) > 0 ||
+		c.Close.Cmp(c.Low) < 0 || c.Close.Cmp(c.High) > 0 {
+		return fmt.Errorf("okex: candle %s: prices out of range low %s high %s", isoTimeOf(c.Time), c.Low, c.High)
+	}
+	if c.Volume.Sign() < 0 {
+		return fmt.Errorf("okex: candle %s: negative volume %s", isoTimeOf(c.Time), c.Volume)
+	}
+	return nil
+}
+
+/*
+ Parse a bar, either the array form
+	["2019-03-19T08:08:00.000Z","3.721","3.743","3.677","3.708","8422410","22698348.04828491"]
+ or the object form
+	{"time":"2019-03-19T08:08:00.000Z","open":"3.721","high":"3.743","low":"3.677","close":"3.708","volume":"8422410"}
+*/
+func parseCandle(row gjson.Result) (Candle, error) {
+	var c Candle
+	var ts string
+	if row.IsArray() {
+		fields := row.Array()
+		if len(fields) < 6 {
+			return c, fmt.Errorf("okex: illegal candle %s", row.Raw)
+		}
+		ts = fields[0].String()
+		c.Open, c.High, c.Low, c.Close, c.Volume = Decimal(fields[1].String()), Decimal(fields[2].String()),
+			Decimal(fields[3].String()), Decimal(fields[4].String()), Decimal(fields[5].String())
+		if len(fields) > 6 {
+			c.CurrencyVolume = Decimal(fields[6].String())
+		}
+	} else {
+		ts = row.Get("time").String()
+		c.Open, c.High, c.Low, c.Close, c.Volume = Decimal(row.Get("open").String()), Decimal(row.Get("high").String()),
+			Decimal(row.Get("low").String()), Decimal(row.Get("close").String()), Decimal(row.Get("volume").String())
+	}
+	for _, v := range []Decimal{c.Open, c.High, c.Low, c.Close, c.Volume, c.CurrencyVolume} {
+		if !v.Valid() {
+			return c, fmt.Errorf("okex: illegal candle %s", row.Raw)
+		}
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return c, fmt.Errorf("okex: illegal candle time %s", row.Raw)
+	}
+	c.Time = t
+	return c, nil
+}
+
+/*
+ Merge bars into bars of a larger granularity, eg: 1m -> 5m.
+ Candles must be oldest first, the result is oldest first as well. Bars are
+ aligned to the utc day, weekly bars start on monday. The last bar may be
+ incomplete.
+*/
+func ResampleCandles(candles []Candle, to Granularity) ([]Candle, error) {
+	if to <= 0 {
+		return nil, fmt.Errorf("okex: illegal granularity %d", to)
+	}
+	var result []Candle
+	for i, c := range candles {
+		if i > 0 && c.Time.Before(candles[i-1].Time) {
+			return nil, fmt.Errorf("okex: candles out of order at %s", isoTimeOf(c.Time))
+		}
+		// time.Truncate is relative to 0001-01-01, a monday
+		start := c.Time.Truncate(to.Duration())
+		n := len(result)
+		if n == 0 || !result[n-1].Time.Equal(start) {
+			c.Time = start
+			result = append(result, c)
+			continue
+		}
+		bar := &result[n-1]
+		if c.High.Cmp(bar.High) > 0 {
+			bar.High = c.High
+		}
+		if c.Low.Cmp(bar.Low) < 0 {
+			bar.Low = c.Low
+		}
+		bar.Close = c.Close
+		bar.Volume = bar.Volume.Add(c.Volume)
+		if c.CurrencyVolume != "" {
+			bar.CurrencyVolume = bar.CurrencyVolume.Add(c.CurrencyVolume)
+		}
+	}
+	return result, nil
+}
+
+/*
+ Candles of one request, at most 200 bars ending at End.
+ A zero Granularity, Start or End is left to the api (60s bars, the latest).
+*/
+type CandlesParams struct {
+	Granularity Granularity
+	Start       time.Time
+	End         time.Time
+}
+
+func (p *CandlesParams) params() map[string]string {
+	params := NewParams()
+	if p == nil {
+		return params
+	}
+	if p.Granularity > 0 {
+		params["granularity"] = Int2String(int(p.Granularity))
+	}
+	if !p.Start.IsZero() {
+		params["start"] = isoTimeOf(p.Start)
+	}
+	if !p.End.IsZero() {
+		params["end"] = isoTimeOf(p.End)
+	}
+	return params
+}
+
+/*
+ The candles uri of the instrument:
+  BTC-USD-SWAP          -> swap
+  BTC-USD-200327        -> futures
+  BTC-USD-200327-9000-C -> option
+  BTC-USDT              -> spot (margin shares the spot candles)
+*/
+func candlesUri(instrumentId string) string {
+	parts := strings.Split(instrumentId, "-")
+	switch {
+	case strings.HasSuffix(instrumentId, "-SWAP"):
+		return GetInstrumentIdUri(SWAP_INSTRUMENT_CANDLES, instrumentId)
+	case len(parts) == 3 && StringToInt(parts[2]) > 0:
+		return GetInstrumentIdUri(FUTURES_INSTRUMENT_CANDLES, instrumentId)
+	case len(parts) == 5 && (parts[4] == OptionTypeCall || parts[4] == OptionTypePut):
+		return GetInstrumentIdUri(OPTION_INSTRUMENT_CANDLES, instrumentId)
+	default:
+		return GetInstrumentIdUri(SPOT_INSTRUMENT_CANDLES, instrumentId)
+	}
+}
+
+/*
+ Get all bars of the instrument in [start, end), oldest first.
+
+ The range is requested page by page from end backwards, bars returned twice by
+ overlapping pages are dropped. Every range without a bar (the exchange has none,
+ eg: no trades or maintenance) is reported in the gaps.
+*/
+func (client *Client) BackfillCandles(instrumentId string, granularity Granularity, start, end time.Time) ([]Candle, []CandleGap, error) {
+	return client.BackfillCandlesCtx(context.Background(), instrumentId, granularity, start, end)
+}
+
+func (client *Client) BackfillCandlesCtx(ctx context.Context, instrumentId string, granularity Granularity,
+	start, end time.Time) ([]Candle, []CandleGap, error) {
+	if !granularity.Valid() {
+		return nil, nil, fmt.Errorf("okex: illegal granularity %d", granularity)
+	}
+	if !end.After(start) {
+		return nil, nil, fmt.Errorf("okex: illegal candle range %s - %s", isoTimeOf(start), isoTimeOf(end))
+	}
+
+	// the last bar starts before end
+	until := end.Add(-time.Nanosecond)
+	it := client.newCandleIterator(ctx, candlesUri(instrumentId), granularity, IterOptions{Since: start, Until: until})
+	seen := make(map[int64]bool)
+	var candles []Candle
+	for it.Next() {
+		var c Candle
+		if err := it.Decode(&c); err != nil {
+			return nil, nil, err
+		}
+		if seen[c.Time.UnixNano()] {
+			continue
+		}
+		seen[c.Time.UnixNano()] = true
+		candles = append(candles, c)
+	}
+	if err := it.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+	return candles, candleGaps(candles, granularity.Duration(), start, end), nil
+}
+
+func candleGaps(candles []Candle, bar time.Duration, start, end time.Time) []CandleGap {
+	var gaps []CandleGap
+	// the first bar of the range starts at start rounded up to the bar size
+	next := start.Truncate(bar)
+	if next.Before(start) {
+		next = next.Add(bar)
+	}
+	for _, c := range candles {
+		if c.Time.After(next) {
+			gaps = append(gaps, CandleGap{From: next, To: c.Time})
+		}
+		next = c.Time.Add(bar)
+	}
+	if next.Before(end) {
+		gaps = append(gaps, CandleGap{From: next, To: end})
+	}
+	return gaps
+}
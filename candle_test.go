@@ -0,0 +1,138 @@
+package okex
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandlesUri(t *testing.T) {
+	assert.Equal(t, "/api/swap/v3/instruments/BTC-USD-SWAP/candles", candlesUri("BTC-USD-SWAP"))
+	assert.Equal(t, "/api/futures/v3/instruments/BTC-USD-200327/candles", candlesUri("BTC-USD-200327"))
+	assert.Equal(t, "/api/spot/v3/instruments/BTC-USDT/candles", candlesUri("BTC-USDT"))
+	assert.Equal(t, "/api/option/v3/instruments/BTC-USD-200327-9000-C/candles", candlesUri("BTC-USD-200327-9000-C"))
+}
+
+func TestClient_BackfillCandles(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	// bars 0..9 minutes, 4 is missing
+	var bars []time.Time
+	for i := 0; i < 10; i++ {
+		if i != 4 {
+			bars = append(bars, base.Add(time.Duration(i)*time.Minute))
+		}
+	}
+	requests := 0
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/api/swap/v3/instruments/BTC-USD-SWAP/candles", r.URL.Path)
+		end, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("end"))
+		assert.Nil(t, err)
+		// newest first, 3 per page, the page includes the bar at end like the exchange does
+		var rows []string
+		for i := len(bars) - 1; i >= 0 && len(rows) < 3; i-- {
+			if !bars[i].After(end) {
+				rows = append(rows, fmt.Sprintf(`["%s","1","2","0.5","1.5","10","0.1"]`, isoTimeOf(bars[i])))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(rows, ",") + "]"))
+	})
+	defer server.Close()
+
+	candles, gaps, err := c.BackfillCandles("BTC-USD-SWAP", CANDLES_1MIN, base.Add(2*time.Minute), base.Add(9*time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, requests > 1)
+	var times []time.Time
+	for _, candle := range candles {
+		times = append(times, candle.Time)
+	}
+	assert.Equal(t, []time.Time{
+		base.Add(2 * time.Minute), base.Add(3 * time.Minute), base.Add(5 * time.Minute),
+		base.Add(6 * time.Minute), base.Add(7 * time.Minute), base.Add(8 * time.Minute),
+	}, times)
+	assert.Equal(t, Decimal("1.5"), candles[0].Close)
+	assert.Equal(t, Decimal("0.1"), candles[0].CurrencyVolume)
+	assert.Equal(t, []CandleGap{{From: base.Add(4 * time.Minute), To: base.Add(5 * time.Minute)}}, gaps)
+
+	_, _, err = c.BackfillCandles("BTC-USD-SWAP", CANDLES_1MIN, base, base)
+	assert.NotNil(t, err)
+}
+
+func TestGranularity(t *testing.T) {
+	assert.True(t, CANDLES_4HOUR.Valid())
+	assert.False(t, Granularity(120).Valid())
+	assert.Equal(t, 4*time.Hour, CANDLES_4HOUR.Duration())
+	assert.Equal(t, "15m", CANDLES_15MIN.String())
+	assert.Equal(t, "1d", CANDLES_1DAY.String())
+	assert.Equal(t, "1w", CANDLES_1WEEK.String())
+	assert.Equal(t, 12, len(Granularities()))
+}
+
+func TestCandle_UnmarshalJSON(t *testing.T) {
+	var candles []Candle
+	err := JsonString2Struct(`[["2019-03-19T08:08:00.000Z","3.721","3.743","3.677","3.708","8422410","22698348.04828491"],`+
+		`{"time":"2019-03-19T08:09:00.000Z","open":"3.708","high":"3.71","low":"3.7","close":"3.7","volume":"10"}]`, &candles)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(candles))
+	assert.Equal(t, time.Date(2019, 3, 19, 8, 8, 0, 0, time.UTC), candles[0].Time)
+	assert.Equal(t, Decimal("3.743"), candles[0].High)
+	assert.Equal(t, Decimal("22698348.04828491"), candles[0].CurrencyVolume)
+	assert.Equal(t, Decimal("3.71"), candles[1].High)
+	assert.Nil(t, candles[0].Validate())
+	assert.Nil(t, candles[1].Validate())
+
+	assert.NotNil(t, JsonString2Struct(`[["2019-03-19T08:08:00.000Z","3.721"]]`, &candles))
+
+	bad := Candle{Open: "5", High: "4", Low: "3", Close: "3.5", Volume: "1"}
+	assert.NotNil(t, bad.Validate())
+	bad = Candle{Open: "3", High: "4", Low: "3", Close: "3.5", Volume: "x"}
+	assert.NotNil(t, bad.Validate())
+}
+
+func TestResampleCandles(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for i, p := range []Decimal{"10", "12", "9", "11", "13", "14"} {
+		candles = append(candles, Candle{
+			Time: base.Add(time.Duration(i) * time.Minute), Open: p, High: p, Low: p, Close: p,
+			Volume: "0.1", CurrencyVolume: "1",
+		})
+	}
+	bars, err := ResampleCandles(candles, CANDLES_5MIN)
+	assert.Nil(t, err)
+	assert.Equal(t, []Candle{
+		{Time: base, Open: "10", High: "13", Low: "9", Close: "13", Volume: "0.5", CurrencyVolume: "5"},
+		{Time: base.Add(5 * time.Minute), Open: "14", High: "14", Low: "14", Close: "14", Volume: "0.1", CurrencyVolume: "1"},
+	}, bars)
+
+	weekly, err := ResampleCandles(candles[:1], CANDLES_1WEEK)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Monday, weekly[0].Time.Weekday())
+
+	_, err = ResampleCandles([]Candle{candles[1], candles[0]}, CANDLES_5MIN)
+	assert.NotNil(t, err)
+}
+
+func TestClient_CandlesTyped(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[["2019-03-19T08:08:00.000Z","3.721","3.743","3.677","3.708","8422410","22698348.04828491"]]`))
+	})
+	defer server.Close()
+
+	swap, err := c.GetSwapCandlesByInstrument("BTC-USD-SWAP", nil)
+	assert.Nil(t, err)
+	futures, err := c.GetFuturesInstrumentCandles("BTC-USD-200327", &CandlesParams{Granularity: CANDLES_1MIN})
+	assert.Nil(t, err)
+	spot, err := c.GetSpotInstrumentCandles("BTC-USDT", nil)
+	assert.Nil(t, err)
+	option, err := c.GetOptionCandles("BTC-USD-200327-9000-C", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, swap, futures)
+	assert.Equal(t, spot, option)
+	assert.Equal(t, futures, spot)
+	assert.Equal(t, Decimal("3.708"), spot[0].Close)
+}
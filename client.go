@@ -1,14 +1,13 @@
 package okex
 
 import (
-	"bytes"
-	"errors"
-	"fmt"
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,6 +21,18 @@ import (
 type Client struct {
 	Config     Config
 	HttpClient *http.Client
+
+	logger        Logger
+	signer        Signer
+	limiter       *rateLimiter
+	clock         *Clock
+	middlewares   []Middleware
+	clockSyncMu   sync.Mutex
+	stopClockSync context.CancelFunc
+	instruments   *InstrumentRegistry
+
+	// instrument ids answering that they have no order amendment
+	amendUnsupported sync.Map
 }
 
 type ApiMessage struct {
@@ -35,33 +46,112 @@ type ApiMessage struct {
 func NewClient(config Config) *Client {
 	var client Client
 	client.Config = config
-	httpClient := config.HTTPClient
-	if httpClient == nil {
-		timeout := config.TimeoutSecond
-		if timeout <= 0 {
-			timeout = 30
-		}
-		transport := &http.Transport{}
-		if config.ProxyURL != "" {
-			proxyURL_, err := url.Parse(config.ProxyURL)
-			if err != nil {
-				return nil
-			}
-			transport.Proxy = http.ProxyURL(proxyURL_)
-		}
-		httpClient = &http.Client{
-			Transport: transport,
-			Timeout:   time.Duration(timeout) * time.Second,
-		}
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil
 	}
 	client.HttpClient = httpClient
+	client.logger = newClientLogger(config)
+	client.signer = newSigner(config.Signer, config.SecretKey)
+	client.limiter = newRateLimiter(config.RateLimit)
+	client.Use(config.Middlewares...)
+	client.clock = &Clock{}
+	if config.ClockSyncInterval > 0 {
+		client.StartClockSync(config.ClockSyncInterval)
+	}
 	return &client
 }
 
+/*
+ The configured http client, or a new one with the config's timeout and proxy
+*/
+func newHTTPClient(config Config) (*http.Client, error) {
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+	timeout := config.TimeoutSecond
+	if timeout <= 0 {
+		timeout = 30
+	}
+	transport := &http.Transport{}
+	if config.ProxyURL != "" {
+		proxyURL_, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL_)
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+/*
+ The configured logger, or a stderr logger at debug level when IsPrint is set.
+ Credentials are redacted unless LogSecrets is set.
+*/
+func newClientLogger(config Config) Logger {
+	logger := config.Logger
+	if logger == nil {
+		logger = NewStdLogger(T3O(config.IsPrint, LogLevelDebug, LogLevelInfo).(LogLevel))
+	}
+	if config.LogSecrets {
+		return logger
+	}
+	return newRedactingLogger(logger, config.ApiKey, config.SecretKey, config.Passphrase)
+}
+
 /*
  Send a http request to remote server and get a response data
 */
 func (client *Client) Request(method string, requestPath string,
+	params, result interface{}) (respBody []byte, response *http.Response, err error) {
+	return client.RequestCtx(context.Background(), method, requestPath, params, result)
+}
+
+/*
+ Same as Request, but the request is bound to ctx: it is abandoned as soon as
+ ctx is cancelled or its deadline passes. Every public api method has a
+ *Ctx variant built on top of this, eg: GetSwapPositionsCtx(ctx).
+*/
+func (client *Client) RequestCtx(ctx context.Context, method string, requestPath string,
+	params, result interface{}) (respBody []byte, response *http.Response, err error) {
+	policy := client.Config.Retry
+	if method != GET || !policy.enabled() {
+		return client.doRequestSynced(ctx, method, requestPath, params, result)
+	}
+	for attempt := 1; ; attempt++ {
+		respBody, response, err = client.doRequestSynced(ctx, method, requestPath, params, result)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) || ctx.Err() != nil {
+			return respBody, response, err
+		}
+		if sleepErr := sleepCtx(ctx, policy.backoff(attempt)); sleepErr != nil {
+			return respBody, response, err
+		}
+	}
+}
+
+/*
+ Send the request, resync the clock and resend it once when the server rejects its timestamp
+*/
+func (client *Client) doRequestSynced(ctx context.Context, method string, requestPath string,
+	params, result interface{}) (respBody []byte, response *http.Response, err error) {
+	respBody, response, err = client.doRequest(ctx, method, requestPath, params, result)
+	if err == nil || client.Config.ClockSyncInterval <= 0 || requestPath == OKEX_TIME_URI ||
+		ErrorClassOf(err) != ErrorClassInvalidTimestamp {
+		return respBody, response, err
+	}
+	if _, syncErr := client.SyncServerTime(ctx); syncErr != nil {
+		return respBody, response, err
+	}
+	return client.doRequest(ctx, method, requestPath, params, result)
+}
+
+/*
+ Send the request exactly once
+*/
+func (client *Client) doRequest(ctx context.Context, method string, requestPath string,
 	params, result interface{}) (respBody []byte, response *http.Response, err error) {
 	config := client.Config
 	// uri
@@ -69,42 +159,66 @@ func (client *Client) Request(method string, requestPath string,
 	if strings.HasSuffix(config.Endpoint, "/") {
 		endpoint = config.Endpoint[0 : len(config.Endpoint)-1]
 	}
-	url := endpoint + requestPath
 
 	// get json and bin styles request body
 	var jsonBody string
-	var binBody = bytes.NewReader(make([]byte, 0))
 	if params != nil {
-		jsonBody, binBody, err = ParseRequestParams(params)
+		jsonBody, _, err = ParseRequestParams(params)
 		if err != nil {
 			return respBody, response, err
 		}
 	}
 
+	hookReq := &HookRequest{Method: method, Path: requestPath, Body: jsonBody, Start: time.Now()}
+	if len(client.middlewares) > 0 {
+		defer func() {
+			err = client.afterResponse(ctx, hookReq, response, respBody, err)
+		}()
+	}
+	if err = client.beforeSign(ctx, hookReq); err != nil {
+		return respBody, response, err
+	}
+	requestPath, jsonBody = hookReq.Path, hookReq.Body
+
+	// throttled by the path as rewritten by the middlewares
+	if err = client.limiter.wait(ctx, method, requestPath); err != nil {
+		return respBody, response, err
+	}
+	url := endpoint + requestPath
+
 	// get a http request
-	request, err := http.NewRequest(method, url, binBody)
+	request, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(jsonBody))
 	if err != nil {
 		return respBody, response, err
 	}
 
 	// Sign and set request headers
-	timestamp := IsoTime()
+	timestamp := client.clock.IsoTime()
 	preHash := PreHashString(timestamp, method, requestPath, jsonBody)
-	sign, err := HmacSha256Base64Signer(preHash, config.SecretKey)
+	sign, err := client.signer.Sign(preHash)
 	if err != nil {
 		return respBody, response, err
 	}
 	Headers(request, config, timestamp, sign)
 
 	if config.IsPrint {
-		printRequest(config, request, jsonBody, preHash)
+		client.logRequest(request, jsonBody, preHash)
 	}
 
 	// send a request to remote server, and get a response
-	response, err = client.HttpClient.Do(request)
+	response, err = client.afterSign(ctx, hookReq, request)
 	if err != nil {
+		if response != nil {
+			response.Body.Close()
+		}
 		return respBody, response, err
 	}
+	if response == nil {
+		response, err = client.HttpClient.Do(request)
+		if err != nil {
+			return respBody, response, err
+		}
+	}
 	defer response.Body.Close()
 
 	// get a response results and parse
@@ -118,7 +232,7 @@ func (client *Client) Request(method string, requestPath string,
 	respBody = body
 
 	if config.IsPrint {
-		printResponse(status, message, body)
+		client.logResponse(status, message, body)
 	}
 
 	responseBodyString := string(body)
@@ -144,57 +258,36 @@ func (client *Client) Request(method string, requestPath string,
 		response.Header.Add(ResultPageJsonString, pageJsonString)
 	}
 
-	if status >= 200 && status < 300 {
-		//fmt.Printf("Resp: %v", string(body))
-		if body != nil && result != nil {
-			err := JsonBytes2Struct(body, result)
-			if err != nil {
-				return respBody, response, err
-			}
-		}
-		return respBody, response, nil
-	} else if status >= 400 || status <= 500 {
-		errMsg := "Http error(400~500) result: status=" + IntToString(status) + ", message=" + message + ", body=" + responseBodyString
-		fmt.Println(errMsg)
-		if body != nil {
-			err := errors.New(errMsg)
+	if status < 200 || status >= 300 {
+		return respBody, response, newAPIError(method, requestPath, status, body)
+	}
+	if body != nil && result != nil {
+		if err := JsonBytes2Struct(body, result); err != nil {
 			return respBody, response, err
 		}
-	} else {
-		fmt.Println("Http error result: status=" + IntToString(status) + ", message=" + message + ", body=" + responseBodyString)
-		return respBody, response, errors.New(message)
 	}
 	return respBody, response, nil
 }
 
-func printRequest(config Config, request *http.Request, body string, preHash string) {
-	if config.SecretKey != "" {
-		fmt.Println("  Secret-Key: " + config.SecretKey)
-	}
-	fmt.Println("  Request(" + IsoTime() + "):")
-	fmt.Println("\tUrl: " + request.URL.String())
-	fmt.Println("\tMethod: " + strings.ToUpper(request.Method))
-	if len(request.Header) > 0 {
-		fmt.Println("\tHeaders: ")
-		for k, v := range request.Header {
-			if strings.Contains(k, "Ok-") {
-				k = strings.ToUpper(k)
-			}
-			fmt.Println("\t\t" + k + ": " + v[0])
-		}
+func (client *Client) logRequest(request *http.Request, body string, preHash string) {
+	fields := []Field{
+		F("url", request.URL.String()),
+		F("method", strings.ToUpper(request.Method)),
 	}
-	fmt.Println("\tBody: " + body)
-	if preHash != "" {
-		fmt.Println("  PreHash: " + preHash)
+	for k, v := range request.Header {
+		if strings.Contains(k, "Ok-") {
+			k = strings.ToUpper(k)
+		}
+		fields = append(fields, F(k, v[0]))
 	}
+	fields = append(fields, F("body", body), F("pre_hash", preHash))
+	client.logger.Log(LogLevelDebug, "request", fields...)
 }
 
-func printResponse(status int, message string, body []byte) {
-	fmt.Println("  Response(" + IsoTime() + "):")
+func (client *Client) logResponse(status int, message string, body []byte) {
 	statusString := strconv.Itoa(status)
 	message = strings.Replace(message, statusString, "", -1)
 	message = strings.Trim(message, " ")
-	fmt.Println("\tStatus: " + statusString)
-	fmt.Println("\tMessage: " + message)
-	fmt.Println("\tBody: " + string(body))
+	client.logger.Log(LogLevelDebug, "response",
+		F("status", status), F("message", message), F("body", string(body)))
 }
@@ -0,0 +1,68 @@
+package okex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newLocalTestClient(handler http.HandlerFunc) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	var config Config
+	config.Endpoint = server.URL + "/"
+	config.ApiKey = "test-api-key"
+	config.SecretKey = "test-secret-key"
+	config.Passphrase = "test-passphrase"
+	config.TimeoutSecond = 5
+	config.I18n = ENGLISH
+	return NewClient(config), server
+}
+
+func TestClient_RequestCtxCancel(t *testing.T) {
+	release := make(chan struct{})
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetSwapPositionsCtx(ctx)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < 2*time.Second)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestClient_RequestCtxResult(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, OKEX_TIME_URI, r.URL.Path)
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+
+	serverTime, err := c.GetServerTimeCtx(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-04-12T10:24:19.913Z", serverTime.Iso)
+}
+
+func TestClient_Simulated(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.Header.Get(X_SIMULATED_TRADING))
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	c.Config.Simulated = true
+
+	_, err := c.GetServerTime()
+	assert.Nil(t, err)
+}
@@ -0,0 +1,116 @@
+package okex
+
+/*
+ Server clock offset tracking.
+
+ OKEx rejects requests whose timestamp is too far from the server time, so the
+ client can measure the offset between the local clock and the server clock
+ and sign every request with local time + offset.
+*/
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is local time corrected by a measured server offset. A nil *Clock is the local clock.
+type Clock struct {
+	offset int64 // nanoseconds, server - local
+}
+
+func (c *Clock) Offset() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&c.offset))
+}
+
+func (c *Clock) SetOffset(offset time.Duration) {
+	atomic.StoreInt64(&c.offset, int64(offset))
+}
+
+// Now returns the estimated server time.
+func (c *Clock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+/*
+ Server time in iso format, eg: 2018-03-16T18:02:48.284Z
+*/
+func (c *Clock) IsoTime() string {
+	return isoTimeOf(c.Now())
+}
+
+/*
+ Server time in epoch format, eg: 1521221737.376
+*/
+func (c *Clock) EpochTime() string {
+	return epochTimeOf(c.Now())
+}
+
+/*
+ Measure the offset to the server clock with GetServerTime and apply it to
+ the client's clock. The request latency is split evenly between both ways.
+*/
+func (client *Client) SyncServerTime(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	serverTime, err := client.GetServerTimeCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	end := time.Now()
+	epoch, err := strconv.ParseFloat(serverTime.Epoch, 64)
+	if err != nil {
+		return 0, err
+	}
+	server := time.Unix(0, int64(epoch*float64(time.Second)))
+	local := start.Add(end.Sub(start) / 2)
+	offset := server.Sub(local)
+	client.clock.SetOffset(offset)
+	return offset, nil
+}
+
+/*
+ Clock used to sign requests, share it with SwapWS/FuturesWS through SetClock.
+*/
+func (client *Client) Clock() *Clock {
+	return client.clock
+}
+
+/*
+ Sync the clock and then every interval, until StopClockSync is called.
+ The first sync is done before StartClockSync returns, so the following
+ requests are signed with the synced clock. A failed sync is logged.
+*/
+func (client *Client) StartClockSync(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client.clockSyncMu.Lock()
+	if client.stopClockSync != nil {
+		client.stopClockSync()
+	}
+	client.stopClockSync = cancel
+	client.clockSyncMu.Unlock()
+
+	syncServerTime := func() {
+		if _, err := client.SyncServerTime(ctx); err != nil && ctx.Err() == nil {
+			client.logger.Log(LogLevelWarn, "sync server time failed", F("error", err))
+		}
+	}
+	syncServerTime()
+	go func() {
+		for sleepCtx(ctx, interval) == nil {
+			syncServerTime()
+		}
+	}()
+}
+
+func (client *Client) StopClockSync() {
+	client.clockSyncMu.Lock()
+	defer client.clockSyncMu.Unlock()
+	if client.stopClockSync != nil {
+		client.stopClockSync()
+		client.stopClockSync = nil
+	}
+}
@@ -0,0 +1,84 @@
+package okex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serverTimeHandler(w http.ResponseWriter, offset time.Duration) {
+	now := time.Now().Add(offset)
+	epoch := fmt.Sprintf("%d.%03d", now.Unix(), now.Nanosecond()/int(time.Millisecond))
+	w.Write([]byte(`{"iso":"` + isoTimeOf(now) + `","epoch":"` + epoch + `"}`))
+}
+
+func TestClock_SyncServerTime(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		serverTimeHandler(w, -time.Minute)
+	})
+	defer server.Close()
+
+	offset, err := c.SyncServerTime(context.Background())
+	assert.Nil(t, err)
+	assert.InDelta(t, float64(-time.Minute), float64(offset), float64(time.Second))
+	assert.Equal(t, offset, c.Clock().Offset())
+	assert.InDelta(t, float64(time.Now().Add(-time.Minute).UnixNano()), float64(c.Clock().Now().UnixNano()), float64(time.Second))
+
+	var nilClock *Clock
+	assert.Equal(t, time.Duration(0), nilClock.Offset())
+}
+
+func TestClock_ResyncOnInvalidTimestamp(t *testing.T) {
+	var syncs, calls int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OKEX_TIME_URI {
+			atomic.AddInt32(&syncs, 1)
+			serverTimeHandler(w, time.Hour)
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		ts, _ := IsoToTime(r.Header.Get(OK_ACCESS_TIMESTAMP))
+		if time.Until(ts) < 30*time.Minute {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":30008,"message":"Timestamp request expired"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+	c.Config.ClockSyncInterval = time.Hour
+
+	_, err := c.GetSwapPositions()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&syncs))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestClock_StartClockSync(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		serverTimeHandler(w, time.Hour)
+	})
+	defer server.Close()
+
+	// synced before the first request
+	c.StartClockSync(time.Hour)
+	assert.InDelta(t, float64(time.Hour), float64(c.Clock().Offset()), float64(time.Second))
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			c.StartClockSync(time.Hour)
+			c.StopClockSync()
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	c.StopClockSync()
+}
@@ -0,0 +1,212 @@
+package okex
+
+/*
+ Market close (市价全平) of futures and swap positions.
+
+ The exchange closes one side of one instrument per request. The methods here
+ send one request per side, go on after a failure and report every side in
+ the ClosePositionInfo of the result, so a single call flattens everything:
+
+	result, err := client.CloseSwapPositionsByUnderlying("BTC-USD")
+
+ Open orders are left as they are.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+/*
+ Close the position of the instrument at market.
+ direction: long, short, "" closes every side holding contracts.
+*/
+func (client *Client) CloseFuturesPosition(instrumentId, direction string) (FuturesClosePositionResult, error) {
+	return client.CloseFuturesPositionCtx(context.Background(), instrumentId, direction)
+}
+
+func (client *Client) CloseFuturesPositionCtx(ctx context.Context, instrumentId, direction string) (FuturesClosePositionResult, error) {
+	if direction != "" {
+		return client.CloseFuturesPositionsCtx(ctx, FuturesClosePositionParams{
+			ClosePositionData: []ClosePositionData{{InstrumentId: instrumentId, Direction: direction}}})
+	}
+	position, err := client.GetFuturesInstrumentPositionCtx(ctx, instrumentId)
+	if err != nil {
+		return FuturesClosePositionResult{}, err
+	}
+	return client.CloseFuturesPositionsCtx(ctx, futuresCloseParams(position, func(string) bool { return true }))
+}
+
+/*
+ Close all positions of the underlying (eg: BTC-USD, BTC-USDT) at market, in
+ every delivery, "" closes the positions of all underlyings.
+*/
+func (client *Client) CloseFuturesPositionsByUnderlying(underlying string) (FuturesClosePositionResult, error) {
+	return client.CloseFuturesPositionsByUnderlyingCtx(context.Background(), underlying)
+}
+
+func (client *Client) CloseFuturesPositionsByUnderlyingCtx(ctx context.Context, underlying string) (FuturesClosePositionResult, error) {
+	position, err := client.GetFuturesPositionsCtx(ctx)
+	if err != nil {
+		return FuturesClosePositionResult{}, err
+	}
+	return client.CloseFuturesPositionsCtx(ctx, futuresCloseParams(position, func(instrumentId string) bool {
+		return ofUnderlying(instrumentId, underlying)
+	}))
+}
+
+/*
+ Close every side of params at market. All sides are tried, the error reports
+ the failed ones and the result the outcome of each.
+*/
+func (client *Client) CloseFuturesPositions(params FuturesClosePositionParams) (FuturesClosePositionResult, error) {
+	return client.CloseFuturesPositionsCtx(context.Background(), params)
+}
+
+func (client *Client) CloseFuturesPositionsCtx(ctx context.Context, params FuturesClosePositionParams) (FuturesClosePositionResult, error) {
+	var result FuturesClosePositionResult
+	var err error
+	result.ClosePositionInfo, err = client.closePositions(ctx, FUTURES_CLOSE_POSITION, params.ClosePositionData)
+	result.Result.Result = err == nil
+	return result, err
+}
+
+/*
+ Close the position of the instrument at market.
+ direction: long, short, "" closes every side holding contracts.
+*/
+func (client *Client) CloseSwapPosition(instrumentId, direction string) (SwapClosePositionResult, error) {
+	return client.CloseSwapPositionCtx(context.Background(), instrumentId, direction)
+}
+
+func (client *Client) CloseSwapPositionCtx(ctx context.Context, instrumentId, direction string) (SwapClosePositionResult, error) {
+	if direction != "" {
+		return client.CloseSwapPositionsCtx(ctx, FuturesClosePositionParams{
+			ClosePositionData: []ClosePositionData{{InstrumentId: instrumentId, Direction: direction}}})
+	}
+	position, err := client.GetSwapPositionByInstrumentCtx(ctx, instrumentId)
+	if err != nil {
+		return SwapClosePositionResult{}, err
+	}
+	return client.CloseSwapPositionsCtx(ctx, swapCloseParams([]SwapPosition{position}, func(string) bool { return true }))
+}
+
+// Close all swap positions of the underlying (eg: BTC-USD, BTC-USDT) at market, "" closes all
+func (client *Client) CloseSwapPositionsByUnderlying(underlying string) (SwapClosePositionResult, error) {
+	return client.CloseSwapPositionsByUnderlyingCtx(context.Background(), underlying)
+}
+
+func (client *Client) CloseSwapPositionsByUnderlyingCtx(ctx context.Context, underlying string) (SwapClosePositionResult, error) {
+	positions, err := client.GetSwapPositionsCtx(ctx)
+	if err != nil {
+		return SwapClosePositionResult{}, err
+	}
+	return client.CloseSwapPositionsCtx(ctx, swapCloseParams(*positions, func(instrumentId string) bool {
+		return ofUnderlying(instrumentId, underlying)
+	}))
+}
+
+// @see CloseFuturesPositions
+func (client *Client) CloseSwapPositions(params FuturesClosePositionParams) (SwapClosePositionResult, error) {
+	return client.CloseSwapPositionsCtx(context.Background(), params)
+}
+
+func (client *Client) CloseSwapPositionsCtx(ctx context.Context, params FuturesClosePositionParams) (SwapClosePositionResult, error) {
+	var result SwapClosePositionResult
+	var err error
+	result.ClosePositionInfo, err = client.closePositions(ctx, SWAP_CLOSE_POSITION, params.ClosePositionData)
+	result.Result.Result = err == nil
+	return result, err
+}
+
+// eg: BTC-USD-SWAP and BTC-USD-200925 are of BTC-USD, BTC-USDT-SWAP is not
+func ofUnderlying(instrumentId, underlying string) bool {
+	if underlying == "" {
+		return true
+	}
+	return strings.HasPrefix(strings.ToUpper(instrumentId), strings.ToUpper(underlying)+"-") &&
+		strings.Count(instrumentId, "-") == strings.Count(underlying, "-")+1
+}
+
+func futuresCloseParams(position FuturesPosition, match func(instrumentId string) bool) FuturesClosePositionParams {
+	var holdings []FuturesPositionBase
+	for _, h := range position.CrossPosition {
+		holdings = append(holdings, h.FuturesPositionBase)
+	}
+	for _, h := range position.FixedPosition {
+		holdings = append(holdings, h.FuturesPositionBase)
+	}
+	var params FuturesClosePositionParams
+	for _, h := range holdings {
+		if !match(h.InstrumentId) {
+			continue
+		}
+		if h.LongQty.Sign() > 0 {
+			params.ClosePositionData = append(params.ClosePositionData, ClosePositionData{InstrumentId: h.InstrumentId, Direction: PositionSideLong})
+		}
+		if h.ShortQty.Sign() > 0 {
+			params.ClosePositionData = append(params.ClosePositionData, ClosePositionData{InstrumentId: h.InstrumentId, Direction: PositionSideShort})
+		}
+	}
+	return params
+}
+
+func swapCloseParams(positions []SwapPosition, match func(instrumentId string) bool) FuturesClosePositionParams {
+	var params FuturesClosePositionParams
+	for _, position := range positions {
+		for _, h := range position.Holding {
+			if !match(h.InstrumentId) {
+				continue
+			}
+			if side, contracts := h.direction(); contracts.Sign() > 0 {
+				params.ClosePositionData = append(params.ClosePositionData, ClosePositionData{InstrumentId: h.InstrumentId, Direction: side})
+			}
+		}
+	}
+	return params
+}
+
+/*
+ Post every side to uri. A side failing with an APIError keeps its code and
+ message in its info, other errors (eg: ctx done) get code -1.
+*/
+func (client *Client) closePositions(ctx context.Context, uri string, data []ClosePositionData) ([]ClosePositionInfo, error) {
+	infos := make([]ClosePositionInfo, 0, len(data))
+	var failed []string
+	var firstErr error
+	for _, d := range data {
+		info := ClosePositionInfo{InstrumentId: d.InstrumentId, Direction: d.Direction}
+		body, _, err := client.RequestCtx(ctx, POST, uri, d, nil)
+		if err == nil {
+			ret := gjson.ParseBytes(body)
+			if code := ret.Get("error_code").Int(); code != 0 {
+				err = fmt.Errorf("okex: error code %d, message %q", code, ret.Get("error_message").String())
+				info.ErrorCode, info.ErrorMessage = code, ret.Get("error_message").String()
+			}
+		} else {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				info.ErrorCode, info.ErrorMessage = int64(apiErr.Code), apiErr.Message
+			} else {
+				info.ErrorCode, info.ErrorMessage = -1, err.Error()
+			}
+		}
+		if err != nil {
+			failed = append(failed, d.InstrumentId+" "+d.Direction)
+			if firstErr == nil {
+				firstErr = err
+			}
+			client.logger.Log(LogLevelWarn, "close position failed", F("instrument_id", d.InstrumentId),
+				F("direction", d.Direction), F("error", err))
+		}
+		infos = append(infos, info)
+	}
+	if firstErr != nil {
+		return infos, fmt.Errorf("okex: close %s failed: %w", strings.Join(failed, ", "), firstErr)
+	}
+	return infos, nil
+}
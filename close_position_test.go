@@ -0,0 +1,108 @@
+package okex
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestOfUnderlying(t *testing.T) {
+	assert.True(t, ofUnderlying("BTC-USD-SWAP", "BTC-USD"))
+	assert.True(t, ofUnderlying("BTC-USD-200925", "btc-usd"))
+	assert.False(t, ofUnderlying("BTC-USDT-SWAP", "BTC-USD"))
+	assert.False(t, ofUnderlying("ETH-USD-SWAP", "BTC-USD"))
+	assert.True(t, ofUnderlying("ETH-USD-SWAP", ""))
+}
+
+func TestClient_CloseFuturesPositionsByUnderlying(t *testing.T) {
+	var closed []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FUTURES_POSITION:
+			w.Write([]byte(`{"result":true,"margin_mode":"crossed","holding":[` +
+				`{"instrument_id":"BTC-USD-200925","long_qty":"2","short_qty":"1"},` +
+				`{"instrument_id":"BTC-USD-201225","long_qty":"0","short_qty":"3"},` +
+				`{"instrument_id":"ETH-USD-200925","long_qty":"5","short_qty":"0"}]}`))
+		case FUTURES_CLOSE_POSITION:
+			body, _ := ioutil.ReadAll(r.Body)
+			id, direction := gjson.GetBytes(body, "instrument_id").String(), gjson.GetBytes(body, "direction").String()
+			closed = append(closed, id+" "+direction)
+			if id == "BTC-USD-201225" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error_code":"32014","error_message":"Positions that you are closing exceeded the total qty of contracts"}`))
+				return
+			}
+			w.Write([]byte(`{"instrument_id":"` + id + `","result":true,"error_message":"","error_code":"0"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.CloseFuturesPositionsByUnderlying("BTC-USD")
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+	assert.False(t, result.Result.Result)
+	assert.Equal(t, []string{"BTC-USD-200925 long", "BTC-USD-200925 short", "BTC-USD-201225 short"}, closed)
+	assert.Equal(t, 3, len(result.ClosePositionInfo))
+	assert.Equal(t, int64(0), result.ClosePositionInfo[0].ErrorCode)
+	assert.Equal(t, int64(32014), result.ClosePositionInfo[2].ErrorCode)
+
+	closed = nil
+	result, err = c.CloseFuturesPosition("ETH-USD-200925", PositionSideLong)
+	assert.Nil(t, err)
+	assert.True(t, result.Result.Result)
+	assert.Equal(t, []string{"ETH-USD-200925 long"}, closed)
+}
+
+func TestClient_CloseSwapPosition(t *testing.T) {
+	var closed []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/swap/v3/BTC-USDT-SWAP/position":
+			w.Write([]byte(`{"margin_mode":"crossed","holding":[{"instrument_id":"BTC-USDT-SWAP","side":"long","position":"10"},` +
+				`{"instrument_id":"BTC-USDT-SWAP","side":"short","position":"0"}]}`))
+		case SWAP_CLOSE_POSITION:
+			body, _ := ioutil.ReadAll(r.Body)
+			closed = append(closed, gjson.GetBytes(body, "instrument_id").String()+" "+gjson.GetBytes(body, "direction").String())
+			w.Write([]byte(`{"instrument_id":"BTC-USDT-SWAP","direction":"long","result":"true","error_code":"0","error_message":""}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.CloseSwapPosition("BTC-USDT-SWAP", "")
+	assert.Nil(t, err)
+	assert.True(t, result.Result.Result)
+	assert.Equal(t, []string{"BTC-USDT-SWAP long"}, closed)
+	assert.Equal(t, []ClosePositionInfo{{InstrumentId: "BTC-USDT-SWAP", Direction: PositionSideLong}}, result.ClosePositionInfo)
+}
+
+func TestClient_CloseSwapPositionsByUnderlying_Net(t *testing.T) {
+	var closed []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SWAP_POSITION:
+			w.Write([]byte(`[{"margin_mode":"crossed","holding":[{"instrument_id":"BTC-USD-SWAP","side":"net","position":"3"}]},` +
+				`{"margin_mode":"crossed","holding":[{"instrument_id":"BTC-USD-SWAP","side":"net","position":"-2"}]},` +
+				`{"margin_mode":"crossed","holding":[{"instrument_id":"BTC-USD-SWAP","side":"net","position":"0"}]}]`))
+		case SWAP_CLOSE_POSITION:
+			body, _ := ioutil.ReadAll(r.Body)
+			closed = append(closed, gjson.GetBytes(body, "instrument_id").String()+" "+gjson.GetBytes(body, "direction").String())
+			w.Write([]byte(`{"instrument_id":"BTC-USD-SWAP","result":"true","error_code":"0","error_message":""}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.CloseSwapPositionsByUnderlying("BTC-USD")
+	assert.Nil(t, err)
+	assert.True(t, result.Result.Result)
+	assert.Equal(t, []string{"BTC-USD-SWAP long", "BTC-USD-SWAP short"}, closed)
+}
@@ -1,6 +1,9 @@
 package okex
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 /*
  OKEX api config info
@@ -20,16 +23,36 @@ type Config struct {
 	ApiKey string
 	// The user's secret key provided by OKEx. The secret key used to sign your request data.
 	SecretKey string
+	// Signs requests instead of SecretKey, eg: a UnixSocketSigner. nil signs with HMAC over SecretKey.
+	Signer Signer
 	// The Passphrase will be provided by you to further secure your API access.
 	Passphrase string
 	// Http request timeout.
 	TimeoutSecond int
-	// Whether to print API information
+	// Whether to log API requests and responses at debug level
 	IsPrint bool
+	// Leveled structured logger, nil logs to stderr. @see NewStdLogger
+	Logger Logger
+	// Disable redaction of keys, passphrases and signatures in logs. For local debugging only.
+	LogSecrets bool
+	// Simulated (demo) trading, every request is sent with the x-simulated-trading header.
+	// Use a demo api key, and NewSwapWS/NewFuturesWS with WithWSSimulated.
+	Simulated bool
 	// Internationalization @see file: constants.go
 	I18n string
 	// 设置代理 http://127.0.0.1:1080
 	ProxyURL string
 	// Custom http client
 	HTTPClient *http.Client
+	// Retry policy for GET requests and client_oid-protected order placement, nil disables retrying.
+	// @see DefaultRetryPolicy
+	Retry *RetryPolicy
+	// Client side rate limit per endpoint, nil disables throttling.
+	// @see DefaultRateLimits
+	RateLimit *RateLimitConfig
+	// Sync the signing clock with the server time in NewClient (before it returns) and then every interval,
+	// and resync on invalid timestamp errors. 0 signs with the local clock.
+	ClockSyncInterval time.Duration
+	// Hooks around every rest request. @see Client.Use
+	Middlewares []Middleware
 }
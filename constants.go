@@ -15,6 +15,15 @@ const (
 	OK_ACCESS_SIGN       = "OK-ACCESS-SIGN"
 	OK_ACCESS_TIMESTAMP  = "OK-ACCESS-TIMESTAMP"
 	OK_ACCESS_PASSPHRASE = "OK-ACCESS-PASSPHRASE"
+	// "1" marks a request to the simulated (demo) trading environment
+	X_SIMULATED_TRADING = "x-simulated-trading"
+
+	/*
+	  endpoints
+	*/
+	REST_ENDPOINT    = "https://www.okex.com/"
+	WS_ENDPOINT      = "wss://real.okex.com:8443/ws/v3"
+	DEMO_WS_ENDPOINT = "wss://wspap.okex.com:8443/ws/v3?brokerId=9999"
 
 	/**
 	  paging params
@@ -23,6 +32,9 @@ const (
 	OK_TO    = "OK-TO"
 	OK_LIMIT = "OK-LIMIT"
 
+	OK_BEFORE = "OK-BEFORE"
+	OK_AFTER  = "OK-AFTER"
+
 	CONTENT_TYPE = "Content-Type"
 	ACCEPT       = "Accept"
 	COOKIE       = "Cookie"
@@ -11,3 +11,31 @@ type CursorPage struct {
 	// Number of results per request. Maximum 100. (default 100)
 	Limit int
 }
+
+// Add the set after/before/limit of the page to params
+func (p *CursorPage) addTo(params map[string]string) map[string]string {
+	if params == nil {
+		params = NewParams()
+	}
+	if p == nil {
+		return params
+	}
+	if p.Before > 0 {
+		params["before"] = Int2String(p.Before)
+	}
+	if p.After > 0 {
+		params["after"] = Int2String(p.After)
+	}
+	if p.Limit > 0 {
+		params["limit"] = Int2String(p.Limit)
+	}
+	return params
+}
+
+// requestPath with the params, if any
+func pageUri(requestPath string, params map[string]string) string {
+	if len(params) == 0 {
+		return requestPath
+	}
+	return BuildParams(requestPath, params)
+}
@@ -0,0 +1,329 @@
+package okex
+
+/*
+ Exact decimal numbers.
+
+ The api sends prices, sizes and balances as decimal strings, eg: "3.721".
+ Decimal keeps that text as it is, so nothing is lost by decoding and
+ re-encoding it, and does its arithmetic exactly on big integers instead of
+ float64. An empty Decimal ("", the api sends it for missing values) is zero.
+
+	cost := fill.Price.Mul(fill.Size)
+	if !price.IsMultipleOf(instrument.TickSize) { ... }
+*/
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Decimal string
+
+var bigTen = big.NewInt(10)
+
+// Parse and validate a decimal string, eg: "-12.50", "1e-8"
+func ParseDecimal(s string) (Decimal, error) {
+	if s == "" {
+		return "", nil
+	}
+	if !Decimal(s).Valid() {
+		return "", fmt.Errorf("okex: illegal decimal %q", s)
+	}
+	return Decimal(s), nil
+}
+
+func NewDecimalFromInt(i int64) Decimal {
+	return Decimal(strconv.FormatInt(i, 10))
+}
+
+// The shortest decimal which converts back to f
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// parse into unscaled * 10^-scale
+func (d Decimal) parse() (*big.Int, int, bool) {
+	s := strings.TrimSpace(string(d))
+	if s == "" {
+		return new(big.Int), 0, true
+	}
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, 0, false
+		}
+		exp = e
+		s = s[:i]
+	}
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(s) - i - 1
+		s = s[:i] + s[i+1:]
+	}
+	if s == "" || s == "-" || s == "+" {
+		return nil, 0, false
+	}
+	unscaled, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, 0, false
+	}
+	scale -= exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return unscaled, scale, true
+}
+
+// unscaled and scale of d, zero if d is not a number
+func (d Decimal) value() (*big.Int, int) {
+	unscaled, scale, ok := d.parse()
+	if !ok {
+		return new(big.Int), 0
+	}
+	return unscaled, scale
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func rescale(unscaled *big.Int, from, to int) *big.Int {
+	return new(big.Int).Mul(unscaled, pow10(to-from))
+}
+
+func formatDecimal(unscaled *big.Int, scale int) Decimal {
+	s := new(big.Int).Abs(unscaled).String()
+	if scale > 0 {
+		if len(s) <= scale {
+			s = strings.Repeat("0", scale-len(s)+1) + s
+		}
+		s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return Decimal(s)
+}
+
+// Valid reports whether d is a number or empty
+func (d Decimal) Valid() bool {
+	s := strings.TrimSpace(string(d))
+	if s == "" {
+		return true
+	}
+	if s[0] == '-' || s[0] == '+' {
+		s = s[1:]
+	}
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		if _, err := strconv.Atoi(s[i+1:]); err != nil {
+			return false
+		}
+		s = s[:i]
+	}
+	digits, dot := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			digits++
+		case s[i] == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// The text of d as sent by the api
+func (d Decimal) String() string {
+	return string(d)
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(string(d)), 64)
+	return f
+}
+
+func (d Decimal) Sign() int {
+	if !d.Valid() {
+		return 0
+	}
+	s := strings.TrimSpace(string(d))
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		s = s[:i]
+	}
+	if strings.Trim(s, "+-0.") == "" {
+		return 0
+	}
+	if s[0] == '-' {
+		return -1
+	}
+	return 1
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// -1 if d < e, 0 if d == e, +1 if d > e
+func (d Decimal) Cmp(e Decimal) int {
+	x, xs := d.value()
+	y, ys := e.value()
+	if xs < ys {
+		x = rescale(x, xs, ys)
+	} else if ys < xs {
+		y = rescale(y, ys, xs)
+	}
+	return x.Cmp(y)
+}
+
+// Equal compares the numbers, eg: "1.50" equals "1.5"
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+func (d Decimal) Add(e Decimal) Decimal {
+	x, xs := d.value()
+	y, ys := e.value()
+	if xs < ys {
+		x, xs = rescale(x, xs, ys), ys
+	} else if ys < xs {
+		y = rescale(y, ys, xs)
+	}
+	return formatDecimal(x.Add(x, y), xs)
+}
+
+func (d Decimal) Sub(e Decimal) Decimal {
+	return d.Add(e.Neg())
+}
+
+func (d Decimal) Mul(e Decimal) Decimal {
+	x, xs := d.value()
+	y, ys := e.value()
+	return formatDecimal(x.Mul(x, y), xs+ys)
+}
+
+/*
+ d / e rounded half away from zero to places decimals.
+ Panics if e is zero.
+*/
+func (d Decimal) Div(e Decimal, places int) Decimal {
+	x, xs := d.value()
+	y, ys := e.value()
+	if y.Sign() == 0 {
+		panic("okex: decimal division by zero")
+	}
+	// x*10^-xs / (y*10^-ys) = x*10^(ys-xs+places+1) / y * 10^-(places+1)
+	shift := ys - xs + places + 1
+	if shift >= 0 {
+		x = rescale(x, 0, shift)
+	} else {
+		y = rescale(y, 0, -shift)
+	}
+	q := new(big.Int).Quo(x, y)
+	return formatDecimal(roundHalfUp(q), places)
+}
+
+// drop the last digit of q, rounding half away from zero
+func roundHalfUp(q *big.Int) *big.Int {
+	r := new(big.Int)
+	q, r = new(big.Int).QuoRem(q, bigTen, r)
+	if r.CmpAbs(big.NewInt(5)) >= 0 {
+		if r.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func (d Decimal) Neg() Decimal {
+	x, xs := d.value()
+	return formatDecimal(x.Neg(x), xs)
+}
+
+func (d Decimal) Abs() Decimal {
+	x, xs := d.value()
+	return formatDecimal(x.Abs(x), xs)
+}
+
+// Round half away from zero to places decimals
+func (d Decimal) Round(places int) Decimal {
+	x, xs := d.value()
+	if xs <= places {
+		return formatDecimal(rescale(x, xs, places), places)
+	}
+	q := new(big.Int).Quo(x, pow10(xs-places-1))
+	return formatDecimal(roundHalfUp(q), places)
+}
+
+// Truncate toward zero to places decimals
+func (d Decimal) Truncate(places int) Decimal {
+	x, xs := d.value()
+	if xs <= places {
+		return formatDecimal(rescale(x, xs, places), places)
+	}
+	return formatDecimal(new(big.Int).Quo(x, pow10(xs-places)), places)
+}
+
+// IsMultipleOf reports whether d is a whole multiple of step, eg: a price of the tick size
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	x, xs := d.value()
+	y, ys := step.value()
+	if y.Sign() == 0 {
+		return false
+	}
+	if xs < ys {
+		x = rescale(x, xs, ys)
+	} else if ys < xs {
+		y = rescale(y, ys, xs)
+	}
+	return new(big.Int).Rem(x, y).Sign() == 0
+}
+
+// TruncateTo the nearest multiple of step toward zero, eg: a size to the lot size
+func (d Decimal) TruncateTo(step Decimal) Decimal {
+	x, xs := d.value()
+	step0, ys := step.value()
+	if step0.Sign() == 0 {
+		return d
+	}
+	y := step0
+	if ys > xs {
+		x = rescale(x, xs, ys)
+	} else if xs > ys {
+		y = rescale(y, ys, xs)
+	}
+	q := new(big.Int).Quo(x, y)
+	// in the scale of step
+	return formatDecimal(q.Mul(q, step0), ys)
+}
+
+/*
+ Accepts a json string "3.721", a json number 3.721 or null (empty).
+*/
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = ""
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return err
+		}
+		s = unquoted
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
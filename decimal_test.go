@@ -0,0 +1,73 @@
+package okex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	assert.Equal(t, Decimal("0.3"), Decimal("0.1").Add("0.2"))
+	assert.Equal(t, Decimal("-0.10"), Decimal("1.15").Sub("1.25"))
+	assert.Equal(t, Decimal("0.0200"), Decimal("0.20").Mul("0.10"))
+	assert.Equal(t, Decimal("0.33"), Decimal("1").Div("3", 2))
+	assert.Equal(t, Decimal("-0.67"), Decimal("-2").Div("3", 2))
+	assert.Equal(t, Decimal("1.5"), Decimal("").Add("1.5"))
+	assert.Equal(t, Decimal("0.00000001"), Decimal("1e-8").Add("0.00000000"))
+
+	assert.Equal(t, Decimal("2.35"), Decimal("2.345").Round(2))
+	assert.Equal(t, Decimal("-2.35"), Decimal("-2.345").Round(2))
+	assert.Equal(t, Decimal("2.34"), Decimal("2.345").Truncate(2))
+	assert.Equal(t, Decimal("2.500"), Decimal("2.5").Round(3))
+
+	assert.True(t, Decimal("1.50").Equal("1.5"))
+	assert.Equal(t, -1, Decimal("9.99").Cmp("10"))
+	assert.Equal(t, 0, Decimal("").Sign())
+	assert.True(t, Decimal("0.000").IsZero())
+	assert.Equal(t, -1, Decimal("-0.01").Sign())
+
+	assert.True(t, Decimal("6773.45").IsMultipleOf("0.05"))
+	assert.False(t, Decimal("6773.41").IsMultipleOf("0.05"))
+	assert.Equal(t, Decimal("0.123"), Decimal("0.12345").TruncateTo("0.001"))
+	assert.Equal(t, Decimal("6770"), Decimal("6773").TruncateTo("10"))
+}
+
+func TestDecimal_Parse(t *testing.T) {
+	for _, s := range []string{"1", "-1.5", "+0.25", ".5", "1e-8", "1.5E3"} {
+		_, err := ParseDecimal(s)
+		assert.Nil(t, err, s)
+	}
+	for _, s := range []string{"abc", "1.2.3", "-", "1e", "NaN"} {
+		_, err := ParseDecimal(s)
+		assert.NotNil(t, err, s)
+	}
+	assert.Equal(t, Decimal("42"), NewDecimalFromInt(42))
+	assert.Equal(t, Decimal("0.1"), NewDecimalFromFloat(0.1))
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var holding SwapPositionHolding
+	err := JsonString2Struct(`{"avg_cost":"6773.41","position":2,"leverage":null,"instrument_id":"BTC-USD-SWAP"}`, &holding)
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("6773.41"), holding.AvgCost)
+	assert.Equal(t, "6773.41", holding.AvgCost.String())
+	assert.Equal(t, Decimal("2"), holding.Position)
+	assert.Equal(t, Decimal(""), holding.Leverage)
+
+	assert.NotNil(t, JsonString2Struct(`{"avg_cost":"1,5"}`, &holding))
+
+	s, err := Struct2JsonString(BasePlaceOrderInfo{Price: "100.10", Size: "1"})
+	assert.Nil(t, err)
+	assert.Contains(t, s, `"price":"100.10"`)
+	assert.Contains(t, s, `"size":"1"`)
+}
+
+func TestDepthOrderBook_IllegalLevel(t *testing.T) {
+	dob := NewDepthOrderBook("BTC-USD-SWAP")
+	err := dob.Update(ActionDepthL2Partial, &WSDepthL2Tbt{Asks: [][]string{{"6773.41", "344", "0", "8"}}})
+	assert.Nil(t, err)
+	err = dob.Update(ActionDepthL2Update, &WSDepthL2Tbt{Asks: [][]string{{"x", "1", "0", "1"}}})
+	assert.NotNil(t, err)
+	ob := dob.GetOrderBook(1)
+	assert.Equal(t, Decimal("6773.41"), ob.Asks[0].Price)
+}
@@ -1,7 +1,7 @@
 package okex
 
 import (
-	"log"
+	"context"
 	"net/http"
 	"strings"
 )
@@ -20,8 +20,12 @@ import (
  The exchange rate of legal tender pairs
 */
 func (client *Client) GetFuturesExchangeRate() (ExchangeRate, error) {
+	return client.GetFuturesExchangeRateCtx(context.Background())
+}
+
+func (client *Client) GetFuturesExchangeRateCtx(ctx context.Context) (ExchangeRate, error) {
 	var exchangeRate ExchangeRate
-	_, _, err := client.Request(GET, FUTURES_RATE, nil, &exchangeRate)
+	_, _, err := client.RequestCtx(ctx, GET, FUTURES_RATE, nil, &exchangeRate)
 	return exchangeRate, err
 }
 
@@ -29,8 +33,12 @@ func (client *Client) GetFuturesExchangeRate() (ExchangeRate, error) {
   Get all of futures contract list
 */
 func (client *Client) GetFuturesInstruments() ([]FuturesInstrumentsResult, error) {
+	return client.GetFuturesInstrumentsCtx(context.Background())
+}
+
+func (client *Client) GetFuturesInstrumentsCtx(ctx context.Context) ([]FuturesInstrumentsResult, error) {
 	var Instruments []FuturesInstrumentsResult
-	_, _, err := client.Request(GET, FUTURES_INSTRUMENTS, nil, &Instruments)
+	_, _, err := client.RequestCtx(ctx, GET, FUTURES_INSTRUMENTS, nil, &Instruments)
 	return Instruments, err
 }
 
@@ -38,8 +46,12 @@ func (client *Client) GetFuturesInstruments() ([]FuturesInstrumentsResult, error
  Get the futures contract currencies
 */
 func (client *Client) GetFuturesInstrumentCurrencies() ([]FuturesInstrumentCurrenciesResult, error) {
+	return client.GetFuturesInstrumentCurrenciesCtx(context.Background())
+}
+
+func (client *Client) GetFuturesInstrumentCurrenciesCtx(ctx context.Context) ([]FuturesInstrumentCurrenciesResult, error) {
 	var currencies []FuturesInstrumentCurrenciesResult
-	_, _, err := client.Request(GET, FUTURES_CURRENCIES, nil, &currencies)
+	_, _, err := client.RequestCtx(ctx, GET, FUTURES_CURRENCIES, nil, &currencies)
 	return currencies, err
 }
 
@@ -56,6 +68,10 @@ func (client *Client) GetFuturesInstrumentCurrencies() ([]FuturesInstrumentCurre
 
 */
 func (client *Client) GetFuturesInstrumentBook(InstrumentId string, optionalParams map[string]string) (FuturesInstrumentBookResult, error) {
+	return client.GetFuturesInstrumentBookCtx(context.Background(), InstrumentId, optionalParams)
+}
+
+func (client *Client) GetFuturesInstrumentBookCtx(ctx context.Context, InstrumentId string, optionalParams map[string]string) (FuturesInstrumentBookResult, error) {
 	var book FuturesInstrumentBookResult
 	params := NewParams()
 	if optionalParams != nil {
@@ -67,7 +83,7 @@ func (client *Client) GetFuturesInstrumentBook(InstrumentId string, optionalPara
 		}
 	}
 	requestPath := BuildParams(GetInstrumentIdUri(FUTURES_INSTRUMENT_BOOK, InstrumentId), params)
-	_, _, err := client.Request(GET, requestPath, nil, &book)
+	_, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &book)
 	return book, err
 }
 
@@ -75,8 +91,12 @@ func (client *Client) GetFuturesInstrumentBook(InstrumentId string, optionalPara
  Get the futures contract Instrument all ticker
 */
 func (client *Client) GetFuturesInstrumentAllTicker() ([]FuturesInstrumentTickerResult, error) {
+	return client.GetFuturesInstrumentAllTickerCtx(context.Background())
+}
+
+func (client *Client) GetFuturesInstrumentAllTickerCtx(ctx context.Context) ([]FuturesInstrumentTickerResult, error) {
 	var tickers []FuturesInstrumentTickerResult
-	_, _, err := client.Request(GET, FUTURES_TICKERS, nil, &tickers)
+	_, _, err := client.RequestCtx(ctx, GET, FUTURES_TICKERS, nil, &tickers)
 	return tickers, err
 }
 
@@ -84,8 +104,12 @@ func (client *Client) GetFuturesInstrumentAllTicker() ([]FuturesInstrumentTicker
  Get the futures contract Instrument ticker
 */
 func (client *Client) GetFuturesInstrumentTicker(InstrumentId string) (FuturesInstrumentTickerResult, error) {
+	return client.GetFuturesInstrumentTickerCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentTickerCtx(ctx context.Context, InstrumentId string) (FuturesInstrumentTickerResult, error) {
 	var ticker FuturesInstrumentTickerResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_TICKER, InstrumentId), nil, &ticker)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_TICKER, InstrumentId), nil, &ticker)
 	return ticker, err
 }
 
@@ -93,32 +117,26 @@ func (client *Client) GetFuturesInstrumentTicker(InstrumentId string) (FuturesIn
  Get the futures contract Instrument trades
 */
 func (client *Client) GetFuturesInstrumentTrades(InstrumentId string) ([]FuturesInstrumentTradesResult, error) {
+	return client.GetFuturesInstrumentTradesCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentTradesCtx(ctx context.Context, InstrumentId string) ([]FuturesInstrumentTradesResult, error) {
 	var trades []FuturesInstrumentTradesResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_TRADES, InstrumentId), nil, &trades)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_TRADES, InstrumentId), nil, &trades)
 	return trades, err
 }
 
 /*
  Get the futures contract Instrument candles
- granularity: @see  file: futures_constants.go
 */
-func (client *Client) GetFuturesInstrumentCandles(InstrumentId string, optionalParams map[string]string) ([][]string, error) {
-	var candles [][]string
-	params := NewParams()
+func (client *Client) GetFuturesInstrumentCandles(InstrumentId string, params *CandlesParams) ([]Candle, error) {
+	return client.GetFuturesInstrumentCandlesCtx(context.Background(), InstrumentId, params)
+}
 
-	if optionalParams != nil && len(optionalParams) > 0 {
-		if v, ok := optionalParams["start"]; ok {
-			params["start"] = v
-		}
-		if v, ok := optionalParams["end"]; ok {
-			params["end"] = v
-		}
-		if v, ok := optionalParams["granularity"]; ok {
-			params["granularity"] = v
-		}
-	}
-	requestPath := BuildParams(GetInstrumentIdUri(FUTURES_INSTRUMENT_CANDLES, InstrumentId), params)
-	_, _, err := client.Request(GET, requestPath, nil, &candles)
+func (client *Client) GetFuturesInstrumentCandlesCtx(ctx context.Context, InstrumentId string, params *CandlesParams) ([]Candle, error) {
+	var candles []Candle
+	requestPath := pageUri(GetInstrumentIdUri(FUTURES_INSTRUMENT_CANDLES, InstrumentId), params.params())
+	_, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &candles)
 	return candles, err
 }
 
@@ -126,8 +144,12 @@ func (client *Client) GetFuturesInstrumentCandles(InstrumentId string, optionalP
  Get the futures contract Instrument index
 */
 func (client *Client) GetFuturesInstrumentIndex(InstrumentId string) (FuturesInstrumentIndexResult, error) {
+	return client.GetFuturesInstrumentIndexCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentIndexCtx(ctx context.Context, InstrumentId string) (FuturesInstrumentIndexResult, error) {
 	var index FuturesInstrumentIndexResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_INDEX, InstrumentId), nil, &index)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_INDEX, InstrumentId), nil, &index)
 	return index, err
 }
 
@@ -135,8 +157,12 @@ func (client *Client) GetFuturesInstrumentIndex(InstrumentId string) (FuturesIns
  Get the futures contract Instrument estimated price
 */
 func (client *Client) GetFuturesInstrumentEstimatedPrice(InstrumentId string) (FuturesInstrumentEstimatedPriceResult, error) {
+	return client.GetFuturesInstrumentEstimatedPriceCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentEstimatedPriceCtx(ctx context.Context, InstrumentId string) (FuturesInstrumentEstimatedPriceResult, error) {
 	var estimatedPrice FuturesInstrumentEstimatedPriceResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_ESTIMATED_PRICE, InstrumentId), nil, &estimatedPrice)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_ESTIMATED_PRICE, InstrumentId), nil, &estimatedPrice)
 	return estimatedPrice, err
 }
 
@@ -144,8 +170,12 @@ func (client *Client) GetFuturesInstrumentEstimatedPrice(InstrumentId string) (F
  Get the futures contract Instrument holds
 */
 func (client *Client) GetFuturesInstrumentOpenInterest(InstrumentId string) (FuturesInstrumentOpenInterestResult, error) {
+	return client.GetFuturesInstrumentOpenInterestCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentOpenInterestCtx(ctx context.Context, InstrumentId string) (FuturesInstrumentOpenInterestResult, error) {
 	var openInterest FuturesInstrumentOpenInterestResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_OPEN_INTEREST, InstrumentId), nil, &openInterest)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_OPEN_INTEREST, InstrumentId), nil, &openInterest)
 	return openInterest, err
 }
 
@@ -153,8 +183,12 @@ func (client *Client) GetFuturesInstrumentOpenInterest(InstrumentId string) (Fut
  Get the futures contract Instrument limit price
 */
 func (client *Client) GetFuturesInstrumentPriceLimit(InstrumentId string) (FuturesInstrumentPriceLimitResult, error) {
+	return client.GetFuturesInstrumentPriceLimitCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentPriceLimitCtx(ctx context.Context, InstrumentId string) (FuturesInstrumentPriceLimitResult, error) {
 	var priceLimit FuturesInstrumentPriceLimitResult
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_PRICE_LIMIT, InstrumentId), nil, &priceLimit)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_PRICE_LIMIT, InstrumentId), nil, &priceLimit)
 	return priceLimit, err
 }
 
@@ -162,6 +196,10 @@ func (client *Client) GetFuturesInstrumentPriceLimit(InstrumentId string) (Futur
  Get the futures contract liquidation
 */
 func (client *Client) GetFuturesInstrumentLiquidation(InstrumentId string, status, from, to, limit int) (FuturesInstrumentLiquidationListResult, error) {
+	return client.GetFuturesInstrumentLiquidationCtx(context.Background(), InstrumentId, status, from, to, limit)
+}
+
+func (client *Client) GetFuturesInstrumentLiquidationCtx(ctx context.Context, InstrumentId string, status, from, to, limit int) (FuturesInstrumentLiquidationListResult, error) {
 	var liquidation []FuturesInstrumentLiquidationResult
 	params := NewParams()
 	params["status"] = Int2String(status)
@@ -169,7 +207,7 @@ func (client *Client) GetFuturesInstrumentLiquidation(InstrumentId string, statu
 	params["to"] = Int2String(to)
 	params["limit"] = Int2String(limit)
 	requestPath := BuildParams(GetInstrumentIdUri(FUTURES_INSTRUMENT_LIQUIDATION, InstrumentId), params)
-	_, response, err := client.Request(GET, requestPath, nil, &liquidation)
+	_, response, err := client.RequestCtx(ctx, GET, requestPath, nil, &liquidation)
 	var list FuturesInstrumentLiquidationListResult
 	page := parsePage(response)
 	list.Page = page
@@ -186,7 +224,11 @@ func (client *Client) GetFuturesInstrumentLiquidation(InstrumentId string, statu
  return struct: FuturesPositions
 */
 func (client *Client) GetFuturesPositions() (FuturesPosition, error) {
-	_, response, err := client.Request(GET, FUTURES_POSITION, nil, nil)
+	return client.GetFuturesPositionsCtx(context.Background())
+}
+
+func (client *Client) GetFuturesPositionsCtx(ctx context.Context) (FuturesPosition, error) {
+	_, response, err := client.RequestCtx(ctx, GET, FUTURES_POSITION, nil, nil)
 	return parsePositions(response, err)
 }
 
@@ -195,7 +237,11 @@ func (client *Client) GetFuturesPositions() (FuturesPosition, error) {
  return struct: FuturesPositions
 */
 func (client *Client) GetFuturesInstrumentPosition(InstrumentId string) (FuturesPosition, error) {
-	_, response, err := client.Request(GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_POSITION, InstrumentId), nil, nil)
+	return client.GetFuturesInstrumentPositionCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesInstrumentPositionCtx(ctx context.Context, InstrumentId string) (FuturesPosition, error) {
+	_, response, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_INSTRUMENT_POSITION, InstrumentId), nil, nil)
 	return parsePositions(response, err)
 }
 
@@ -204,8 +250,12 @@ func (client *Client) GetFuturesInstrumentPosition(InstrumentId string) (Futures
  return struct: FuturesAccounts
 */
 func (client *Client) GetFuturesAccounts() (GetFuturesAccountsResult, error) {
+	return client.GetFuturesAccountsCtx(context.Background())
+}
+
+func (client *Client) GetFuturesAccountsCtx(ctx context.Context) (GetFuturesAccountsResult, error) {
 	var r GetFuturesAccountsResult
-	_, _, err := client.Request(GET, FUTURES_ACCOUNTS, nil, &r)
+	_, _, err := client.RequestCtx(ctx, GET, FUTURES_ACCOUNTS, nil, &r)
 	//return parseAccounts(response, err)
 	return r, err
 }
@@ -215,7 +265,11 @@ func (client *Client) GetFuturesAccounts() (GetFuturesAccountsResult, error) {
  return struct: FuturesCurrencyAccounts
 */
 func (client *Client) GetFuturesAccountsByCurrency(currency string) (result FuturesCurrencyAccount, err error) {
-	_, _, err = client.Request(GET, GetUnderlyingUri(FUTURES_ACCOUNT_CURRENCY_INFO, currency), nil, &result)
+	return client.GetFuturesAccountsByCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetFuturesAccountsByCurrencyCtx(ctx context.Context, currency string) (result FuturesCurrencyAccount, err error) {
+	_, _, err = client.RequestCtx(ctx, GET, GetUnderlyingUri(FUTURES_ACCOUNT_CURRENCY_INFO, currency), nil, &result)
 	//return parseCurrencyAccounts(response, err)
 	return
 }
@@ -224,13 +278,17 @@ func (client *Client) GetFuturesAccountsByCurrency(currency string) (result Futu
  Get the futures contract currency ledger
 */
 func (client *Client) GetFuturesAccountsLedgerByCurrency(currency string, from, to, limit int) ([]FuturesCurrencyLedger, error) {
+	return client.GetFuturesAccountsLedgerByCurrencyCtx(context.Background(), currency, from, to, limit)
+}
+
+func (client *Client) GetFuturesAccountsLedgerByCurrencyCtx(ctx context.Context, currency string, from, to, limit int) ([]FuturesCurrencyLedger, error) {
 	var ledger []FuturesCurrencyLedger
 	params := NewParams()
 	params["from"] = Int2String(from)
 	params["to"] = Int2String(to)
 	params["limit"] = Int2String(limit)
 	requestPath := BuildParams(GetCurrencyUri(FUTURES_ACCOUNT_CURRENCY_LEDGER, currency), params)
-	_, _, err := client.Request(GET, requestPath, nil, &ledger)
+	_, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &ledger)
 	return ledger, err
 }
 
@@ -238,8 +296,12 @@ func (client *Client) GetFuturesAccountsLedgerByCurrency(currency string, from,
  Get the futures contract Instrument holds
 */
 func (client *Client) GetFuturesAccountsHoldsByInstrumentId(InstrumentId string) (FuturesAccountsHolds, error) {
+	return client.GetFuturesAccountsHoldsByInstrumentIdCtx(context.Background(), InstrumentId)
+}
+
+func (client *Client) GetFuturesAccountsHoldsByInstrumentIdCtx(ctx context.Context, InstrumentId string) (FuturesAccountsHolds, error) {
 	var holds FuturesAccountsHolds
-	_, _, err := client.Request(GET, GetInstrumentIdUri(FUTURES_ACCOUNT_INSTRUMENT_HOLDS, InstrumentId), nil, &holds)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(FUTURES_ACCOUNT_INSTRUMENT_HOLDS, InstrumentId), nil, &holds)
 	return holds, err
 }
 
@@ -247,9 +309,31 @@ func (client *Client) GetFuturesAccountsHoldsByInstrumentId(InstrumentId string)
  Create a new order
 */
 func (client *Client) FuturesOrder(newOrderParams FuturesNewOrderParams) ([]byte, FuturesNewOrderResult, error) {
+	return client.FuturesOrderCtx(context.Background(), newOrderParams)
+}
+
+func (client *Client) FuturesOrderCtx(ctx context.Context, newOrderParams FuturesNewOrderParams) ([]byte, FuturesNewOrderResult, error) {
 	var newOrderResult FuturesNewOrderResult
+	if err := client.validateOrder(ctx, newOrderParams.InstrumentId, newOrderParams.Price, newOrderParams.Size,
+		newOrderParams.MatchPrice); err != nil {
+		return nil, newOrderResult, err
+	}
 	var respBody []byte
-	respBody, _, err := client.Request(POST, FUTURES_ORDER, newOrderParams, &newOrderResult)
+	clientOid := newOrderParams.ClientOid
+	err := client.placeOrderWithRetry(ctx, clientOid, func() (err error) {
+		newOrderResult = FuturesNewOrderResult{}
+		respBody, _, err = client.RequestCtx(ctx, POST, FUTURES_ORDER, newOrderParams, &newOrderResult)
+		return err
+	}, func() (bool, error) {
+		order, err := client.GetFuturesOrderCtx(ctx, newOrderParams.InstrumentId, clientOid)
+		if err != nil || order.OrderId == "" {
+			return false, err
+		}
+		newOrderResult = FuturesNewOrderResult{ClientOid: clientOid, OrderId: order.OrderId}
+		newOrderResult.Result.Result = true
+		respBody = nil
+		return true, nil
+	})
 	return respBody, newOrderResult, err
 }
 
@@ -257,9 +341,13 @@ func (client *Client) FuturesOrder(newOrderParams FuturesNewOrderParams) ([]byte
  Batch create new order.(Max of 5 orders are allowed per request)
 */
 func (client *Client) FuturesOrders(batchNewOrder FuturesBatchNewOrderParams) ([]byte, FuturesBatchNewOrderResult, error) {
+	return client.FuturesOrdersCtx(context.Background(), batchNewOrder)
+}
+
+func (client *Client) FuturesOrdersCtx(ctx context.Context, batchNewOrder FuturesBatchNewOrderParams) ([]byte, FuturesBatchNewOrderResult, error) {
 	var batchNewOrderResult FuturesBatchNewOrderResult
 	var respBody []byte
-	respBody, _, err := client.Request(POST, FUTURES_ORDERS, batchNewOrder, &batchNewOrderResult)
+	respBody, _, err := client.RequestCtx(ctx, POST, FUTURES_ORDERS, batchNewOrder, &batchNewOrderResult)
 	return respBody, batchNewOrderResult, err
 }
 
@@ -267,6 +355,10 @@ func (client *Client) FuturesOrders(batchNewOrder FuturesBatchNewOrderParams) ([
  Get all of futures contract order list
 */
 func (client *Client) GetFuturesOrders(InstrumentId string, status int, after string, before string, limit int) (FuturesGetOrdersResult, error) {
+	return client.GetFuturesOrdersCtx(context.Background(), InstrumentId, status, after, before, limit)
+}
+
+func (client *Client) GetFuturesOrdersCtx(ctx context.Context, InstrumentId string, status int, after string, before string, limit int) (FuturesGetOrdersResult, error) {
 	var ordersResult FuturesGetOrdersResult
 	params := NewParams()
 	params["status"] = Int2String(status)
@@ -280,7 +372,7 @@ func (client *Client) GetFuturesOrders(InstrumentId string, status int, after st
 		params["limit"] = Int2String(limit)
 	}
 	requestPath := BuildParams(GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_LIST, InstrumentId), params)
-	_, _, err := client.Request(GET, requestPath, nil, &ordersResult)
+	_, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &ordersResult)
 	return ordersResult, err
 }
 
@@ -288,8 +380,12 @@ func (client *Client) GetFuturesOrders(InstrumentId string, status int, after st
  Get all of futures contract a order by order id
 */
 func (client *Client) GetFuturesOrder(InstrumentId string, orderId string) (FuturesGetOrderResult, error) {
+	return client.GetFuturesOrderCtx(context.Background(), InstrumentId, orderId)
+}
+
+func (client *Client) GetFuturesOrderCtx(ctx context.Context, InstrumentId string, orderId string) (FuturesGetOrderResult, error) {
 	var getOrderResult FuturesGetOrderResult
-	_, _, err := client.Request(GET, GetInstrumentIdOrdersUri(FUTURES_INSTRUMENT_ORDER_INFO, InstrumentId, orderId), nil, &getOrderResult)
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdOrdersUri(FUTURES_INSTRUMENT_ORDER_INFO, InstrumentId, orderId), nil, &getOrderResult)
 	return getOrderResult, err
 }
 
@@ -297,11 +393,15 @@ func (client *Client) GetFuturesOrder(InstrumentId string, orderId string) (Futu
  Batch Cancel the orders
 */
 func (client *Client) BatchCancelFuturesInstrumentOrders(InstrumentId, orderIds string) ([]byte, FuturesBatchCancelInstrumentOrdersResult, error) {
+	return client.BatchCancelFuturesInstrumentOrdersCtx(context.Background(), InstrumentId, orderIds)
+}
+
+func (client *Client) BatchCancelFuturesInstrumentOrdersCtx(ctx context.Context, InstrumentId, orderIds string) ([]byte, FuturesBatchCancelInstrumentOrdersResult, error) {
 	var cancelInstrumentOrdersResult FuturesBatchCancelInstrumentOrdersResult
 	params := NewParams()
 	params["order_ids"] = orderIds
 	var respBody []byte
-	respBody, _, err := client.Request(POST, GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_BATCH_CANCEL, InstrumentId), params, &cancelInstrumentOrdersResult)
+	respBody, _, err := client.RequestCtx(ctx, POST, GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_BATCH_CANCEL, InstrumentId), params, &cancelInstrumentOrdersResult)
 	return respBody, cancelInstrumentOrdersResult, err
 }
 
@@ -309,9 +409,13 @@ func (client *Client) BatchCancelFuturesInstrumentOrders(InstrumentId, orderIds
  Cancel the order
 */
 func (client *Client) CancelFuturesInstrumentOrder(InstrumentId string, orderId string) ([]byte, FuturesCancelInstrumentOrderResult, error) {
+	return client.CancelFuturesInstrumentOrderCtx(context.Background(), InstrumentId, orderId)
+}
+
+func (client *Client) CancelFuturesInstrumentOrderCtx(ctx context.Context, InstrumentId string, orderId string) ([]byte, FuturesCancelInstrumentOrderResult, error) {
 	var cancelInstrumentOrderResult FuturesCancelInstrumentOrderResult
 	var respBody []byte
-	respBody, _, err := client.Request(POST, GetInstrumentIdOrdersUri(FUTURES_INSTRUMENT_ORDER_CANCEL, InstrumentId, orderId), nil,
+	respBody, _, err := client.RequestCtx(ctx, POST, GetInstrumentIdOrdersUri(FUTURES_INSTRUMENT_ORDER_CANCEL, InstrumentId, orderId), nil,
 		&cancelInstrumentOrderResult)
 	return respBody, cancelInstrumentOrderResult, err
 }
@@ -320,6 +424,10 @@ func (client *Client) CancelFuturesInstrumentOrder(InstrumentId string, orderId
  Get all of futures contract transactions.
 */
 func (client *Client) GetFuturesFills(InstrumentId string, orderId int64, optionalParams map[string]int) ([]FuturesFillResult, error) {
+	return client.GetFuturesFillsCtx(context.Background(), InstrumentId, orderId, optionalParams)
+}
+
+func (client *Client) GetFuturesFillsCtx(ctx context.Context, InstrumentId string, orderId int64, optionalParams map[string]int) ([]FuturesFillResult, error) {
 	var fillsResult []FuturesFillResult
 	params := NewParams()
 	params["order_id"] = Int64ToString(orderId)
@@ -332,7 +440,7 @@ func (client *Client) GetFuturesFills(InstrumentId string, orderId int64, option
 	}
 
 	requestPath := BuildParams(FUTURES_FILLS, params)
-	_, _, err := client.Request(GET, requestPath, nil, &fillsResult)
+	_, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &fillsResult)
 	return fillsResult, err
 }
 
@@ -344,9 +452,13 @@ func (client *Client) GetFuturesFills(InstrumentId string, orderId int64, option
 GET/api/futures/v3/instruments/BTC-USD-180309/mark_price
 */
 func (client *Client) GetInstrumentMarkPrice(instrumentId string) (*FuturesMarkdown, error) {
+	return client.GetInstrumentMarkPriceCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetInstrumentMarkPriceCtx(ctx context.Context, instrumentId string) (*FuturesMarkdown, error) {
 	uri := GetInstrumentIdUri(FUTURES_INSTRUMENT_MARK_PRICE, instrumentId)
 	r := FuturesMarkdown{}
-	_, _, err := client.Request(GET, uri, nil, &r)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
 	return &r, err
 }
 
@@ -396,7 +508,6 @@ func parseAccounts(response *http.Response, err error) (FuturesAccount, error) {
 	var result Result
 	result.Result = false
 	jsonString := GetResponseDataJsonString(response)
-	log.Printf(jsonString)
 	if strings.Contains(jsonString, "\"contracts\"") {
 		var fixedAccount FuturesFixedAccountInfo
 		err = JsonString2Struct(jsonString, &fixedAccount)
@@ -485,6 +596,10 @@ POST/api/futures/v3/accounts/btc/leverage{"instrument_id":"BTC-USD-180213","dire
 
 */
 func (client *Client) PostFuturesAccountsLeverage(currency string, leverage int, optionalParams map[string]string) (map[string]interface{}, error) {
+	return client.PostFuturesAccountsLeverageCtx(context.Background(), currency, leverage, optionalParams)
+}
+
+func (client *Client) PostFuturesAccountsLeverageCtx(ctx context.Context, currency string, leverage int, optionalParams map[string]string) (map[string]interface{}, error) {
 	uri := GetUnderlyingUri(FUTURES_ACCOUNT_CURRENCY_LEVERAGE, currency)
 	params := NewParams()
 	params["leverage"] = Int2String(leverage)
@@ -495,7 +610,7 @@ func (client *Client) PostFuturesAccountsLeverage(currency string, leverage int,
 	}
 
 	r := new(map[string]interface{})
-	_, _, err := client.Request(POST, uri, params, r)
+	_, _, err := client.RequestCtx(ctx, POST, uri, params, r)
 
 	return *r, err
 }
@@ -507,11 +622,15 @@ POST /api/futures/v3/accounts/margin_mode{"underlying":"btc-usd","margin_mode":"
 */
 
 func (client *Client) PostFuturesAccountsMarginNode(underlying string, marginMode string) (map[string]interface{}, error) {
+	return client.PostFuturesAccountsMarginNodeCtx(context.Background(), underlying, marginMode)
+}
+
+func (client *Client) PostFuturesAccountsMarginNodeCtx(ctx context.Context, underlying string, marginMode string) (map[string]interface{}, error) {
 	params := NewParams()
 	params["underlying"] = underlying
 	params["margin_mode"] = marginMode
 	r := new(map[string]interface{})
-	_, _, err := client.Request(POST, FUTURES_ACCOUNT_MARGIN_MODE, params, r)
+	_, _, err := client.RequestCtx(ctx, POST, FUTURES_ACCOUNT_MARGIN_MODE, params, r)
 	return *r, err
 }
 
@@ -526,8 +645,12 @@ GET /api/futures/v3/accounts/<currency>/leverage
 GET/api/futures/v3/accounts/btc/leverage
 */
 func (client *Client) GetFuturesAccountsLeverage(currency string) (map[string]interface{}, error) {
+	return client.GetFuturesAccountsLeverageCtx(context.Background(), currency)
+}
+
+func (client *Client) GetFuturesAccountsLeverageCtx(ctx context.Context, currency string) (map[string]interface{}, error) {
 	uri := GetUnderlyingUri(FUTURES_ACCOUNT_CURRENCY_LEVERAGE, currency)
 	r := new(map[string]interface{})
-	_, _, err := client.Request(GET, uri, nil, r)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, r)
 	return *r, err
 }
@@ -32,20 +32,22 @@ const (
 	*/
 	CROSS = 1
 	FIXED = 2
+)
 
-	/*
-	 candles bin size
-	*/
-	CANDLES_1MIN   = 60
-	CANDLES_3MIN   = 180
-	CANDLES_5MIN   = 300
-	CANDLES_15MIN  = 900
-	CANDLES_30MIN  = 1800
-	CANDLES_1HOUR  = 3600
-	CANDLES_2HOUR  = 7200
-	CANDLES_4HOUR  = 14400
-	CANDLES_6HOUR  = 21600
-	CANDLES_12HOUR = 43200
-	CANDLES_1DAY   = 86400
-	CANDLES_1WEEK  = 604800
+/*
+ candles bin size, @see Granularity
+*/
+const (
+	CANDLES_1MIN   Granularity = 60
+	CANDLES_3MIN   Granularity = 180
+	CANDLES_5MIN   Granularity = 300
+	CANDLES_15MIN  Granularity = 900
+	CANDLES_30MIN  Granularity = 1800
+	CANDLES_1HOUR  Granularity = 3600
+	CANDLES_2HOUR  Granularity = 7200
+	CANDLES_4HOUR  Granularity = 14400
+	CANDLES_6HOUR  Granularity = 21600
+	CANDLES_12HOUR Granularity = 43200
+	CANDLES_1DAY   Granularity = 86400
+	CANDLES_1WEEK  Granularity = 604800
 )
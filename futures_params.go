@@ -33,22 +33,25 @@ type FuturesBatchNewOrderParams struct {
 }
 
 type FuturesBatchNewOrderItem struct {
-	ClientOid  string `json:"client_oid"`
-	Type       string `json:"type"`
-	OrderType  string `json:"order_type"`
-	Price      string `json:"price"`
-	Size       string `json:"size"`
-	MatchPrice string `json:"match_price"`
+	ClientOid  string  `json:"client_oid"`
+	Type       string  `json:"type"`
+	OrderType  string  `json:"order_type"`
+	Price      Decimal `json:"price"`
+	Size       Decimal `json:"size"`
+	MatchPrice string  `json:"match_price"`
 }
 
+/*
+ Market close params of CloseFuturesPositions and CloseSwapPositions.
+ Direction: long, short
+*/
 type FuturesClosePositionParams struct {
 	ClosePositionData []ClosePositionData
 }
 
 type ClosePositionData struct {
 	InstrumentId string `json:"instrument_id"`
-	Type         string `json:"type"`
-	LeverRate    string `json:"lever_rate"`
+	Direction    string `json:"direction"`
 }
 
 /*
@@ -0,0 +1,236 @@
+package okex
+
+/*
+ Futures expiry calendar and rolling aliases.
+
+ Futures instrument ids carry their delivery date (BTC-USD-200925) and go
+ stale every week or quarter. FuturesResolver maps an alias such as
+ "BTC-USD quarter" to the current contract, and reports a FuturesRoll when an
+ alias moves to a new contract after a delivery:
+
+	resolver := okex.NewFuturesResolver(client)
+	id, _ := resolver.Resolve(ctx, "BTC-USD", okex.FuturesAliasQuarter)
+	resolver.OnRoll(func(roll okex.FuturesRoll) { ... })
+	go resolver.Run(ctx, time.Hour)
+
+ A FuturesWS with the resolver (FuturesWS.SetFuturesResolver) accepts the
+ alias form as symbol and moves such subscriptions along on a roll.
+*/
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	FuturesAliasThisWeek  = "this_week"
+	FuturesAliasNextWeek  = "next_week"
+	FuturesAliasQuarter   = "quarter"
+	FuturesAliasBiQuarter = "bi_quarter"
+)
+
+// wait after a delivery before reloading, the next contract is listed shortly after
+const futuresRollDelay = time.Minute
+
+// An alias moved from one contract to the next
+type FuturesRoll struct {
+	Underlying string // eg: BTC-USD
+	Alias      string // eg: quarter
+	From       string // the delivered instrument id, eg: BTC-USD-200925
+	To         string // the current instrument id, eg: BTC-USD-201225
+}
+
+/*
+ Split an alias symbol, eg: "BTC-USD this_week" or "BTC-USD-this_week",
+ into its underlying and alias. ok is false for anything else, eg: an instrument id.
+*/
+func ParseFuturesAlias(symbol string) (underlying, alias string, ok bool) {
+	for _, a := range []string{FuturesAliasThisWeek, FuturesAliasNextWeek, FuturesAliasQuarter, FuturesAliasBiQuarter} {
+		for _, sep := range []string{" ", "-"} {
+			if strings.HasSuffix(symbol, sep+a) {
+				underlying = strings.TrimSuffix(symbol, sep+a)
+				return strings.TrimSpace(underlying), a, underlying != ""
+			}
+		}
+	}
+	return "", "", false
+}
+
+func futuresAliasKey(underlying, alias string) string {
+	return strings.ToUpper(underlying) + " " + alias
+}
+
+type FuturesResolver struct {
+	client *Client
+
+	mu        sync.RWMutex
+	contracts map[string]Instrument // by underlying + alias
+	loaded    bool
+	listeners []func(roll FuturesRoll)
+}
+
+func NewFuturesResolver(client *Client) *FuturesResolver {
+	return &FuturesResolver{client: client, contracts: make(map[string]Instrument)}
+}
+
+// Call f with every roll found by Refresh, in the goroutine calling Refresh or Run
+func (r *FuturesResolver) OnRoll(f func(roll FuturesRoll)) {
+	r.mu.Lock()
+	r.listeners = append(r.listeners, f)
+	r.mu.Unlock()
+}
+
+/*
+ Reload the futures instruments and report every alias which moved to another
+ contract since the last load.
+*/
+func (r *FuturesResolver) Refresh(ctx context.Context) ([]FuturesRoll, error) {
+	futures, err := r.client.GetFuturesInstrumentsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contracts := make(map[string]Instrument)
+	for _, f := range futures {
+		if f.Alias == "" {
+			continue
+		}
+		instrument := futuresInstrument(f)
+		contracts[futuresAliasKey(instrument.Underlying, instrument.Alias)] = instrument
+	}
+
+	r.mu.Lock()
+	var rolls []FuturesRoll
+	if r.loaded {
+		for key, instrument := range contracts {
+			if old, ok := r.contracts[key]; ok && old.InstrumentId != instrument.InstrumentId {
+				rolls = append(rolls, FuturesRoll{Underlying: instrument.Underlying, Alias: instrument.Alias,
+					From: old.InstrumentId, To: instrument.InstrumentId})
+			}
+		}
+	}
+	r.contracts = contracts
+	r.loaded = true
+	listeners := r.listeners
+	r.mu.Unlock()
+
+	sort.Slice(rolls, func(i, j int) bool {
+		return futuresAliasKey(rolls[i].Underlying, rolls[i].Alias) < futuresAliasKey(rolls[j].Underlying, rolls[j].Alias)
+	})
+	for _, roll := range rolls {
+		for _, f := range listeners {
+			f(roll)
+		}
+	}
+	return rolls, nil
+}
+
+func (r *FuturesResolver) ensureLoaded(ctx context.Context) error {
+	r.mu.RLock()
+	loaded := r.loaded
+	r.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	_, err := r.Refresh(ctx)
+	return err
+}
+
+// The current contract of underlying (eg: BTC-USD) and alias (eg: FuturesAliasQuarter)
+func (r *FuturesResolver) Contract(ctx context.Context, underlying, alias string) (Instrument, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return Instrument{}, err
+	}
+	r.mu.RLock()
+	instrument, ok := r.contracts[futuresAliasKey(underlying, alias)]
+	r.mu.RUnlock()
+	if !ok {
+		return Instrument{}, fmt.Errorf("%w: %s %s", ErrUnknownInstrument, underlying, alias)
+	}
+	return instrument, nil
+}
+
+// The current instrument id of underlying and alias, eg: BTC-USD, quarter -> BTC-USD-201225
+func (r *FuturesResolver) Resolve(ctx context.Context, underlying, alias string) (string, error) {
+	instrument, err := r.Contract(ctx, underlying, alias)
+	return instrument.InstrumentId, err
+}
+
+/*
+ The instrument id of an alias symbol (@see ParseFuturesAlias), any other symbol
+ is returned as it is.
+*/
+func (r *FuturesResolver) ResolveSymbol(ctx context.Context, symbol string) (string, error) {
+	underlying, alias, ok := ParseFuturesAlias(symbol)
+	if !ok {
+		return symbol, nil
+	}
+	return r.Resolve(ctx, underlying, alias)
+}
+
+// The current contracts of underlying (all for ""), by delivery
+func (r *FuturesResolver) Calendar(ctx context.Context, underlying string) ([]Instrument, error) {
+	if err := r.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	var calendar []Instrument
+	for _, instrument := range r.contracts {
+		if underlying == "" || strings.EqualFold(instrument.Underlying, underlying) {
+			calendar = append(calendar, instrument)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(calendar, func(i, j int) bool {
+		if !calendar[i].Delivery.Equal(calendar[j].Delivery) {
+			return calendar[i].Delivery.Before(calendar[j].Delivery)
+		}
+		return calendar[i].InstrumentId < calendar[j].InstrumentId
+	})
+	return calendar, nil
+}
+
+// The earliest delivery of the current contracts, zero before the first load
+func (r *FuturesResolver) NextDelivery() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var next time.Time
+	for _, instrument := range r.contracts {
+		if next.IsZero() || instrument.Delivery.Before(next) {
+			next = instrument.Delivery
+		}
+	}
+	return next
+}
+
+/*
+ Refresh shortly after every delivery, and at least every interval (default an
+ hour), until ctx is done. Failed refreshes are logged and retried a minute later.
+*/
+func (r *FuturesResolver) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	for {
+		wait := interval
+		if _, err := r.Refresh(ctx); err != nil {
+			r.client.logger.Log(LogLevelWarn, "refresh futures contracts failed", F("error", err))
+			wait = futuresRollDelay
+		} else if next := r.NextDelivery(); !next.IsZero() {
+			// a delivered contract still listed is retried every futuresRollDelay
+			if untilRoll := time.Until(next.Add(futuresRollDelay)); untilRoll <= 0 {
+				wait = futuresRollDelay
+			} else if untilRoll < wait {
+				wait = untilRoll
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
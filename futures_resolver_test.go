@@ -0,0 +1,115 @@
+package okex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFuturesAlias(t *testing.T) {
+	underlying, alias, ok := ParseFuturesAlias("BTC-USD this_week")
+	assert.True(t, ok)
+	assert.Equal(t, "BTC-USD", underlying)
+	assert.Equal(t, FuturesAliasThisWeek, alias)
+
+	underlying, alias, ok = ParseFuturesAlias("BTC-USDT-bi_quarter")
+	assert.True(t, ok)
+	assert.Equal(t, "BTC-USDT", underlying)
+	assert.Equal(t, FuturesAliasBiQuarter, alias)
+
+	_, _, ok = ParseFuturesAlias("BTC-USD-200925")
+	assert.False(t, ok)
+	_, _, ok = ParseFuturesAlias(" quarter")
+	assert.False(t, ok)
+}
+
+func TestFuturesResolver(t *testing.T) {
+	quarter := `{"instrument_id":"BTC-USD-200925","underlying":"BTC-USD","delivery":"2020-09-25","alias":"quarter"}`
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, FUTURES_INSTRUMENTS, r.URL.Path)
+		w.Write([]byte(`[{"instrument_id":"BTC-USD-200911","underlying":"BTC-USD","delivery":"2020-09-11","alias":"this_week"},` +
+			quarter + `,{"instrument_id":"ETH-USD-200911","underlying":"ETH-USD","delivery":"2020-09-11","alias":"this_week"}]`))
+	})
+	defer server.Close()
+	ctx := context.Background()
+
+	resolver := NewFuturesResolver(c)
+	id, err := resolver.Resolve(ctx, "btc-usd", FuturesAliasQuarter)
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-200925", id)
+	id, err = resolver.ResolveSymbol(ctx, "BTC-USD this_week")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-200911", id)
+	id, err = resolver.ResolveSymbol(ctx, "BTC-USD-SWAP")
+	assert.Nil(t, err)
+	assert.Equal(t, "BTC-USD-SWAP", id)
+	_, err = resolver.Resolve(ctx, "BTC-USD", FuturesAliasNextWeek)
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+
+	calendar, err := resolver.Calendar(ctx, "BTC-USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(calendar))
+	assert.Equal(t, "BTC-USD-200911", calendar[0].InstrumentId)
+	assert.Equal(t, time.Date(2020, 9, 11, 8, 0, 0, 0, time.UTC), resolver.NextDelivery())
+
+	var notified []FuturesRoll
+	resolver.OnRoll(func(roll FuturesRoll) {
+		notified = append(notified, roll)
+	})
+	rolls, err := resolver.Refresh(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(rolls))
+
+	quarter = `{"instrument_id":"BTC-USD-201225","underlying":"BTC-USD","delivery":"2020-12-25","alias":"quarter"}`
+	rolls, err = resolver.Refresh(ctx)
+	assert.Nil(t, err)
+	expected := []FuturesRoll{{Underlying: "BTC-USD", Alias: FuturesAliasQuarter, From: "BTC-USD-200925", To: "BTC-USD-201225"}}
+	assert.Equal(t, expected, rolls)
+	assert.Equal(t, expected, notified)
+}
+
+func TestFuturesWS_Roll(t *testing.T) {
+	quarter := "BTC-USD-200925"
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"instrument_id":"` + quarter + `","underlying":"BTC-USD","delivery":"2020-09-25","alias":"quarter"}]`))
+	})
+	defer server.Close()
+
+	// not started, messages to the exchange fail and are only logged
+	ws := NewFuturesWS("", "", "", "", false)
+	assert.NotNil(t, ws.SubscribeTicker("t", "BTC-USD quarter"))
+
+	resolver := NewFuturesResolver(c)
+	ws.SetFuturesResolver(resolver)
+	var rolls []FuturesRoll
+	ws.SetRollCallback(func(roll FuturesRoll) {
+		rolls = append(rolls, roll)
+	})
+	ws.SubscribeTicker("t", "BTC-USD quarter")
+	ws.SubscribeDepthL2Tbt("d", "BTC-USD-200925")
+	assert.Equal(t, []string{"futures/ticker:BTC-USD-200925"}, subscriptionArgs(ws.subscriptions["t"]))
+
+	quarter = "BTC-USD-201225"
+	_, err := resolver.Refresh(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rolls))
+	assert.Equal(t, []string{"futures/ticker:BTC-USD-201225"}, subscriptionArgs(ws.subscriptions["t"]))
+	// instrument ids are not moved
+	assert.Equal(t, []string{"futures/depth_l2_tbt:BTC-USD-200925"}, subscriptionArgs(ws.subscriptions["d"]))
+
+	ws.Unsubscribe("t")
+	assert.Equal(t, 0, len(ws.aliasSubscriptions))
+}
+
+func subscriptionArgs(op interface{}) []string {
+	var args struct {
+		Args []string `json:"args"`
+	}
+	data, _ := json.Marshal(op)
+	json.Unmarshal(data, &args)
+	return args.Args
+}
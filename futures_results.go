@@ -14,7 +14,7 @@ type ServerTime struct {
 
 type ExchangeRate struct {
 	InstrumentId string  `json:"instrument_id"`
-	Rate         float64 `json:"rate,string"`
+	Rate         Decimal `json:"rate"`
 	Timestamp    string  `json:"timestamp"`
 }
 
@@ -56,32 +56,32 @@ type FuturesFixedPosition struct {
 
 type FuturesCrossPositionHolding struct {
 	FuturesPositionBase
-	LiquidationPrice float64 `json:"liquidation_price,string"`
-	Leverage         float64 `json:"leverage,string"`
+	LiquidationPrice Decimal `json:"liquidation_price"`
+	Leverage         Decimal `json:"leverage"`
 }
 
 type FuturesFixedPositionHolding struct {
 	FuturesPositionBase
-	LongMargin      float64 `json:"long_margin,string"`
-	LongLiquiPrice  float64 `json:"long_liqui_price,string"`
-	LongPnlRatio    float64 `json:"long_pnl_ratio,string"`
-	LongLeverage    float64 `json:"long_leverage,string"`
-	ShortMargin     float64 `json:"short_margin,string"`
-	ShortLiquiPrice float64 `json:"short_liqui_price,string"`
-	ShortPnlRatio   float64 `json:"short_pnl_ratio,string"`
-	ShortLeverage   float64 `json:"short_leverage,string"`
+	LongMargin      Decimal `json:"long_margin"`
+	LongLiquiPrice  Decimal `json:"long_liqui_price"`
+	LongPnlRatio    Decimal `json:"long_pnl_ratio"`
+	LongLeverage    Decimal `json:"long_leverage"`
+	ShortMargin     Decimal `json:"short_margin"`
+	ShortLiquiPrice Decimal `json:"short_liqui_price"`
+	ShortPnlRatio   Decimal `json:"short_pnl_ratio"`
+	ShortLeverage   Decimal `json:"short_leverage"`
 }
 
 type FuturesPositionBase struct {
-	LongQty              float64 `json:"long_qty,string"`
-	LongAvailQty         float64 `json:"long_avail_qty,string"`
-	LongAvgCost          float64 `json:"long_avg_cost,string"`
-	LongSettlementPrice  float64 `json:"long_settlement_price,string"`
-	RealizedPnl          float64 `json:"realized_pnl,string"`
-	ShortQty             float64 `json:"short_qty,string"`
-	ShortAvailQty        float64 `json:"short_avail_qty,string"`
-	ShortAvgCost         float64 `json:"short_avg_cost,string"`
-	ShortSettlementPrice float64 `json:"short_settlement_price,string"`
+	LongQty              Decimal `json:"long_qty"`
+	LongAvailQty         Decimal `json:"long_avail_qty"`
+	LongAvgCost          Decimal `json:"long_avg_cost"`
+	LongSettlementPrice  Decimal `json:"long_settlement_price"`
+	RealizedPnl          Decimal `json:"realized_pnl"`
+	ShortQty             Decimal `json:"short_qty"`
+	ShortAvailQty        Decimal `json:"short_avail_qty"`
+	ShortAvgCost         Decimal `json:"short_avg_cost"`
+	ShortSettlementPrice Decimal `json:"short_settlement_price"`
 	InstrumentId         string  `json:"instrument_id"`
 	CreatedAt            string  `json:"created_at"`
 	UpdatedAt            string  `json:"updated_at"`
@@ -96,19 +96,19 @@ type FuturesAccount struct {
 }
 
 type FuturesAccountsContract struct {
-	AvailableQty      string  `json:"available_qty"`
-	FixedBalance      string  `json:"fixed_balance"`
+	AvailableQty      Decimal `json:"available_qty"`
+	FixedBalance      Decimal `json:"fixed_balance"`
 	InstrumentID      string  `json:"instrument_id"`
-	MarginForUnfilled string  `json:"margin_for_unfilled"`
-	MarginFrozen      string  `json:"margin_frozen"`
-	RealizedPnl       string  `json:"realized_pnl"`
-	UnrealizedPnl     string  `json:"unrealized_pnl"`
-	MarginRatio       string  `json:"margin_ratio"`
-	MaintMarginRatio  string  `json:"maint_margin_ratio"`
-	CanWithdraw       string  `json:"can_withdraw"`
-	Equity            string  `json:"equity"`
+	MarginForUnfilled Decimal `json:"margin_for_unfilled"`
+	MarginFrozen      Decimal `json:"margin_frozen"`
+	RealizedPnl       Decimal `json:"realized_pnl"`
+	UnrealizedPnl     Decimal `json:"unrealized_pnl"`
+	MarginRatio       Decimal `json:"margin_ratio"`
+	MaintMarginRatio  Decimal `json:"maint_margin_ratio"`
+	CanWithdraw       Decimal `json:"can_withdraw"`
+	Equity            Decimal `json:"equity"`
 	MarginMode        string  `json:"margin_mode"`
-	TotalAvailBalance float64 `json:"total_avail_balance,string"`
+	TotalAvailBalance Decimal `json:"total_avail_balance"`
 	AutoMargin        string  `json:"auto_margin"`
 	Underlying        string  `json:"underlying"`
 }
@@ -155,43 +155,43 @@ type FuturesCrossAccountInfo struct {
 
 type FuturesFixedAccount struct {
 	MarginMode        string                         `json:"margin_mode"`
-	Equity            float64                        `json:"equity,string"`
-	TotalAvailBalance float64                        `json:"total_avail_balance,string"`
+	Equity            Decimal                        `json:"equity"`
+	TotalAvailBalance Decimal                        `json:"total_avail_balance"`
 	Contracts         []FuturesFixedAccountContracts `json:"contracts"`
 }
 
 type FuturesFixedAccountContracts struct {
-	AvailableQty      float64 `json:"available_qty,string"`
-	FixedBalance      float64 `json:"fixed_balance,string"`
+	AvailableQty      Decimal `json:"available_qty"`
+	FixedBalance      Decimal `json:"fixed_balance"`
 	InstrumentId      string  `json:"instrument_id"`
-	MarginFixed       float64 `json:"margin_fixed,string"`
-	MarginForUnfilled float64 `json:"margin_for_unfilled,string"`
-	MarginFrozen      float64 `json:"margin_frozen,string"`
-	RealizedPnl       float64 `json:"realized_pnl,string"`
-	UnrealizedPnl     float64 `json:"unrealizedPnl,string"`
+	MarginFixed       Decimal `json:"margin_fixed"`
+	MarginForUnfilled Decimal `json:"margin_for_unfilled"`
+	MarginFrozen      Decimal `json:"margin_frozen"`
+	RealizedPnl       Decimal `json:"realized_pnl"`
+	UnrealizedPnl     Decimal `json:"unrealizedPnl"`
 }
 
 type FuturesCrossAccount struct {
-	Equity            float64 `json:"equity,string"`
-	Margin            float64 `json:"margin,string"`
+	Equity            Decimal `json:"equity"`
+	Margin            Decimal `json:"margin"`
 	MarginMode        string  `json:"margin_mode"`
-	MarginRatio       float64 `json:"margin_ratio,string"`
-	RealizedPnl       float64 `json:"realized_pnl,string"`
-	UnrealizedPnl     float64 `json:"unrealized_pnl,string"`
-	TotalAvailBalance float64 `json:"total_avail_balance,string"`
+	MarginRatio       Decimal `json:"margin_ratio"`
+	RealizedPnl       Decimal `json:"realized_pnl"`
+	UnrealizedPnl     Decimal `json:"unrealized_pnl"`
+	TotalAvailBalance Decimal `json:"total_avail_balance"`
 }
 
 type FuturesCurrencyAccount struct {
-	TotalAvailBalance float64                        `json:"total_avail_balance,string"` // 账户余额（账户静态权益）
+	TotalAvailBalance Decimal                        `json:"total_avail_balance"` // 账户余额（账户静态权益）
 	Contracts         []FuturesFixedAccountContracts `json:"contracts"`
-	Equity            float64                        `json:"equity,string"`         // 账户权益（账户动态权益）
-	MarginMode        string                         `json:"margin_mode"`           // 账户类型 全仓：crossed 逐仓: fixed
-	AutoMargin        int                            `json:"auto_margin,string"`    // 是否自动追加保证金 1: 自动追加已开启 0: 自动追加未开启
-	LiquiMode         string                         `json:"liqui_mode"`            // 强平模式：tier（梯度强平）
-	CanWithdraw       float64                        `json:"can_withdraw,string"`   // 可划转数量
-	RealizedPnl       float64                        `json:"realized_pnl,string"`   // 全仓模式 已实现盈亏
-	UnRealizedPnl     float64                        `json:"unrealized_pnl,string"` // 全仓模式 未实现盈亏
-	Margin            float64                        `json:"margin,string"`         // 保证金（挂单冻结+持仓已用）
+	Equity            Decimal                        `json:"equity"`             // 账户权益（账户动态权益）
+	MarginMode        string                         `json:"margin_mode"`        // 账户类型 全仓：crossed 逐仓: fixed
+	AutoMargin        int                            `json:"auto_margin,string"` // 是否自动追加保证金 1: 自动追加已开启 0: 自动追加未开启
+	LiquiMode         string                         `json:"liqui_mode"`         // 强平模式：tier（梯度强平）
+	CanWithdraw       Decimal                        `json:"can_withdraw"`       // 可划转数量
+	RealizedPnl       Decimal                        `json:"realized_pnl"`       // 全仓模式 已实现盈亏
+	UnRealizedPnl     Decimal                        `json:"unrealized_pnl"`     // 全仓模式 未实现盈亏
+	Margin            Decimal                        `json:"margin"`             // 保证金（挂单冻结+持仓已用）
 }
 
 type FuturesCurrencyAccountV0 struct {
@@ -204,8 +204,8 @@ type FuturesCurrencyAccountV0 struct {
 
 type FuturesCurrencyLedger struct {
 	LedgerId  int64                        `json:"ledger_id,string"`
-	Amount    float64                      `json:"amount,string"`
-	Balance   float64                      `json:"balance,string"`
+	Amount    Decimal                      `json:"amount"`
+	Balance   Decimal                      `json:"balance"`
 	Currency  string                       `json:"currency"`
 	Type      string                       `json:"type"`
 	Timestamp string                       `json:"timestamp"`
@@ -219,7 +219,7 @@ type FuturesCurrencyLedgerDetails struct {
 
 type FuturesAccountsHolds struct {
 	InstrumentId string  `json:"instrument_id"`
-	Amount       float64 `json:"amount,string"`
+	Amount       Decimal `json:"amount"`
 	Timestamp    string  `json:"timestamp"`
 }
 
@@ -271,6 +271,7 @@ type FuturesClosePositionResult struct {
 
 type ClosePositionInfo struct {
 	InstrumentId string `json:"instrument_id"`
+	Direction    string `json:"direction"`
 	CodeMessage
 }
 
@@ -283,29 +284,29 @@ type FuturesGetOrderResult struct {
 	InstrumentId string  `json:"instrument_id"`
 	Size         int64   `json:"size,string"`
 	Timestamp    string  `json:"timestamp"`
-	FilledQty    float64 `json:"filled_qty,string"`
-	Fee          float64 `json:"fee,string"`
+	FilledQty    Decimal `json:"filled_qty"`
+	Fee          Decimal `json:"fee"`
 	OrderId      string  `json:"order_id"`
 	ClientOId    string  `json:"client_oid"`
-	Price        float64 `json:"price,string"`
-	PriceAvg     float64 `json:"price_avg,string"`
+	Price        Decimal `json:"price"`
+	PriceAvg     Decimal `json:"price_avg"`
 	Status       string  `json:"status"`
 	State        int     `json:"state,string"`
 	Type         int     `json:"type,string"`
 	OrderType    int     `json:"order_type,string"`
-	ContractVal  float64 `json:"contract_val,string"`
-	Leverage     float64 `json:"leverage,string"`
+	ContractVal  Decimal `json:"contract_val"`
+	Leverage     Decimal `json:"leverage"`
 }
 
 type FuturesFillResult struct {
 	TradeId      int64   `json:"trade_id,string"`
 	InstrumentId string  `json:"instrument_id"`
-	Price        float64 `json:"price,string"`
-	OrderQty     float64 `json:"order_qty,string"`
+	Price        Decimal `json:"price"`
+	OrderQty     Decimal `json:"order_qty"`
 	OrderId      string  `json:"order_id"`
 	CreatedAt    string  `json:"created_at"`
 	ExecType     string  `json:"exec_type"`
-	Fee          float64 `json:"fee,string"`
+	Fee          Decimal `json:"fee"`
 	Side         string  `json:"side"`
 }
 
@@ -315,8 +316,8 @@ type FuturesUsersSelfTrailingVolumesResult struct {
 
 type FuturesUsersSelfTrailingVolumeResult struct {
 	InstrumentId   string  `json:"instrument_id"`
-	ExchangeVolume float64 `json:"exchange_volume,string"`
-	Volume         float64 `json:"volume,string"`
+	ExchangeVolume Decimal `json:"exchange_volume"`
+	Volume         Decimal `json:"volume"`
 	RecordedAt     string  `json:"recorded_at"`
 }
 
@@ -324,11 +325,11 @@ type FuturesInstrumentsResult struct {
 	InstrumentId        string  `json:"instrument_id"`
 	UnderlyingIndex     string  `json:"underlying_index"`
 	QuoteCurrency       string  `json:"quote_currency"`
-	TickSize            float64 `json:"tick_size,string"`
-	ContractVal         float64 `json:"contract_val,string"`
+	TickSize            Decimal `json:"tick_size"`
+	ContractVal         Decimal `json:"contract_val"`
 	Listing             string  `json:"listing"`
 	Delivery            string  `json:"delivery"`
-	TradeIncrement      float64 `json:"trade_increment,string"`
+	TradeIncrement      Decimal `json:"trade_increment"`
 	Alias               string  `json:"alias"`
 	Underlying          string  `json:"underlying"`
 	BaseCurrency        string  `json:"base_currency"`
@@ -340,7 +341,7 @@ type FuturesInstrumentsResult struct {
 type FuturesInstrumentCurrenciesResult struct {
 	Id      int64   `json:"id,string"`
 	Name    string  `json:"name"`
-	MinSize float64 `json:"min_size,string"`
+	MinSize Decimal `json:"min_size"`
 }
 
 type FuturesInstrumentBookResult struct {
@@ -351,32 +352,32 @@ type FuturesInstrumentBookResult struct {
 
 type FuturesInstrumentTickerResult struct {
 	InstrumentId string  `json:"instrument_id"`
-	BestBid      float64 `json:"best_bid,string"`
-	BestAsk      float64 `json:"best_ask,string"`
-	High24h      float64 `json:"high_24h,string"`
-	Low24h       float64 `json:"low_24h,string"`
-	Last         float64 `json:"last,string"`
-	Volume24h    float64 `json:"volume_24h,string"`
+	BestBid      Decimal `json:"best_bid"`
+	BestAsk      Decimal `json:"best_ask"`
+	High24h      Decimal `json:"high_24h"`
+	Low24h       Decimal `json:"low_24h"`
+	Last         Decimal `json:"last"`
+	Volume24h    Decimal `json:"volume_24h"`
 	Timestamp    string  `json:"timestamp"`
 }
 
 type FuturesInstrumentTradesResult struct {
 	TradeId   string  `json:"trade_id"`
 	Side      string  `json:"side"`
-	Price     float64 `json:"price,string"`
-	Qty       float64 `json:"qty,string"`
+	Price     Decimal `json:"price"`
+	Qty       Decimal `json:"qty"`
 	Timestamp string  `json:"timestamp"`
 }
 
 type FuturesInstrumentIndexResult struct {
 	InstrumentId string  `json:"instrument_id"`
-	Index        float64 `json:"index,string"`
+	Index        Decimal `json:"index"`
 	Timestamp    string  `json:"timestamp"`
 }
 
 type FuturesInstrumentEstimatedPriceResult struct {
 	InstrumentId    string  `json:"instrument_id"`
-	SettlementPrice float64 `json:"settlement_price,string"`
+	SettlementPrice Decimal `json:"settlement_price"`
 	Timestamp       string  `json:"timestamp"`
 }
 
@@ -388,8 +389,8 @@ type FuturesInstrumentOpenInterestResult struct {
 
 type FuturesInstrumentPriceLimitResult struct {
 	InstrumentId string  `json:"instrument_id"`
-	Highest      float64 `json:"highest,string"`
-	Lowest       float64 `json:"lowest,string"`
+	Highest      Decimal `json:"highest"`
+	Lowest       Decimal `json:"lowest"`
 	Timestamp    string  `json:"timestamp"`
 }
 
@@ -400,8 +401,8 @@ type FuturesInstrumentLiquidationListResult struct {
 
 type FuturesInstrumentLiquidationResult struct {
 	InstrumentId string  `json:"instrument_id"`
-	Price        float64 `json:"price"`
+	Price        Decimal `json:"price"`
 	Size         int64   `json:"size"`
-	Loss         float64 `json:"loss"`
+	Loss         Decimal `json:"loss"`
 	CreatedAt    string  `json:"created_at"`
 }
@@ -98,46 +98,20 @@ func TestGetFuturesInstrumentTrades(t *testing.T) {
 func TestGetFuturesInstrumentCandles(t *testing.T) {
 	//start := "2018-06-20T02:31:00Z"
 	//end := "2018-06-20T02:55:00Z"
-	granularity := CANDLES_1MIN
-
-	optional := map[string]string{}
-	//optional["start"] = start
-	//optional["end"] = end
-	optional["granularity"] = Int2String(granularity)
+	params := &CandlesParams{Granularity: CANDLES_1MIN}
+	//params.Start, _ = IsoToTime(start)
+	//params.End, _ = IsoToTime(end)
 
 	insId := getValidInstrumentId()
 
-	candles, err := NewTestClient().GetFuturesInstrumentCandles(insId, optional)
+	candles, err := NewTestClient().GetFuturesInstrumentCandles(insId, params)
 	if err != nil {
 		t.Error(err)
 	}
 	fmt.Println("Futures Instrument candles:")
-	for i, outLen := 0, len(candles); i < outLen; i++ {
-		candle := candles[i]
-		for j, inLen := 0, 7; j < inLen; j++ {
-			if j == 0 {
-				fmt.Print("timestamp:")
-				fmt.Print(candle[j])
-			} else if j == 1 {
-				fmt.Print(" open:")
-				fmt.Print(candle[j])
-			} else if j == 2 {
-				fmt.Print(" high:")
-				fmt.Print(candle[j])
-			} else if j == 3 {
-				fmt.Print(" low:")
-				fmt.Print(candle[j])
-			} else if j == 4 {
-				fmt.Print(" close:")
-				fmt.Print(candle[j])
-			} else if j == 5 {
-				fmt.Print(" volume:")
-				fmt.Print(candle[j])
-			} else if j == 6 {
-				fmt.Print(" currency_volume:")
-				fmt.Println(candle[j])
-			}
-		}
+	for _, candle := range candles {
+		fmt.Println("timestamp:", candle.Time, " open:", candle.Open, " high:", candle.High, " low:", candle.Low,
+			" close:", candle.Close, " volume:", candle.Volume, " currency_volume:", candle.CurrencyVolume)
 	}
 }
 
@@ -280,7 +254,7 @@ func TestFuturesOrders(t *testing.T) {
 		var item FuturesBatchNewOrderItem
 		item.ClientOid = "od" + IntToString(12345670+i)
 		item.Type = IntToString(OPEN_SHORT)
-		item.Price = IntToString(100000 + i)
+		item.Price = NewDecimalFromInt(int64(100000 + i))
 		item.Size = "1"
 		item.MatchPrice = "0"
 		ordersData[i-1] = item
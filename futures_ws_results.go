@@ -8,39 +8,39 @@ type WSFuturesPositionResult struct {
 }
 
 type WSFuturesPosition struct {
-	LongQty               string    `json:"long_qty"`
-	LongAvailQty          string    `json:"long_avail_qty"`
-	LongMargin            string    `json:"long_margin"`
-	LongLiquiPrice        string    `json:"long_liqui_price"`
-	LongPnlRatio          string    `json:"long_pnl_ratio"`
-	LongAvgCost           string    `json:"long_avg_cost"`
-	LongSettlementPrice   string    `json:"long_settlement_price"`
-	RealisedPnl           string    `json:"realised_pnl"`
-	ShortQty              string    `json:"short_qty"`
-	ShortAvailQty         string    `json:"short_avail_qty"`
-	ShortMargin           string    `json:"short_margin"`
-	ShortLiquiPrice       string    `json:"short_liqui_price"`
-	ShortPnlRatio         string    `json:"short_pnl_ratio"`
-	ShortAvgCost          string    `json:"short_avg_cost"`
-	ShortSettlementPrice  string    `json:"short_settlement_price"`
+	LongQty               Decimal   `json:"long_qty"`
+	LongAvailQty          Decimal   `json:"long_avail_qty"`
+	LongMargin            Decimal   `json:"long_margin"`
+	LongLiquiPrice        Decimal   `json:"long_liqui_price"`
+	LongPnlRatio          Decimal   `json:"long_pnl_ratio"`
+	LongAvgCost           Decimal   `json:"long_avg_cost"`
+	LongSettlementPrice   Decimal   `json:"long_settlement_price"`
+	RealisedPnl           Decimal   `json:"realised_pnl"`
+	ShortQty              Decimal   `json:"short_qty"`
+	ShortAvailQty         Decimal   `json:"short_avail_qty"`
+	ShortMargin           Decimal   `json:"short_margin"`
+	ShortLiquiPrice       Decimal   `json:"short_liqui_price"`
+	ShortPnlRatio         Decimal   `json:"short_pnl_ratio"`
+	ShortAvgCost          Decimal   `json:"short_avg_cost"`
+	ShortSettlementPrice  Decimal   `json:"short_settlement_price"`
 	InstrumentID          string    `json:"instrument_id"`
-	LongLeverage          string    `json:"long_leverage"`
-	ShortLeverage         string    `json:"short_leverage"`
+	LongLeverage          Decimal   `json:"long_leverage"`
+	ShortLeverage         Decimal   `json:"short_leverage"`
 	CreatedAt             time.Time `json:"created_at"`
 	UpdatedAt             time.Time `json:"updated_at"`
 	Timestamp             time.Time `json:"timestamp"`
 	MarginMode            string    `json:"margin_mode"`
-	ShortMarginRatio      string    `json:"short_margin_ratio"`
-	ShortMaintMarginRatio string    `json:"short_maint_margin_ratio"`
-	ShortPnl              string    `json:"short_pnl"`
-	ShortUnrealisedPnl    string    `json:"short_unrealised_pnl"`
-	LongMarginRatio       string    `json:"long_margin_ratio"`
-	LongMaintMarginRatio  string    `json:"long_maint_margin_ratio"`
-	LongPnl               string    `json:"long_pnl"`
-	LongUnrealisedPnl     string    `json:"long_unrealised_pnl"`
-	LongOpenOutstanding   string    `json:"long_open_outstanding"`
-	ShortOpenOutstanding  string    `json:"short_open_outstanding"`
-	LongSettledPnl        string    `json:"long_settled_pnl"`
-	ShortSettledPnl       string    `json:"short_settled_pnl"`
-	Last                  string    `json:"last"`
+	ShortMarginRatio      Decimal   `json:"short_margin_ratio"`
+	ShortMaintMarginRatio Decimal   `json:"short_maint_margin_ratio"`
+	ShortPnl              Decimal   `json:"short_pnl"`
+	ShortUnrealisedPnl    Decimal   `json:"short_unrealised_pnl"`
+	LongMarginRatio       Decimal   `json:"long_margin_ratio"`
+	LongMaintMarginRatio  Decimal   `json:"long_maint_margin_ratio"`
+	LongPnl               Decimal   `json:"long_pnl"`
+	LongUnrealisedPnl     Decimal   `json:"long_unrealised_pnl"`
+	LongOpenOutstanding   Decimal   `json:"long_open_outstanding"`
+	ShortOpenOutstanding  Decimal   `json:"short_open_outstanding"`
+	LongSettledPnl        Decimal   `json:"long_settled_pnl"`
+	ShortSettledPnl       Decimal   `json:"short_settled_pnl"`
+	Last                  Decimal   `json:"last"`
 }
@@ -1,5 +1,7 @@
 package okex
 
+import "context"
+
 /*
  OKEX general api
  @author Tony Tian
@@ -11,7 +13,11 @@ package okex
  Time of the server running OKEX's REST API.
 */
 func (client *Client) GetServerTime() (ServerTime, error) {
+	return client.GetServerTimeCtx(context.Background())
+}
+
+func (client *Client) GetServerTimeCtx(ctx context.Context) (ServerTime, error) {
 	var serverTime ServerTime
-	_, _, err := client.Request(GET, OKEX_TIME_URI, nil, &serverTime)
+	_, _, err := client.RequestCtx(ctx, GET, OKEX_TIME_URI, nil, &serverTime)
 	return serverTime, err
 }
@@ -4,6 +4,7 @@ go 1.13
 
 require (
 	github.com/MauriceGit/skiplist v0.0.0-20191117202105-643e379adb62
+	github.com/gorilla/websocket v1.4.1
 	github.com/json-iterator/go v1.1.9
 	github.com/recws-org/recws v1.2.1
 	github.com/spf13/viper v1.6.3
@@ -0,0 +1,371 @@
+package okex
+
+/*
+ Instrument metadata and order validation.
+
+ InstrumentRegistry loads the swap, futures and spot instruments and caches
+ them for a ttl. Prices must be multiples of the tick size and sizes multiples
+ of the size increment (contracts for swap/futures), otherwise the exchange
+ rejects the order. With a registry set by Client.ValidateOrdersWith,
+ PostSwapOrder, FuturesOrder and PostSpotOrders check their order before
+ sending it:
+
+	registry := okex.NewInstrumentRegistry(client, time.Hour)
+	client.ValidateOrdersWith(registry)
+	price, _ := registry.RoundPrice(ctx, "BTC-USD-SWAP", "6773.437")
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// a failed reload of stale instruments is retried after this
+const instrumentRetryInterval = time.Minute
+
+const (
+	InstrumentTypeSwap    = "swap"
+	InstrumentTypeFutures = "futures"
+	InstrumentTypeSpot    = "spot"
+)
+
+var (
+	ErrUnknownInstrument = errors.New("okex: unknown instrument")
+	ErrInvalidOrder      = errors.New("okex: invalid order")
+)
+
+/*
+ Metadata of a swap, futures or spot instrument.
+ SizeIncrement is in contracts for swap/futures (size_increment, trade_increment)
+ and in base currency for spot. ContractVal, Alias and Delivery are not set for spot.
+*/
+type Instrument struct {
+	InstrumentId        string
+	Type                string // InstrumentTypeSwap, InstrumentTypeFutures, InstrumentTypeSpot
+	Underlying          string // eg: BTC-USD, spot: BTC-USDT
+	BaseCurrency        string
+	QuoteCurrency       string
+	SettlementCurrency  string
+	TickSize            Decimal
+	SizeIncrement       Decimal
+	MinSize             Decimal
+	ContractVal         Decimal
+	ContractValCurrency string
+	IsInverse           bool   // coin margined
+	Alias               string // futures: this_week, next_week, quarter, bi_quarter
+	Listing             time.Time
+	Delivery            time.Time // futures expiry, 08:00 UTC of the delivery date
+}
+
+// Round price to the nearest multiple of the tick size
+func (i Instrument) RoundPrice(price Decimal) Decimal {
+	if i.TickSize.Sign() <= 0 {
+		return price
+	}
+	return price.Div(i.TickSize, 0).Mul(i.TickSize)
+}
+
+// Truncate size to a multiple of the size increment, so it never exceeds the given size
+func (i Instrument) RoundSize(size Decimal) Decimal {
+	if i.SizeIncrement.Sign() <= 0 {
+		return size
+	}
+	return size.TruncateTo(i.SizeIncrement)
+}
+
+/*
+ Check price and size are numbers, positive and on the instrument's grid.
+ An empty price (market orders, match price) or size (spot market buys by notional)
+ is not checked. The error wraps ErrInvalidOrder.
+*/
+func (i Instrument) Validate(price, size Decimal) error {
+	if price != "" {
+		if !price.Valid() || price.Sign() <= 0 {
+			return fmt.Errorf("%w: %s: illegal price %q", ErrInvalidOrder, i.InstrumentId, price)
+		}
+		if i.TickSize.Sign() > 0 && !price.IsMultipleOf(i.TickSize) {
+			return fmt.Errorf("%w: %s: price %s is not a multiple of the tick size %s", ErrInvalidOrder, i.InstrumentId, price, i.TickSize)
+		}
+	}
+	if size != "" {
+		if !size.Valid() || size.Sign() <= 0 {
+			return fmt.Errorf("%w: %s: illegal size %q", ErrInvalidOrder, i.InstrumentId, size)
+		}
+		if i.SizeIncrement.Sign() > 0 && !size.IsMultipleOf(i.SizeIncrement) {
+			return fmt.Errorf("%w: %s: size %s is not a multiple of the size increment %s", ErrInvalidOrder, i.InstrumentId, size, i.SizeIncrement)
+		}
+		if i.MinSize.Sign() > 0 && size.Cmp(i.MinSize) < 0 {
+			return fmt.Errorf("%w: %s: size %s is less than the min size %s", ErrInvalidOrder, i.InstrumentId, size, i.MinSize)
+		}
+	}
+	return nil
+}
+
+type InstrumentRegistry struct {
+	client *Client
+	ttl    time.Duration
+
+	mu          sync.RWMutex
+	refreshMu   sync.Mutex
+	instruments map[string]Instrument
+	loadedAt    time.Time
+	failedAt    time.Time
+}
+
+/*
+ A registry loading its instruments with client, and reloading them once they
+ are older than ttl. ttl 0 never reloads.
+*/
+func NewInstrumentRegistry(client *Client, ttl time.Duration) *InstrumentRegistry {
+	return &InstrumentRegistry{client: client, ttl: ttl}
+}
+
+/*
+ Load all swap, futures and spot instruments and replace the cached ones.
+ On error the cache is left as it is.
+*/
+func (r *InstrumentRegistry) Refresh(ctx context.Context) error {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	return r.refresh(ctx)
+}
+
+func (r *InstrumentRegistry) refresh(ctx context.Context) error {
+	instruments := make(map[string]Instrument)
+
+	swaps, err := r.client.GetSwapInstrumentsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range swaps {
+		instruments[s.InstrumentId] = swapInstrument(s)
+	}
+
+	futures, err := r.client.GetFuturesInstrumentsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, f := range futures {
+		instruments[f.InstrumentId] = futuresInstrument(f)
+	}
+
+	spots, err := r.client.GetSpotInstrumentsCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range spots {
+		instruments[s.InstrumentID] = spotInstrument(s)
+	}
+
+	r.mu.Lock()
+	r.instruments = instruments
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *InstrumentRegistry) stale() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.instruments == nil {
+		return true
+	}
+	return r.ttl > 0 && time.Since(r.loadedAt) > r.ttl && time.Since(r.failedAt) > instrumentRetryInterval
+}
+
+/*
+ Refresh if the cache is empty or older than the ttl. A failed reload keeps the
+ stale instruments, is only logged and retried a minute later, unless there are
+ none at all.
+*/
+func (r *InstrumentRegistry) ensureFresh(ctx context.Context) error {
+	if !r.stale() {
+		return nil
+	}
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	// refreshed by another goroutine meanwhile
+	if !r.stale() {
+		return nil
+	}
+	err := r.refresh(ctx)
+	if err == nil {
+		return nil
+	}
+	r.mu.Lock()
+	loaded := r.instruments != nil
+	r.failedAt = time.Now()
+	r.mu.Unlock()
+	if !loaded {
+		return err
+	}
+	r.client.logger.Log(LogLevelWarn, "refresh instruments failed", F("error", err))
+	return nil
+}
+
+// The instrument by id, eg: BTC-USD-SWAP, BTC-USD-200925, btc-usdt. The error wraps ErrUnknownInstrument.
+func (r *InstrumentRegistry) Get(ctx context.Context, instrumentId string) (Instrument, error) {
+	if err := r.ensureFresh(ctx); err != nil {
+		return Instrument{}, err
+	}
+	r.mu.RLock()
+	instrument, ok := r.instruments[strings.ToUpper(instrumentId)]
+	r.mu.RUnlock()
+	if !ok {
+		return Instrument{}, fmt.Errorf("%w: %s", ErrUnknownInstrument, instrumentId)
+	}
+	return instrument, nil
+}
+
+// All instruments of a type (InstrumentTypeSwap ...), or all for "", sorted by id
+func (r *InstrumentRegistry) Instruments(ctx context.Context, instrumentType string) ([]Instrument, error) {
+	if err := r.ensureFresh(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	var instruments []Instrument
+	for _, instrument := range r.instruments {
+		if instrumentType == "" || instrument.Type == instrumentType {
+			instruments = append(instruments, instrument)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(instruments, func(i, j int) bool {
+		return instruments[i].InstrumentId < instruments[j].InstrumentId
+	})
+	return instruments, nil
+}
+
+func (r *InstrumentRegistry) RoundPrice(ctx context.Context, instrumentId string, price Decimal) (Decimal, error) {
+	instrument, err := r.Get(ctx, instrumentId)
+	if err != nil {
+		return price, err
+	}
+	return instrument.RoundPrice(price), nil
+}
+
+func (r *InstrumentRegistry) RoundSize(ctx context.Context, instrumentId string, size Decimal) (Decimal, error) {
+	instrument, err := r.Get(ctx, instrumentId)
+	if err != nil {
+		return size, err
+	}
+	return instrument.RoundSize(size), nil
+}
+
+// @see Instrument.Validate
+func (r *InstrumentRegistry) Validate(ctx context.Context, instrumentId string, price, size Decimal) error {
+	instrument, err := r.Get(ctx, instrumentId)
+	if err != nil {
+		return err
+	}
+	return instrument.Validate(price, size)
+}
+
+/*
+ Validate every order of PostSwapOrder, FuturesOrder and PostSpotOrders with the
+ registry before sending it, nil stops validating.
+*/
+func (client *Client) ValidateOrdersWith(registry *InstrumentRegistry) {
+	client.instruments = registry
+}
+
+// matchPrice "1" ignores the price, @see BasePlaceOrderInfo.MatchPrice
+func (client *Client) validateOrder(ctx context.Context, instrumentId string, price, size Decimal, matchPrice string) error {
+	if client.instruments == nil {
+		return nil
+	}
+	if matchPrice == "1" {
+		price = ""
+	}
+	return client.instruments.Validate(ctx, instrumentId, price, size)
+}
+
+func parseInstrumentTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+/*
+ Whether a contract is coin margined: is_inverse, which older api versions do
+ not answer, or else settled in its base currency, or else quoted in USD.
+*/
+func inverseContract(isInverse bool, baseCurrency, quoteCurrency, settlementCurrency string) bool {
+	if isInverse {
+		return true
+	}
+	if baseCurrency != "" && settlementCurrency != "" {
+		return settlementCurrency == baseCurrency
+	}
+	return quoteCurrency == "USD"
+}
+
+func swapInstrument(s BaseInstrumentInfo) Instrument {
+	return Instrument{
+		InstrumentId:        s.InstrumentId,
+		Type:                InstrumentTypeSwap,
+		Underlying:          s.Underlying,
+		BaseCurrency:        s.BaseCurrency,
+		QuoteCurrency:       s.QuoteCurrency,
+		SettlementCurrency:  s.SettlementCurrency,
+		TickSize:            s.TickSize,
+		SizeIncrement:       s.SizeIncrement,
+		ContractVal:         s.ContractVal,
+		ContractValCurrency: s.ContractValCurrency,
+		IsInverse:           inverseContract(s.IsInverse, s.BaseCurrency, s.QuoteCurrency, s.SettlementCurrency),
+		Listing:             parseInstrumentTime(s.Listing),
+	}
+}
+
+func futuresInstrument(f FuturesInstrumentsResult) Instrument {
+	delivery := parseInstrumentTime(f.Delivery)
+	// a date only, eg: 2020-09-25
+	if len(f.Delivery) == len("2006-01-02") {
+		delivery = delivery.Add(8 * time.Hour)
+	}
+	underlying := f.Underlying
+	// older api versions, eg: BTC + USD
+	if underlying == "" && f.UnderlyingIndex != "" {
+		underlying = f.UnderlyingIndex + "-" + f.QuoteCurrency
+	}
+	return Instrument{
+		InstrumentId:        f.InstrumentId,
+		Type:                InstrumentTypeFutures,
+		Underlying:          underlying,
+		BaseCurrency:        f.BaseCurrency,
+		QuoteCurrency:       f.QuoteCurrency,
+		SettlementCurrency:  f.SettlementCurrency,
+		TickSize:            f.TickSize,
+		SizeIncrement:       f.TradeIncrement,
+		ContractVal:         f.ContractVal,
+		ContractValCurrency: f.ContractValCurrency,
+		IsInverse:           inverseContract(f.IsInverse, f.BaseCurrency, f.QuoteCurrency, f.SettlementCurrency),
+		Alias:               f.Alias,
+		Listing:             parseInstrumentTime(f.Listing),
+		Delivery:            delivery,
+	}
+}
+
+func spotInstrument(s SpotInstrument) Instrument {
+	return Instrument{
+		InstrumentId:  s.InstrumentID,
+		Type:          InstrumentTypeSpot,
+		Underlying:    s.InstrumentID,
+		BaseCurrency:  s.BaseCurrency,
+		QuoteCurrency: s.QuoteCurrency,
+		TickSize:      s.TickSize,
+		SizeIncrement: s.SizeIncrement,
+		MinSize:       s.MinSize,
+	}
+}
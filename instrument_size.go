@@ -0,0 +1,210 @@
+package okex
+
+/*
+ Size conversions between contracts, coin and USD.
+
+ Swap and futures sizes are in contracts. A coin margined (inverse) contract is
+ worth contract_val USD, eg: 100 USD for BTC-USD-SWAP, a USDT margined (linear)
+ contract is worth contract_val coin, eg: 0.01 BTC for BTC-USDT-SWAP:
+
+	inverse: usd = contracts * contract_val, coin = usd / price
+	linear:  coin = contracts * contract_val, usd = coin * price
+
+ USD is the quote currency, USDT for USDT margined instruments. Spot and margin
+ instruments convert like linear contracts of 1 base currency.
+*/
+
+import (
+	"context"
+	"strings"
+)
+
+// decimals kept by divisions of the conversions
+const sizeConversionPlaces = 12
+
+const (
+	PositionSideLong  = "long"
+	PositionSideShort = "short"
+)
+
+// One side of a position in contracts, and its coin and USD value at Price
+type PositionSize struct {
+	InstrumentId string
+	Side         string // PositionSideLong, PositionSideShort
+	Contracts    Decimal
+	Coin         Decimal
+	USD          Decimal
+	Price        Decimal
+}
+
+func (i Instrument) contractVal() Decimal {
+	if i.ContractVal.Sign() <= 0 {
+		return "1"
+	}
+	return i.ContractVal
+}
+
+// The coin amount of contracts at price, 0 if an inverse instrument has no positive price
+func (i Instrument) ContractsToCoin(contracts, price Decimal) Decimal {
+	if !i.IsInverse {
+		return contracts.Mul(i.contractVal())
+	}
+	if price.Sign() <= 0 {
+		return "0"
+	}
+	return contracts.Mul(i.contractVal()).Div(price, sizeConversionPlaces)
+}
+
+// The USD (quote currency) value of contracts at price
+func (i Instrument) ContractsToUSD(contracts, price Decimal) Decimal {
+	if i.IsInverse {
+		return contracts.Mul(i.contractVal())
+	}
+	return contracts.Mul(i.contractVal()).Mul(price)
+}
+
+/*
+ The contracts worth the coin amount at price, truncated to the size increment
+ so they are never worth more. 0 if an inverse instrument has no positive price.
+*/
+func (i Instrument) CoinToContracts(coin, price Decimal) Decimal {
+	if !i.IsInverse {
+		return i.RoundSize(coin.Div(i.contractVal(), sizeConversionPlaces))
+	}
+	if price.Sign() <= 0 {
+		return "0"
+	}
+	return i.RoundSize(coin.Mul(price).Div(i.contractVal(), sizeConversionPlaces))
+}
+
+/*
+ The contracts worth the USD (quote currency) amount at price, truncated to the
+ size increment. 0 if a linear instrument has no positive price.
+*/
+func (i Instrument) USDToContracts(usd, price Decimal) Decimal {
+	if i.IsInverse {
+		return i.RoundSize(usd.Div(i.contractVal(), sizeConversionPlaces))
+	}
+	if price.Sign() <= 0 {
+		return "0"
+	}
+	return i.RoundSize(usd.Div(i.contractVal().Mul(price), sizeConversionPlaces))
+}
+
+// The size of contracts on side at price
+func (i Instrument) PositionSize(side string, contracts, price Decimal) PositionSize {
+	return PositionSize{
+		InstrumentId: i.InstrumentId,
+		Side:         side,
+		Contracts:    contracts,
+		Coin:         i.ContractsToCoin(contracts, price),
+		USD:          i.ContractsToUSD(contracts, price),
+		Price:        price,
+	}
+}
+
+/*
+ The size of the holding at price, at the average cost for an empty price.
+ Swap holdings of the net mode (side "net") are long for a positive position.
+*/
+func (h SwapPositionHolding) Size(instrument Instrument, price Decimal) PositionSize {
+	if price == "" {
+		price = h.AvgCost
+	}
+	side, contracts := h.direction()
+	return instrument.PositionSize(side, contracts, price)
+}
+
+// The side (long, short) and the contracts of the holding, net holdings by the sign of the position
+func (h SwapPositionHolding) direction() (string, Decimal) {
+	side, contracts := h.Side, h.Position
+	if side != PositionSideLong && side != PositionSideShort {
+		side = PositionSideLong
+		if contracts.Sign() < 0 {
+			side, contracts = PositionSideShort, contracts.Abs()
+		}
+	}
+	return side, contracts
+}
+
+/*
+ The sizes of the long and the short side of the holding, sides without
+ contracts are left out. An empty price values each side at its average cost.
+*/
+func (h FuturesPositionBase) Sizes(instrument Instrument, price Decimal) []PositionSize {
+	var sizes []PositionSize
+	for _, side := range []struct {
+		side      string
+		contracts Decimal
+		avgCost   Decimal
+	}{
+		{PositionSideLong, h.LongQty, h.LongAvgCost},
+		{PositionSideShort, h.ShortQty, h.ShortAvgCost},
+	} {
+		if side.contracts.Sign() == 0 {
+			continue
+		}
+		p := price
+		if p == "" {
+			p = side.avgCost
+		}
+		sizes = append(sizes, instrument.PositionSize(side.side, side.contracts, p))
+	}
+	return sizes
+}
+
+// The sizes of all holdings at their average cost, @see GetSwapPositions
+func (r *InstrumentRegistry) SwapPositionSizes(ctx context.Context, positions []SwapPosition) ([]PositionSize, error) {
+	var sizes []PositionSize
+	for _, position := range positions {
+		for _, h := range position.Holding {
+			if h.Position.Sign() == 0 {
+				continue
+			}
+			instrument, err := r.Get(ctx, h.InstrumentId)
+			if err != nil {
+				return nil, err
+			}
+			sizes = append(sizes, h.Size(instrument, ""))
+		}
+	}
+	return sizes, nil
+}
+
+// The sizes of all cross and fixed holdings at their average cost, @see GetFuturesPositions
+func (r *InstrumentRegistry) FuturesPositionSizes(ctx context.Context, position FuturesPosition) ([]PositionSize, error) {
+	var holdings []FuturesPositionBase
+	for _, h := range position.CrossPosition {
+		holdings = append(holdings, h.FuturesPositionBase)
+	}
+	for _, h := range position.FixedPosition {
+		holdings = append(holdings, h.FuturesPositionBase)
+	}
+	var sizes []PositionSize
+	for _, h := range holdings {
+		if h.LongQty.Sign() == 0 && h.ShortQty.Sign() == 0 {
+			continue
+		}
+		instrument, err := r.Get(ctx, h.InstrumentId)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, h.Sizes(instrument, "")...)
+	}
+	return sizes, nil
+}
+
+/*
+ The coin amount of the order size at price, from the order's own contract_val,
+ without an instrument registry. Instruments quoted in USD (eg: BTC-USD-SWAP,
+ BTC-USD-200925) are taken as coin margined.
+*/
+func (o WSOrder) Coin(price Decimal) Decimal {
+	var base, quote string
+	if parts := strings.Split(o.InstrumentID, "-"); len(parts) > 2 {
+		base, quote = parts[0], parts[1]
+	}
+	instrument := Instrument{InstrumentId: o.InstrumentID, ContractVal: o.ContractVal,
+		IsInverse: inverseContract(false, base, quote, "")}
+	return instrument.ContractsToCoin(o.Size, price)
+}
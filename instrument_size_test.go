@@ -0,0 +1,64 @@
+package okex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrument_SizeConversions(t *testing.T) {
+	inverse := Instrument{InstrumentId: "BTC-USD-SWAP", ContractVal: "100", SizeIncrement: "1", IsInverse: true}
+	assert.Equal(t, Decimal("0.50000000"), inverse.ContractsToCoin("100", "20000").Round(8))
+	assert.Equal(t, Decimal("10000"), inverse.ContractsToUSD("100", "20000"))
+	assert.Equal(t, Decimal("100"), inverse.CoinToContracts("0.5", "20000"))
+	assert.Equal(t, Decimal("99"), inverse.CoinToContracts("0.4999", "20000"))
+	assert.Equal(t, Decimal("12"), inverse.USDToContracts("1250", ""))
+	assert.Equal(t, Decimal("0"), inverse.CoinToContracts("1", ""))
+
+	linear := Instrument{InstrumentId: "BTC-USDT-SWAP", ContractVal: "0.01", SizeIncrement: "1"}
+	assert.Equal(t, Decimal("1.00"), linear.ContractsToCoin("100", "20000"))
+	assert.Equal(t, Decimal("20000.00"), linear.ContractsToUSD("100", "20000"))
+	assert.Equal(t, Decimal("150"), linear.CoinToContracts("1.5", ""))
+	assert.Equal(t, Decimal("5"), linear.USDToContracts("1000", "20000"))
+
+	spot := Instrument{InstrumentId: "BTC-USDT", SizeIncrement: "0.0001"}
+	assert.Equal(t, Decimal("0.0123"), spot.USDToContracts("246.9", "20000"))
+	assert.Equal(t, Decimal("246.0000"), spot.ContractsToUSD("0.0123", "20000"))
+
+	order := WSOrder{InstrumentID: "BTC-USD-200925", ContractVal: "100", Size: "3"}
+	assert.Equal(t, Decimal("0.01500000"), order.Coin("20000").Round(8))
+	order = WSOrder{InstrumentID: "BTC-USDT-200925", ContractVal: "0.01", Size: "3"}
+	assert.Equal(t, Decimal("0.03"), order.Coin("20000"))
+}
+
+func TestInstrumentRegistry_PositionSizes(t *testing.T) {
+	loads := 0
+	c, server := newLocalTestClient(newInstrumentTestServer(t, &loads))
+	defer server.Close()
+	ctx := context.Background()
+	registry := NewInstrumentRegistry(c, 0)
+
+	sizes, err := registry.SwapPositionSizes(ctx, []SwapPosition{{Holding: []SwapPositionHolding{
+		{InstrumentId: "BTC-USD-SWAP", Side: "short", Position: "50", AvgCost: "10000"},
+		{InstrumentId: "BTC-USD-SWAP", Side: "long", Position: "0", AvgCost: "0"},
+	}}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(sizes))
+	assert.Equal(t, PositionSideShort, sizes[0].Side)
+	assert.Equal(t, Decimal("0.50000000"), sizes[0].Coin.Round(8))
+	assert.Equal(t, Decimal("5000"), sizes[0].USD)
+
+	sizes, err = registry.FuturesPositionSizes(ctx, FuturesPosition{CrossPosition: []FuturesCrossPositionHolding{{
+		FuturesPositionBase: FuturesPositionBase{InstrumentId: "BTC-USDT-200925", LongQty: "200", LongAvgCost: "10000", ShortQty: "0"},
+	}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []PositionSize{{InstrumentId: "BTC-USDT-200925", Side: PositionSideLong, Contracts: "200",
+		Coin: "2.00", USD: "20000.00", Price: "10000"}}, sizes)
+
+	holding := SwapPositionHolding{InstrumentId: "BTC-USD-SWAP", Side: "net", Position: "-3", AvgCost: "10000"}
+	size := holding.Size(Instrument{ContractVal: "100", IsInverse: true}, "20000")
+	assert.Equal(t, PositionSideShort, size.Side)
+	assert.Equal(t, Decimal("3"), size.Contracts)
+	assert.Equal(t, Decimal("300"), size.USD)
+}
@@ -0,0 +1,116 @@
+package okex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newInstrumentTestServer(t *testing.T, loads *int) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SWAP_INSTRUMENTS:
+			*loads++
+			w.Write([]byte(`[{"instrument_id":"BTC-USD-SWAP","underlying":"BTC-USD","base_currency":"BTC","quote_currency":"USD",` +
+				`"settlement_currency":"BTC","contract_val":"100","listing":"2018-08-28T02:43:23.000Z","delivery":"2020-01-15T08:00:00.000Z",` +
+				`"size_increment":"1","tick_size":"0.1","is_inverse":"true","contract_val_currency":"USD"}]`))
+		case FUTURES_INSTRUMENTS:
+			w.Write([]byte(`[{"instrument_id":"BTC-USDT-200925","underlying":"BTC-USDT","base_currency":"BTC","quote_currency":"USDT",` +
+				`"settlement_currency":"USDT","contract_val":"0.01","listing":"2020-09-11","delivery":"2020-09-25","trade_increment":"1",` +
+				`"tick_size":"0.1","alias":"quarter","is_inverse":"false","contract_val_currency":"BTC"}]`))
+		case SPOT_INSTRUMENTS:
+			w.Write([]byte(`[{"base_currency":"BTC","instrument_id":"BTC-USDT","min_size":"0.001","quote_currency":"USDT",` +
+				`"size_increment":"0.00000001","tick_size":"0.1"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+}
+
+func TestInstrumentRegistry(t *testing.T) {
+	loads := 0
+	c, server := newLocalTestClient(newInstrumentTestServer(t, &loads))
+	defer server.Close()
+	ctx := context.Background()
+
+	registry := NewInstrumentRegistry(c, time.Hour)
+	swap, err := registry.Get(ctx, "BTC-USD-SWAP")
+	assert.Nil(t, err)
+	assert.Equal(t, InstrumentTypeSwap, swap.Type)
+	assert.Equal(t, Decimal("100"), swap.ContractVal)
+	assert.True(t, swap.IsInverse)
+
+	futures, err := registry.Get(ctx, "BTC-USDT-200925")
+	assert.Nil(t, err)
+	assert.Equal(t, "quarter", futures.Alias)
+	assert.Equal(t, time.Date(2020, 9, 25, 8, 0, 0, 0, time.UTC), futures.Delivery)
+	assert.Equal(t, Decimal("1"), futures.SizeIncrement)
+
+	spot, err := registry.Get(ctx, "btc-usdt")
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.001"), spot.MinSize)
+
+	_, err = registry.Get(ctx, "ETH-USD-SWAP")
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+	assert.Equal(t, 1, loads)
+
+	all, err := registry.Instruments(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(all))
+
+	price, err := registry.RoundPrice(ctx, "BTC-USD-SWAP", "6773.46")
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("6773.5"), price)
+	assert.Equal(t, Decimal("0.12345678"), spot.RoundSize("0.123456789"))
+	assert.Equal(t, Decimal("3"), swap.RoundSize("3.9"))
+
+	assert.Nil(t, swap.Validate("6773.4", "3"))
+	assert.Nil(t, swap.Validate("", "3"))
+	assert.True(t, errors.Is(swap.Validate("6773.45", "3"), ErrInvalidOrder))
+	assert.True(t, errors.Is(swap.Validate("6773.4", "1.5"), ErrInvalidOrder))
+	assert.True(t, errors.Is(swap.Validate("-1", "1"), ErrInvalidOrder))
+	assert.True(t, errors.Is(spot.Validate("6773.4", "0.0001"), ErrInvalidOrder))
+}
+
+func TestClient_ValidateOrdersWith(t *testing.T) {
+	loads := 0
+	handler := newInstrumentTestServer(t, &loads)
+	orders := 0
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == SWAP_ORDER {
+			orders++
+			w.Write([]byte(`{"order_id":"1","client_oid":"","error_code":"0","error_message":"","result":"true"}`))
+			return
+		}
+		handler(w, r)
+	})
+	defer server.Close()
+
+	c.ValidateOrdersWith(NewInstrumentRegistry(c, 0))
+	_, _, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{Type: "1", Price: "6773.45", Size: "1"})
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Equal(t, 0, orders)
+
+	_, _, err = c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{Type: "1", Price: "6773.45", Size: "1", MatchPrice: "1"})
+	assert.Nil(t, err)
+	_, result, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{Type: "1", Price: "6773.4", Size: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", result.OrderId)
+	assert.Equal(t, 2, orders)
+	assert.Equal(t, 1, loads)
+}
+
+func TestInverseContract(t *testing.T) {
+	assert.True(t, inverseContract(true, "", "", ""))
+	// no is_inverse answered
+	assert.True(t, swapInstrument(BaseInstrumentInfo{InstrumentId: "BTC-USD-SWAP", BaseCurrency: "BTC", QuoteCurrency: "USD",
+		SettlementCurrency: "BTC"}).IsInverse)
+	assert.False(t, swapInstrument(BaseInstrumentInfo{InstrumentId: "BTC-USDT-SWAP", BaseCurrency: "BTC", QuoteCurrency: "USDT",
+		SettlementCurrency: "USDT"}).IsInverse)
+	assert.True(t, futuresInstrument(FuturesInstrumentsResult{InstrumentId: "BTC-USD-200925", QuoteCurrency: "USD"}).IsInverse)
+	assert.False(t, futuresInstrument(FuturesInstrumentsResult{InstrumentId: "BTC-USDT-200925", QuoteCurrency: "USDT"}).IsInverse)
+}
@@ -0,0 +1,148 @@
+package okex
+
+/*
+ Leveled, structured logging.
+
+ Every logger handed to Client or the WS clients is wrapped so that api keys,
+ secret keys, passphrases and signatures never reach the underlying logger:
+ fields with a sensitive key are masked, and any known credential appearing in
+ a message or field value is replaced by "***".
+*/
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Field is a structured key/value pair attached to a log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...Field)
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+/*
+ Logger writing "level msg key=value ..." lines to stderr, dropping entries below level
+*/
+func NewStdLogger(level LogLevel) Logger {
+	return &stdLogger{logger: log.New(os.Stderr, "", log.LstdFlags), level: level}
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, fields ...Field) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	l.logger.Print(b.String())
+}
+
+const redacted = "***"
+
+// Lower-cased field keys whose values are always masked.
+var sensitiveLogKeys = map[string]bool{
+	"api_key":                             true,
+	"apikey":                              true,
+	"secret_key":                          true,
+	"secretkey":                           true,
+	"passphrase":                          true,
+	"sign":                                true,
+	"signature":                           true,
+	strings.ToLower(OK_ACCESS_KEY):        true,
+	strings.ToLower(OK_ACCESS_SIGN):       true,
+	strings.ToLower(OK_ACCESS_PASSPHRASE): true,
+}
+
+type redactingLogger struct {
+	next    Logger
+	secrets []string
+}
+
+/*
+ Wrap logger so that sensitive fields and the given credentials are masked.
+ A nil logger logs at info level to stderr.
+*/
+func newRedactingLogger(logger Logger, secrets ...string) Logger {
+	if logger == nil {
+		logger = NewStdLogger(LogLevelInfo)
+	}
+	r := &redactingLogger{next: logger}
+	for _, s := range secrets {
+		if s != "" {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	return r
+}
+
+func (r *redactingLogger) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, redacted, -1)
+	}
+	return s
+}
+
+func (r *redactingLogger) Log(level LogLevel, msg string, fields ...Field) {
+	safe := make([]Field, len(fields))
+	for i, f := range fields {
+		if sensitiveLogKeys[strings.ToLower(f.Key)] {
+			safe[i] = F(f.Key, redacted)
+			continue
+		}
+		switch v := f.Value.(type) {
+		case nil, bool, int, int64, float64, LogLevel, ErrorClass:
+			safe[i] = f
+		default:
+			s := fmt.Sprintf("%v", v)
+			if rs := r.redact(s); rs != s {
+				safe[i] = F(f.Key, rs)
+			} else {
+				safe[i] = f
+			}
+		}
+	}
+	r.next.Log(level, r.redact(msg), safe...)
+}
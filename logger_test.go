@@ -0,0 +1,78 @@
+package okex
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryLogger struct {
+	sync.Mutex
+	lines []string
+}
+
+func (l *memoryLogger) Log(level LogLevel, msg string, fields ...Field) {
+	l.Lock()
+	defer l.Unlock()
+	line := level.String() + " " + msg
+	for _, f := range fields {
+		line += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+	}
+	l.lines = append(l.lines, line)
+}
+
+func (l *memoryLogger) String() string {
+	l.Lock()
+	defer l.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestLogger_Redact(t *testing.T) {
+	mem := &memoryLogger{}
+	logger := newRedactingLogger(mem, "my-api-key", "my-secret")
+	logger.Log(LogLevelDebug, "login with my-secret",
+		F("sign", "c2lnbmF0dXJl"),
+		F("Ok-Access-Passphrase", "pass"),
+		F("args", []string{"my-api-key", "x"}),
+		F("status", 200))
+
+	out := mem.String()
+	assert.NotContains(t, out, "my-api-key")
+	assert.NotContains(t, out, "my-secret")
+	assert.NotContains(t, out, "c2lnbmF0dXJl")
+	assert.NotContains(t, out, "pass ")
+	assert.Contains(t, out, "status=200")
+	assert.Contains(t, out, "args=[*** x]")
+}
+
+func TestLogger_ClientRequest(t *testing.T) {
+	mem := &memoryLogger{}
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	config := c.Config
+	config.IsPrint = true
+	config.Logger = mem
+	c = NewClient(config)
+
+	_, err := c.GetServerTime()
+	assert.Nil(t, err)
+	out := mem.String()
+	assert.Contains(t, out, "debug request")
+	assert.Contains(t, out, "debug response")
+	assert.NotContains(t, out, config.ApiKey)
+	assert.NotContains(t, out, config.SecretKey)
+	assert.NotContains(t, out, config.Passphrase)
+
+	mem.lines = nil
+	config.LogSecrets = true
+	c = NewClient(config)
+	_, err = c.GetServerTime()
+	assert.Nil(t, err)
+	assert.Contains(t, mem.String(), config.ApiKey)
+}
@@ -1,6 +1,7 @@
 package okex
 
 import (
+	"context"
 	"strings"
 )
 
@@ -13,10 +14,14 @@ HTTP请求
 GET /api/margin/v3/accounts
 */
 func (client *Client) GetMarginAccounts() (GetMarginAccountsResult, error) {
+	return client.GetMarginAccountsCtx(context.Background())
+}
+
+func (client *Client) GetMarginAccountsCtx(ctx context.Context) (GetMarginAccountsResult, error) {
 	//r := []map[string]interface{}{}
 	var r GetMarginAccountsResult
 
-	if _, _, err := client.Request(GET, MARGIN_ACCOUNTS, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, MARGIN_ACCOUNTS, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -31,10 +36,14 @@ HTTP请求
 GET /api/margin/v3/accounts/<instrument_id>
 */
 func (client *Client) GetMarginAccountsByInstrument(instrumentId string) (GetMarginAccountsByInstrumentResult, error) {
+	return client.GetMarginAccountsByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetMarginAccountsByInstrumentCtx(ctx context.Context, instrumentId string) (GetMarginAccountsByInstrumentResult, error) {
 	var r GetMarginAccountsByInstrumentResult
 
 	uri := GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT, instrumentId)
-	_, _, err := client.Request(GET, uri, nil, &r)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
 	return r, err
 }
 
@@ -46,17 +55,18 @@ func (client *Client) GetMarginAccountsByInstrument(instrumentId string) (GetMar
 HTTP请求
 GET /api/margin/v3/accounts/<instrument_id>/ledger
 */
-func (client *Client) GetMarginAccountsLegerByInstrument(instrumentId string, optionalParams *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-	uri := GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_LEDGER, instrumentId)
-	if optionalParams != nil && len(*optionalParams) > 0 {
-		uri = BuildParams(uri, *optionalParams)
-	}
+func (client *Client) GetMarginAccountsLegerByInstrument(instrumentId string, params *LedgerParams) ([]SpotLedger, error) {
+	return client.GetMarginAccountsLegerByInstrumentCtx(context.Background(), instrumentId, params)
+}
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+func (client *Client) GetMarginAccountsLegerByInstrumentCtx(ctx context.Context, instrumentId string, params *LedgerParams) ([]SpotLedger, error) {
+	var r []SpotLedger
+	uri := pageUri(GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_LEDGER, instrumentId), params.params())
+
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -67,13 +77,17 @@ func (client *Client) GetMarginAccountsLegerByInstrument(instrumentId string, op
 HTTP请求
 GET /api/margin/v3/accounts/availability
 */
-func (client *Client) GetMarginAccountsAvailability() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetMarginAccountsAvailability() (GetMarginAccountsAvailabilityByInstrumentIdResult, error) {
+	return client.GetMarginAccountsAvailabilityCtx(context.Background())
+}
+
+func (client *Client) GetMarginAccountsAvailabilityCtx(ctx context.Context) (GetMarginAccountsAvailabilityByInstrumentIdResult, error) {
+	var r GetMarginAccountsAvailabilityByInstrumentIdResult
 
-	if _, _, err := client.Request(GET, MARGIN_ACCOUNTS_AVAILABILITY, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, MARGIN_ACCOUNTS_AVAILABILITY, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -85,11 +99,15 @@ HTTP请求
 GET /api/margin/v3/accounts/<instrument_id>/availability
 */
 func (client *Client) GetMarginAccountsAvailabilityByInstrumentId(instrumentId string) (GetMarginAccountsAvailabilityByInstrumentIdResult, error) {
+	return client.GetMarginAccountsAvailabilityByInstrumentIdCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetMarginAccountsAvailabilityByInstrumentIdCtx(ctx context.Context, instrumentId string) (GetMarginAccountsAvailabilityByInstrumentIdResult, error) {
 	var r GetMarginAccountsAvailabilityByInstrumentIdResult
 
 	uri := GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_AVAILABILITY, instrumentId)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -103,17 +121,18 @@ func (client *Client) GetMarginAccountsAvailabilityByInstrumentId(instrumentId s
 HTTP请求
 GET /api/margin/v3/accounts/borrowed
 */
-func (client *Client) GetMarginAccountsBorrowed(optionalParams *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetMarginAccountsBorrowed(params *MarginBorrowedParams) (GetMarginAccountsBorrowedByInstrumentIdResult, error) {
+	return client.GetMarginAccountsBorrowedCtx(context.Background(), params)
+}
 
-	uri := MARGIN_ACCOUNTS_BORROWED
-	if optionalParams != nil {
-		uri = BuildParams(uri, *optionalParams)
-	}
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+func (client *Client) GetMarginAccountsBorrowedCtx(ctx context.Context, params *MarginBorrowedParams) (GetMarginAccountsBorrowedByInstrumentIdResult, error) {
+	var r GetMarginAccountsBorrowedByInstrumentIdResult
+
+	uri := pageUri(MARGIN_ACCOUNTS_BORROWED, params.params())
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -124,15 +143,16 @@ func (client *Client) GetMarginAccountsBorrowed(optionalParams *map[string]strin
 HTTP请求
 GET /api/margin/v3/accounts/<instrument_id>/borrowed
 */
-func (client *Client) GetMarginAccountsBorrowedByInstrumentId(instrumentId string, optionalParams *map[string]string) (GetMarginAccountsBorrowedByInstrumentIdResult, error) {
+func (client *Client) GetMarginAccountsBorrowedByInstrumentId(instrumentId string, params *MarginBorrowedParams) (GetMarginAccountsBorrowedByInstrumentIdResult, error) {
+	return client.GetMarginAccountsBorrowedByInstrumentIdCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetMarginAccountsBorrowedByInstrumentIdCtx(ctx context.Context, instrumentId string, params *MarginBorrowedParams) (GetMarginAccountsBorrowedByInstrumentIdResult, error) {
 	var r GetMarginAccountsBorrowedByInstrumentIdResult
 
-	uri := GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_BORROWED, instrumentId)
-	if optionalParams != nil && len(*optionalParams) > 0 {
-		uri = BuildParams(uri, *optionalParams)
-	}
+	uri := pageUri(GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_BORROWED, instrumentId), params.params())
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -145,21 +165,22 @@ func (client *Client) GetMarginAccountsBorrowedByInstrumentId(instrumentId strin
 限速规则：20次/2s
 HTTP请求
 GET /api/margin/v3/orders
+
+state: -2:失败 -1:撤单成功 0:等待成交 1:部分成交 2:完全成交 3:下单中 4:撤单中 6:未完成（等待成交+部分成交） 7:已完成（撤单成功+完全成交）
 */
-func (client *Client) GetMarginOrders(instrumentId string, optionalParams map[string]string) ([]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-	fullParams := NewParams()
-	fullParams["instrument_id"] = instrumentId
+func (client *Client) GetMarginOrders(instrumentId, state string, page *CursorPage) ([]MarginGetOrderResult, error) {
+	return client.GetMarginOrdersCtx(context.Background(), instrumentId, state, page)
+}
 
-	if optionalParams != nil && len(optionalParams) > 0 {
-		for k, v := range optionalParams {
-			fullParams[k] = v
-		}
-	}
+func (client *Client) GetMarginOrdersCtx(ctx context.Context, instrumentId, state string, page *CursorPage) ([]MarginGetOrderResult, error) {
+	var r []MarginGetOrderResult
+	fullParams := page.addTo(nil)
+	fullParams["instrument_id"] = instrumentId
+	fullParams["state"] = state
 
 	uri := BuildParams(MARGIN_ORDERS, fullParams)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -176,6 +197,10 @@ GET /api/margin/v3/orders/<order_id>
 GET /api/margin/v3/orders/<client_oid>
 */
 func (client *Client) GetMarginOrdersById(instrumentId, orderOrClientId string) (MarginGetOrderResult, error) {
+	return client.GetMarginOrdersByIdCtx(context.Background(), instrumentId, orderOrClientId)
+}
+
+func (client *Client) GetMarginOrdersByIdCtx(ctx context.Context, instrumentId, orderOrClientId string) (MarginGetOrderResult, error) {
 	var r MarginGetOrderResult
 	uri := strings.Replace(MARGIN_ORDERS_BY_ID, "{order_client_id}", orderOrClientId, -1)
 
@@ -183,7 +208,7 @@ func (client *Client) GetMarginOrdersById(instrumentId, orderOrClientId string)
 	fullParams["instrument_id"] = instrumentId
 	uri = BuildParams(uri, fullParams)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return r, err
 	}
 	return r, nil
@@ -198,15 +223,20 @@ func (client *Client) GetMarginOrdersById(instrumentId, orderOrClientId string)
 HTTP请求
 GET /api/margin/v3/orders_pending
 */
-func (client *Client) GetMarginOrdersPending(optionalParams map[string]string) ([]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetMarginOrdersPending(instrumentId string, page *CursorPage) ([]MarginGetOrderResult, error) {
+	return client.GetMarginOrdersPendingCtx(context.Background(), instrumentId, page)
+}
 
-	uri := MARGIN_ORDERS_PENDING
-	if optionalParams != nil && len(optionalParams) > 0 {
-		uri = BuildParams(uri, optionalParams)
+func (client *Client) GetMarginOrdersPendingCtx(ctx context.Context, instrumentId string, page *CursorPage) ([]MarginGetOrderResult, error) {
+	var r []MarginGetOrderResult
+
+	fullParams := page.addTo(nil)
+	if instrumentId != "" {
+		fullParams["instrument_id"] = instrumentId
 	}
+	uri := pageUri(MARGIN_ORDERS_PENDING, fullParams)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -220,24 +250,22 @@ func (client *Client) GetMarginOrdersPending(optionalParams map[string]string) (
 HTTP请求
 GET /api/margin/v3/fills
 */
-func (client *Client) GetMarginFills(instrumentId, orderId string, optionalParams map[string]string) ([]FillItem, error) {
+func (client *Client) GetMarginFills(instrumentId, orderId string, page *CursorPage) ([]FillItem, error) {
+	return client.GetMarginFillsCtx(context.Background(), instrumentId, orderId, page)
+}
+
+func (client *Client) GetMarginFillsCtx(ctx context.Context, instrumentId, orderId string, page *CursorPage) ([]FillItem, error) {
 	r := []FillItem{}
 
-	fullParams := NewParams()
+	fullParams := page.addTo(nil)
 	fullParams["instrument_id"] = instrumentId
-	fullParams["order_id"] = orderId
-
-	if optionalParams != nil && len(optionalParams) > 0 {
-		for k, v := range optionalParams {
-			if v != "" && len(v) > 0 {
-				fullParams[k] = v
-			}
-		}
+	if orderId != "" {
+		fullParams["order_id"] = orderId
 	}
 
 	uri := BuildParams(MARGIN_FILLS, fullParams)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -252,6 +280,10 @@ HTTP请求
 POST /api/margin/v3/accounts/borrow
 */
 func (client *Client) PostMarginAccountsBorrow(instrumentId, currency, amount string) ([]byte, PostMarginAccountsBorrowResult, error) {
+	return client.PostMarginAccountsBorrowCtx(context.Background(), instrumentId, currency, amount)
+}
+
+func (client *Client) PostMarginAccountsBorrowCtx(ctx context.Context, instrumentId, currency, amount string) ([]byte, PostMarginAccountsBorrowResult, error) {
 	var r PostMarginAccountsBorrowResult
 	var respBody []byte
 
@@ -260,7 +292,7 @@ func (client *Client) PostMarginAccountsBorrow(instrumentId, currency, amount st
 	bodyParams["currency"] = currency
 	bodyParams["amount"] = amount
 
-	respBody, _, err := client.Request(POST, MARGIN_ACCOUNTS_BORROW, bodyParams, &r)
+	respBody, _, err := client.RequestCtx(ctx, POST, MARGIN_ACCOUNTS_BORROW, bodyParams, &r)
 	return respBody, r, err
 }
 
@@ -273,6 +305,10 @@ HTTP请求
 POST /api/margin/v3/accounts/repayment
 */
 func (client *Client) PostMarginAccountsRepayment(instrumentId, currency, amount string, optionalBorrowId *string) ([]byte, PostMarginAccountsRepaymentResult, error) {
+	return client.PostMarginAccountsRepaymentCtx(context.Background(), instrumentId, currency, amount, optionalBorrowId)
+}
+
+func (client *Client) PostMarginAccountsRepaymentCtx(ctx context.Context, instrumentId, currency, amount string, optionalBorrowId *string) ([]byte, PostMarginAccountsRepaymentResult, error) {
 	var r PostMarginAccountsRepaymentResult
 	var respBody []byte
 
@@ -285,7 +321,7 @@ func (client *Client) PostMarginAccountsRepayment(instrumentId, currency, amount
 		bodyParams["borrow_id"] = *optionalBorrowId
 	}
 
-	respBody, _, err := client.Request(POST, MARGIN_ACCOUNTS_REPAYMENT, bodyParams, &r)
+	respBody, _, err := client.RequestCtx(ctx, POST, MARGIN_ACCOUNTS_REPAYMENT, bodyParams, &r)
 	return respBody, r, err
 }
 
@@ -297,36 +333,31 @@ OKEx API提供limit和market两种下单模式。只有当您的账户有足够
 HTTP请求
 POST /api/margin/v3/orders
 */
-func (client *Client) PostMarginOrders(side, instrument_id string, optionalOrderInfo map[string]string) ([]byte, MarginNewOrderResult, error) {
+func (client *Client) PostMarginOrders(order SpotOrderItem) ([]byte, MarginNewOrderResult, error) {
+	return client.PostMarginOrdersCtx(context.Background(), order)
+}
+
+// MarginTrading of the order is set to 2
+func (client *Client) PostMarginOrdersCtx(ctx context.Context, order SpotOrderItem) ([]byte, MarginNewOrderResult, error) {
 	var r MarginNewOrderResult
 	var respBody []byte
-
-	postParams := NewParams()
-	postParams["side"] = side
-	postParams["instrument_id"] = instrument_id
-
-	if optionalOrderInfo != nil && len(optionalOrderInfo) > 0 {
-		if v, ok := optionalOrderInfo["client_oid"]; ok {
-			postParams["client_oid"] = v
-		}
-		postParams["type"] = optionalOrderInfo["type"]
-		postParams["margin_trading"] = optionalOrderInfo["margin_trading"]
-
-		if postParams["type"] == "limit" {
-			postParams["price"] = optionalOrderInfo["price"]
-			postParams["size"] = optionalOrderInfo["size"]
-		} else if postParams["type"] == "market" {
-			if v, ok := optionalOrderInfo["size"]; ok {
-				postParams["size"] = v
-			}
-			if v, ok := optionalOrderInfo["notional"]; ok {
-				postParams["notional"] = v
-			}
+	order.MarginTrading = "2"
+
+	clientOid := order.ClientOid
+	err := client.placeOrderWithRetry(ctx, clientOid, func() (err error) {
+		r = MarginNewOrderResult{}
+		respBody, _, err = client.RequestCtx(ctx, POST, MARGIN_ORDERS, order, &r)
+		return err
+	}, func() (bool, error) {
+		placed, err := client.GetMarginOrdersByIdCtx(ctx, order.InstrumentId, clientOid)
+		if err != nil || placed.OrderID == "" {
+			return false, err
 		}
-	}
-
-	var err error
-	if respBody, _, err = client.Request(POST, MARGIN_ORDERS, postParams, &r); err != nil {
+		r = MarginNewOrderResult{ClientOid: clientOid, OrderID: placed.OrderID, Result: true}
+		respBody = nil
+		return true, nil
+	})
+	if err != nil {
 		return respBody, r, err
 	}
 	return respBody, r, nil
@@ -340,14 +371,24 @@ func (client *Client) PostMarginOrders(side, instrument_id string, optionalOrder
 HTTP请求
 POST /api/spot/v3/batch_orders
 */
-func (client *Client) PostMarginBatchOrders(orderInfos *[]map[string]string) ([]byte, *map[string]interface{}, error) {
-	r := map[string]interface{}{}
+func (client *Client) PostMarginBatchOrders(orders []SpotOrderItem) ([]byte, MarginBatchOrdersResult, error) {
+	return client.PostMarginBatchOrdersCtx(context.Background(), orders)
+}
+
+func (client *Client) PostMarginBatchOrdersCtx(ctx context.Context, orders []SpotOrderItem) ([]byte, MarginBatchOrdersResult, error) {
+	var r MarginBatchOrdersResult
+	marginOrders := make([]SpotOrderItem, len(orders))
+	for i, order := range orders {
+		order.MarginTrading = "2"
+		marginOrders[i] = order
+	}
+
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, MARGIN_BATCH_ORDERS, orderInfos, &r); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, MARGIN_BATCH_ORDERS, marginOrders, &r); err != nil {
 		return respBody, nil, err
 	}
-	return respBody, &r, nil
+	return respBody, r, nil
 }
 
 /*
@@ -361,6 +402,10 @@ POST /api/margin/v3/cancel_orders/<order_id>
 POST /api/margin/v3/cancel_orders/<client_oid>
 */
 func (client *Client) PostMarginCancelOrdersById(instrumentId, orderOrClientId string) ([]byte, MarginNewOrderResult, error) {
+	return client.PostMarginCancelOrdersByIdCtx(context.Background(), instrumentId, orderOrClientId)
+}
+
+func (client *Client) PostMarginCancelOrdersByIdCtx(ctx context.Context, instrumentId, orderOrClientId string) ([]byte, MarginNewOrderResult, error) {
 	var r MarginNewOrderResult
 	var respBody []byte
 	uri := strings.Replace(MARGIN_CANCEL_ORDERS_BY_ID, "{order_client_id}", orderOrClientId, -1)
@@ -370,7 +415,7 @@ func (client *Client) PostMarginCancelOrdersById(instrumentId, orderOrClientId s
 	uri = BuildParams(uri, fullParams)
 
 	var err error
-	if respBody, _, err = client.Request(POST, uri, fullParams, &r); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, uri, SpotCancelOrdersItem{InstrumentId: instrumentId}, &r); err != nil {
 		return respBody, r, err
 	}
 	return respBody, r, nil
@@ -384,14 +429,18 @@ func (client *Client) PostMarginCancelOrdersById(instrumentId, orderOrClientId s
 HTTP请求
 POST /api/margin/v3/cancel_batch_orders
 */
-func (client *Client) PostMarginCancelBatchOrders(orderInfos *[]map[string]string) ([]byte, *map[string]interface{}, error) {
-	r := map[string]interface{}{}
+func (client *Client) PostMarginCancelBatchOrders(orders []SpotCancelOrdersItem) ([]byte, MarginBatchOrdersResult, error) {
+	return client.PostMarginCancelBatchOrdersCtx(context.Background(), orders)
+}
+
+func (client *Client) PostMarginCancelBatchOrdersCtx(ctx context.Context, orders []SpotCancelOrdersItem) ([]byte, MarginBatchOrdersResult, error) {
+	var r MarginBatchOrdersResult
 	var respBody []byte
 	var err error
 
-	if respBody, _, err = client.Request(POST, MARGIN_CANCEL_BATCH_ORDERS, *orderInfos, &r); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, MARGIN_CANCEL_BATCH_ORDERS, orders, &r); err != nil {
 		return respBody, nil, err
 	}
 
-	return respBody, &r, nil
+	return respBody, r, nil
 }
@@ -3,14 +3,14 @@ package okex
 import "time"
 
 type GetMarginAccountsByInstrumentItem struct {
-	Available   float64 `json:"available,string"`    // 可用于交易的数量
-	Balance     float64 `json:"balance,string"`      // 余额
-	Borrowed    float64 `json:"borrowed,string"`     // 已借币（已借未还的部分）
-	CanWithdraw float64 `json:"can_withdraw,string"` // 可划转数量
-	Frozen      float64 `json:"frozen,string"`
-	Hold        float64 `json:"hold,string"` // 冻结（不可用）
-	Holds       float64 `json:"holds,string"`
-	LendingFee  float64 `json:"lending_fee,string"` // 利息（未还的利息）
+	Available   Decimal `json:"available"`    // 可用于交易的数量
+	Balance     Decimal `json:"balance"`      // 余额
+	Borrowed    Decimal `json:"borrowed"`     // 已借币（已借未还的部分）
+	CanWithdraw Decimal `json:"can_withdraw"` // 可划转数量
+	Frozen      Decimal `json:"frozen"`
+	Hold        Decimal `json:"hold"` // 冻结（不可用）
+	Holds       Decimal `json:"holds"`
+	LendingFee  Decimal `json:"lending_fee"` // 利息（未还的利息）
 }
 
 type GetMarginAccountsByInstrumentResult struct {
@@ -22,9 +22,9 @@ type GetMarginAccountsByInstrumentResult struct {
 	CurrencyEOS      GetMarginAccountsByInstrumentItem `json:"currency:EOS"`
 	CurrencyXRP      GetMarginAccountsByInstrumentItem `json:"currency:XRP"`
 	CurrencyUSDT     GetMarginAccountsByInstrumentItem `json:"currency:USDT"`
-	LiquidationPrice float64                           `json:"liquidation_price,string"`
-	MarginRatio      string                            `json:"margin_ratio"`
-	RiskRate         string                            `json:"risk_rate"`
+	LiquidationPrice Decimal                           `json:"liquidation_price"`
+	MarginRatio      Decimal                           `json:"margin_ratio"`
+	RiskRate         Decimal                           `json:"risk_rate"`
 }
 
 type PostMarginAccountsBorrowResult struct {
@@ -34,30 +34,30 @@ type PostMarginAccountsBorrowResult struct {
 }
 
 type GetMarginAccountsBorrowedByInstrumentIdItem struct {
-	Amount           float64   `json:"amount,string"`
+	Amount           Decimal   `json:"amount"`
 	BorrowID         string    `json:"borrow_id"`
 	CreatedAt        time.Time `json:"created_at"`
 	Currency         string    `json:"currency"`
 	ForceRepayTime   time.Time `json:"force_repay_time"`
 	InstrumentID     string    `json:"instrument_id"`
-	Interest         float64   `json:"interest,string"`
+	Interest         Decimal   `json:"interest"`
 	LastInterestTime time.Time `json:"last_interest_time"`
-	PaidInterest     float64   `json:"paid_interest,string"`
+	PaidInterest     Decimal   `json:"paid_interest"`
 	ProductID        string    `json:"product_id"`
-	Rate             float64   `json:"rate,string"`
-	RepayAmount      float64   `json:"repay_amount,string"`
-	RepayInterest    float64   `json:"repay_interest,string"`
-	ReturnedAmount   float64   `json:"returned_amount,string"`
+	Rate             Decimal   `json:"rate"`
+	RepayAmount      Decimal   `json:"repay_amount"`
+	RepayInterest    Decimal   `json:"repay_interest"`
+	ReturnedAmount   Decimal   `json:"returned_amount"`
 	Timestamp        time.Time `json:"timestamp"`
 }
 
 type GetMarginAccountsBorrowedByInstrumentIdResult []GetMarginAccountsBorrowedByInstrumentIdItem
 
 type GetMarginAccountsAvailabilityByInstrumentIdItem struct {
-	Available     float64 `json:"available,string"` // 当前最大可借
-	Leverage      float64 `json:"leverage,string"`  // 最大杠杆倍数
-	LeverageRatio float64 `json:"leverage_ratio,string"`
-	Rate          float64 `json:"rate,string"` // 借币利率
+	Available     Decimal `json:"available"` // 当前最大可借
+	Leverage      Decimal `json:"leverage"`  // 最大杠杆倍数
+	LeverageRatio Decimal `json:"leverage_ratio"`
+	Rate          Decimal `json:"rate"` // 借币利率
 }
 
 type GetMarginAccountsAvailabilityByInstrumentIdResult []struct {
@@ -6,24 +6,24 @@ import (
 )
 
 type MarginCurrency struct {
-	Available   string `json:"available"`
-	Balance     string `json:"balance"`
-	Borrowed    string `json:"borrowed"`
-	CanWithdraw string `json:"can_withdraw"`
-	Frozen      string `json:"frozen"`
-	Hold        string `json:"hold"`
-	Holds       string `json:"holds"`
-	LendingFee  string `json:"lending_fee"`
+	Available   Decimal `json:"available"`
+	Balance     Decimal `json:"balance"`
+	Borrowed    Decimal `json:"borrowed"`
+	CanWithdraw Decimal `json:"can_withdraw"`
+	Frozen      Decimal `json:"frozen"`
+	Hold        Decimal `json:"hold"`
+	Holds       Decimal `json:"holds"`
+	LendingFee  Decimal `json:"lending_fee"`
 }
 
 type GetMarginAccountsItem struct {
 	CurrencyBTC      MarginCurrency `json:"currency:BTC,omitempty"`
 	CurrencyUSDT     MarginCurrency `json:"currency:USDT,omitempty"`
 	InstrumentID     string         `json:"instrument_id"`
-	LiquidationPrice string         `json:"liquidation_price"`
-	MarginRatio      string         `json:"margin_ratio"`
+	LiquidationPrice Decimal        `json:"liquidation_price"`
+	MarginRatio      Decimal        `json:"margin_ratio"`
 	ProductID        string         `json:"product_id"`
-	RiskRate         string         `json:"risk_rate"`
+	RiskRate         Decimal        `json:"risk_rate"`
 	CurrencyLTC      MarginCurrency `json:"currency:LTC,omitempty"`
 	CurrencyETH      MarginCurrency `json:"currency:ETH,omitempty"`
 	CurrencyETC      MarginCurrency `json:"currency:ETC,omitempty"`
@@ -53,9 +53,9 @@ type MarginGetOrderResult struct {
 	CreatedAt      time.Time    `json:"created_at"`
 	FilledNotional sjson.Number `json:"filled_notional"`
 	FilledSize     sjson.Number `json:"filled_size"`
-	Funds          string       `json:"funds"`
+	Funds          Decimal      `json:"funds"`
 	InstrumentID   string       `json:"instrument_id"`
-	Notional       string       `json:"notional"`
+	Notional       Decimal      `json:"notional"`
 	OrderID        string       `json:"order_id"`
 	OrderType      sjson.Number `json:"order_type"` /*int*/
 	Price          sjson.Number `json:"price"`
@@ -71,20 +71,25 @@ type MarginGetOrderResult struct {
 
 type FillItem struct {
 	CreatedAt    string  `json:"created_at"`
+	Currency     string  `json:"currency"`
 	ExecType     string  `json:"exec_type"`
-	Fee          float64 `json:"fee,string"`
+	Fee          Decimal `json:"fee"`
 	InstrumentID string  `json:"instrument_id"`
 	LedgerID     string  `json:"ledger_id"`
 	Liquidity    string  `json:"liquidity"`
 	OrderID      string  `json:"order_id"`
-	Price        string  `json:"price"`
+	Price        Decimal `json:"price"`
 	ProductID    string  `json:"product_id"`
 	Side         string  `json:"side"`
-	Size         float64 `json:"size,string"`
+	Size         Decimal `json:"size"`
 	Timestamp    string  `json:"timestamp"`
+	TradeID      string  `json:"trade_id"`
 }
 
 func (r *MarginGetOrderResult) GetState() int64 {
 	i, _ := r.State.Int64()
 	return i
 }
+
+// The placed or canceled orders by instrument id (lower case), eg: "btc-usdt"
+type MarginBatchOrdersResult map[string][]MarginNewOrderResult
@@ -0,0 +1,86 @@
+package okex
+
+/*
+ Request/response middleware around Client.Request.
+
+ Hooks run for every attempt of a rest request. BeforeSign and AfterSign run
+ in registration order, AfterResponse in reverse order, so the first
+ registered middleware wraps all others.
+*/
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HookRequest is the rest request passed through the middleware chain.
+type HookRequest struct {
+	Method string
+	// Request path including the query string, eg: /api/swap/v3/orders/BTC-USD-SWAP?status=2
+	Path string
+	// Json body, empty for GET requests
+	Body  string
+	Start time.Time
+}
+
+type Middleware struct {
+	// Called before the request is signed. Path and Body may be changed, an error aborts the request.
+	BeforeSign func(ctx context.Context, req *HookRequest) error
+	// Called with the signed http request, headers added here are not signed.
+	// A non-nil response short-circuits the request: it is processed as if it was returned by the server,
+	// a nil Body or Header is read as empty. A response returned with an error is not read, its Body is closed.
+	AfterSign func(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error)
+	// Called with the outcome of the request, err is eg. an *APIError.
+	// The returned error replaces err.
+	AfterResponse func(ctx context.Context, req *HookRequest, resp *http.Response, body []byte, err error) error
+}
+
+/*
+ Append middlewares to the client's chain. Not safe to call concurrently with requests.
+*/
+func (client *Client) Use(middlewares ...Middleware) {
+	client.middlewares = append(client.middlewares, middlewares...)
+}
+
+func (client *Client) beforeSign(ctx context.Context, req *HookRequest) error {
+	for _, m := range client.middlewares {
+		if m.BeforeSign == nil {
+			continue
+		}
+		if err := m.BeforeSign(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *Client) afterSign(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error) {
+	for _, m := range client.middlewares {
+		if m.AfterSign == nil {
+			continue
+		}
+		resp, err := m.AfterSign(ctx, req, httpReq)
+		if resp != nil {
+			if resp.Body == nil {
+				resp.Body = http.NoBody
+			}
+			if resp.Header == nil {
+				resp.Header = make(http.Header)
+			}
+		}
+		if err != nil || resp != nil {
+			return resp, err
+		}
+	}
+	return nil, nil
+}
+
+func (client *Client) afterResponse(ctx context.Context, req *HookRequest, resp *http.Response, body []byte, err error) error {
+	for i := len(client.middlewares) - 1; i >= 0; i-- {
+		if m := client.middlewares[i]; m.AfterResponse != nil {
+			err = m.AfterResponse(ctx, req, resp, body, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package okex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_Chain(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "trace-1", r.Header.Get("X-Trace-Id"))
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+
+	var calls []string
+	c.Use(Middleware{
+		BeforeSign: func(ctx context.Context, req *HookRequest) error {
+			calls = append(calls, "before-1")
+			return nil
+		},
+		AfterSign: func(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error) {
+			calls = append(calls, "after-sign-1")
+			httpReq.Header.Set("X-Trace-Id", "trace-1")
+			return nil, nil
+		},
+		AfterResponse: func(ctx context.Context, req *HookRequest, resp *http.Response, body []byte, err error) error {
+			calls = append(calls, "after-response-1")
+			return err
+		},
+	}, Middleware{
+		BeforeSign: func(ctx context.Context, req *HookRequest) error {
+			calls = append(calls, "before-2")
+			return nil
+		},
+		AfterResponse: func(ctx context.Context, req *HookRequest, resp *http.Response, body []byte, err error) error {
+			calls = append(calls, "after-response-2")
+			assert.Equal(t, GET, req.Method)
+			assert.Equal(t, OKEX_TIME_URI, req.Path)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Contains(t, string(body), "1586687059.913")
+			assert.False(t, req.Start.IsZero())
+			return err
+		},
+	})
+
+	_, err := c.GetServerTime()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"before-1", "before-2", "after-sign-1", "after-response-2", "after-response-1"}, calls)
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	defer server.Close()
+
+	c.Use(Middleware{
+		AfterSign: func(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error) {
+			assert.NotEmpty(t, httpReq.Header.Get(OK_ACCESS_SIGN))
+			body := `{"client_oid":"oid123","order_id":"64-2a-26132f931-3","result":"true"}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	})
+
+	_, result, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{ClientOid: "oid123", Price: "6000", Size: "1", Type: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "64-2a-26132f931-3", result.OrderId)
+}
+
+func TestMiddleware_ReplaceError(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer server.Close()
+
+	audit := errors.New("audited")
+	c.Use(Middleware{
+		AfterResponse: func(ctx context.Context, req *HookRequest, resp *http.Response, body []byte, err error) error {
+			assert.True(t, errors.Is(err, ErrServer))
+			return audit
+		},
+	})
+
+	_, err := c.GetServerTime()
+	assert.Equal(t, audit, err)
+}
+
+func TestMiddleware_ShortCircuitNilBody(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	defer server.Close()
+
+	c.Use(Middleware{
+		AfterSign: func(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, nil
+		},
+	})
+
+	_, err := c.GetServerTime()
+	assert.True(t, errors.Is(err, ErrServer))
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestMiddleware_ShortCircuitErrorClosesBody(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	defer server.Close()
+
+	body := &closeRecorder{Reader: bytes.NewBufferString(`{}`)}
+	refused := errors.New("refused")
+	c.Use(Middleware{
+		AfterSign: func(ctx context.Context, req *HookRequest, httpReq *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: body}, refused
+		},
+	})
+
+	_, err := c.GetServerTime()
+	assert.True(t, errors.Is(err, refused))
+	assert.True(t, body.closed)
+}
+
+func TestMiddleware_RateLimitRewrittenPath(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	c.limiter = newRateLimiter(&RateLimitConfig{
+		Mode:            RateLimitFailFast,
+		Limits:          map[string]RateLimit{SPOT_INSTRUMENTS: {Requests: 1, Per: time.Minute}},
+		DisableDefaults: true,
+	})
+	c.Use(Middleware{
+		BeforeSign: func(ctx context.Context, req *HookRequest) error {
+			req.Path = SPOT_INSTRUMENTS
+			return nil
+		},
+	})
+
+	_, err := c.GetServerTime()
+	assert.Nil(t, err)
+	_, err = c.GetServerTime()
+	assert.True(t, errors.Is(err, ErrRateLimit))
+}
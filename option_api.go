@@ -0,0 +1,363 @@
+package okex
+
+/*
+ OKEX Option Api
+
+ Options are grouped by underlying, eg: BTC-USD. Instrument ids carry the
+ delivery date, strike and type, eg: BTC-USD-200925-9000-C.
+*/
+
+import (
+	"context"
+	"strings"
+)
+
+/*
+获取标的指数
+获取期权交易支持的标的指数列表。
+
+HTTP请求
+GET /api/option/v3/underlying
+*/
+func (client *Client) GetOptionUnderlyings() ([]string, error) {
+	return client.GetOptionUnderlyingsCtx(context.Background())
+}
+
+func (client *Client) GetOptionUnderlyingsCtx(ctx context.Context) ([]string, error) {
+	var r []string
+	if _, _, err := client.RequestCtx(ctx, GET, OPTION_UNDERLYING, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+获取期权合约
+获取标的的所有期权合约，delivery 为空时返回所有交割日期的合约，例如 200925。
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/instruments/<underlying>
+*/
+func (client *Client) GetOptionInstruments(underlying, delivery string) ([]OptionInstrument, error) {
+	return client.GetOptionInstrumentsCtx(context.Background(), underlying, delivery)
+}
+
+func (client *Client) GetOptionInstrumentsCtx(ctx context.Context, underlying, delivery string) ([]OptionInstrument, error) {
+	var r []OptionInstrument
+	params := NewParams()
+	if delivery != "" {
+		params["delivery"] = delivery
+	}
+	uri := pageUri(GetUnderlyingUri(OPTION_INSTRUMENTS, underlying), params)
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+获取标的的所有期权合约详细定价，包括希腊字母和隐含波动率。
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/instruments/<underlying>/summary
+*/
+func (client *Client) GetOptionSummaries(underlying, delivery string) ([]OptionSummary, error) {
+	return client.GetOptionSummariesCtx(context.Background(), underlying, delivery)
+}
+
+func (client *Client) GetOptionSummariesCtx(ctx context.Context, underlying, delivery string) ([]OptionSummary, error) {
+	var r []OptionSummary
+	params := NewParams()
+	if delivery != "" {
+		params["delivery"] = delivery
+	}
+	uri := pageUri(GetUnderlyingUri(OPTION_INSTRUMENTS_SUMMARY, underlying), params)
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+获取单个期权合约详细定价
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/instruments/<underlying>/summary/<instrument_id>
+*/
+func (client *Client) GetOptionSummary(underlying, instrumentId string) (OptionSummary, error) {
+	return client.GetOptionSummaryCtx(context.Background(), underlying, instrumentId)
+}
+
+func (client *Client) GetOptionSummaryCtx(ctx context.Context, underlying, instrumentId string) (OptionSummary, error) {
+	var r OptionSummary
+	uri := GetInstrumentIdUri(GetUnderlyingUri(OPTION_INSTRUMENT_SUMMARY, underlying), instrumentId)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	return r, err
+}
+
+/*
+获取深度数据，size 最大200，0为默认值
+
+HTTP请求
+GET /api/option/v3/instruments/<instrument_id>/book
+*/
+func (client *Client) GetOptionBook(instrumentId string, size int) (OptionBook, error) {
+	return client.GetOptionBookCtx(context.Background(), instrumentId, size)
+}
+
+func (client *Client) GetOptionBookCtx(ctx context.Context, instrumentId string, size int) (OptionBook, error) {
+	var r OptionBook
+	params := NewParams()
+	if size > 0 {
+		params["size"] = Int2String(size)
+	}
+	uri := pageUri(GetInstrumentIdUri(OPTION_INSTRUMENT_BOOK, instrumentId), params)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	return r, err
+}
+
+/*
+获取某个期权合约的最新成交价、买一价、卖一价和24小时交易量
+
+HTTP请求
+GET /api/option/v3/instruments/<instrument_id>/ticker
+*/
+func (client *Client) GetOptionTicker(instrumentId string) (OptionTicker, error) {
+	return client.GetOptionTickerCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetOptionTickerCtx(ctx context.Context, instrumentId string) (OptionTicker, error) {
+	var r OptionTicker
+	_, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(OPTION_INSTRUMENT_TICKER, instrumentId), nil, &r)
+	return r, err
+}
+
+/*
+获取成交数据，最新的排在最前面
+
+HTTP请求
+GET /api/option/v3/instruments/<instrument_id>/trades
+*/
+func (client *Client) GetOptionTrades(instrumentId string, page *CursorPage) ([]OptionTrade, error) {
+	return client.GetOptionTradesCtx(context.Background(), instrumentId, page)
+}
+
+func (client *Client) GetOptionTradesCtx(ctx context.Context, instrumentId string, page *CursorPage) ([]OptionTrade, error) {
+	var r []OptionTrade
+	uri := pageUri(GetInstrumentIdUri(OPTION_INSTRUMENT_TRADES, instrumentId), page.addTo(nil))
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+获取K线数据
+
+HTTP请求
+GET /api/option/v3/instruments/<instrument_id>/candles
+*/
+func (client *Client) GetOptionCandles(instrumentId string, params *CandlesParams) ([]Candle, error) {
+	return client.GetOptionCandlesCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetOptionCandlesCtx(ctx context.Context, instrumentId string, params *CandlesParams) ([]Candle, error) {
+	var r []Candle
+	uri := pageUri(GetInstrumentIdUri(OPTION_INSTRUMENT_CANDLES, instrumentId), params.params())
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+下单
+Orders are not checked by the InstrumentRegistry of Client.ValidateOrdersWith,
+it does not load option instruments.
+
+限速规则：20次/2s
+HTTP请求
+POST /api/option/v3/order
+*/
+func (client *Client) PostOptionOrder(order OptionOrderParams) (OptionOrderResult, error) {
+	return client.PostOptionOrderCtx(context.Background(), order)
+}
+
+func (client *Client) PostOptionOrderCtx(ctx context.Context, order OptionOrderParams) (OptionOrderResult, error) {
+	var r OptionOrderResult
+	_, _, err := client.RequestCtx(ctx, POST, OPTION_ORDER, order, &r)
+	return r, err
+}
+
+/*
+批量下单，每次最多10个同一标的的订单
+
+限速规则：20次/2s
+HTTP请求
+POST /api/option/v3/batch_orders
+*/
+func (client *Client) PostOptionBatchOrders(orders OptionBatchOrdersParams) (OptionBatchOrdersResult, error) {
+	return client.PostOptionBatchOrdersCtx(context.Background(), orders)
+}
+
+func (client *Client) PostOptionBatchOrdersCtx(ctx context.Context, orders OptionBatchOrdersParams) (OptionBatchOrdersResult, error) {
+	var r OptionBatchOrdersResult
+	_, _, err := client.RequestCtx(ctx, POST, OPTION_BATCH_ORDERS, orders, &r)
+	return r, err
+}
+
+/*
+撤单，按 order_id 或 client_oid
+
+限速规则：20次/2s
+HTTP请求
+POST /api/option/v3/cancel_order/<underlying>/<order_id>
+or
+POST /api/option/v3/cancel_order/<underlying>/<client_oid>
+*/
+func (client *Client) CancelOptionOrder(underlying, orderOrClientId string) (OptionOrderResult, error) {
+	return client.CancelOptionOrderCtx(context.Background(), underlying, orderOrClientId)
+}
+
+func (client *Client) CancelOptionOrderCtx(ctx context.Context, underlying, orderOrClientId string) (OptionOrderResult, error) {
+	var r OptionOrderResult
+	uri := strings.Replace(GetUnderlyingUri(OPTION_CANCEL_ORDER, underlying), "{order_client_id}", orderOrClientId, -1)
+	_, _, err := client.RequestCtx(ctx, POST, uri, nil, &r)
+	return r, err
+}
+
+/*
+批量撤单，每次最多10个
+
+限速规则：20次/2s
+HTTP请求
+POST /api/option/v3/cancel_batch_orders/<underlying>
+*/
+func (client *Client) CancelOptionBatchOrders(underlying string, params OptionCancelBatchParams) (OptionBatchOrdersResult, error) {
+	return client.CancelOptionBatchOrdersCtx(context.Background(), underlying, params)
+}
+
+func (client *Client) CancelOptionBatchOrdersCtx(ctx context.Context, underlying string, params OptionCancelBatchParams) (OptionBatchOrdersResult, error) {
+	var r OptionBatchOrdersResult
+	_, _, err := client.RequestCtx(ctx, POST, GetUnderlyingUri(OPTION_CANCEL_BATCH_ORDERS, underlying), params, &r)
+	return r, err
+}
+
+/*
+获取单个订单信息
+
+HTTP请求
+GET /api/option/v3/orders/<underlying>/<order_id>
+or
+GET /api/option/v3/orders/<underlying>/<client_oid>
+*/
+func (client *Client) GetOptionOrder(underlying, orderOrClientId string) (OptionOrder, error) {
+	return client.GetOptionOrderCtx(context.Background(), underlying, orderOrClientId)
+}
+
+func (client *Client) GetOptionOrderCtx(ctx context.Context, underlying, orderOrClientId string) (OptionOrder, error) {
+	var r OptionOrder
+	uri := strings.Replace(GetUnderlyingUri(OPTION_ORDER_INFO, underlying), "{order_client_id}", orderOrClientId, -1)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	return r, err
+}
+
+/*
+获取订单列表，按时间倒序，params.State 必填
+
+HTTP请求
+GET /api/option/v3/orders/<underlying>
+*/
+func (client *Client) GetOptionOrders(underlying string, params *OptionOrdersParams) ([]OptionOrder, error) {
+	return client.GetOptionOrdersCtx(context.Background(), underlying, params)
+}
+
+func (client *Client) GetOptionOrdersCtx(ctx context.Context, underlying string, params *OptionOrdersParams) ([]OptionOrder, error) {
+	var r OptionOrdersResult
+	uri := pageUri(GetUnderlyingUri(OPTION_ORDERS, underlying), params.params())
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r.OrderInfo, nil
+}
+
+/*
+获取成交明细，按时间倒序
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/fills/<underlying>
+*/
+func (client *Client) GetOptionFills(underlying string, params *OptionFillsParams) ([]OptionFill, error) {
+	return client.GetOptionFillsCtx(context.Background(), underlying, params)
+}
+
+func (client *Client) GetOptionFillsCtx(ctx context.Context, underlying string, params *OptionFillsParams) ([]OptionFill, error) {
+	var r []OptionFill
+	uri := pageUri(GetUnderlyingUri(OPTION_FILLS, underlying), params.params())
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+/*
+获取标的的持仓信息，instrumentId 为空时返回所有合约的持仓
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/<underlying>/position
+*/
+func (client *Client) GetOptionPosition(underlying, instrumentId string) (OptionPosition, error) {
+	return client.GetOptionPositionCtx(context.Background(), underlying, instrumentId)
+}
+
+func (client *Client) GetOptionPositionCtx(ctx context.Context, underlying, instrumentId string) (OptionPosition, error) {
+	var r OptionPosition
+	params := NewParams()
+	if instrumentId != "" {
+		params["instrument_id"] = instrumentId
+	}
+	uri := pageUri(GetUnderlyingUri(OPTION_POSITION, underlying), params)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	return r, err
+}
+
+/*
+获取标的的期权账户信息
+
+限速规则：20次/2s
+HTTP请求
+GET /api/option/v3/accounts/<underlying>
+*/
+func (client *Client) GetOptionAccount(underlying string) (OptionAccount, error) {
+	return client.GetOptionAccountCtx(context.Background(), underlying)
+}
+
+func (client *Client) GetOptionAccountCtx(ctx context.Context, underlying string) (OptionAccount, error) {
+	var r OptionAccount
+	_, _, err := client.RequestCtx(ctx, GET, GetUnderlyingUri(OPTION_ACCOUNT, underlying), nil, &r)
+	return r, err
+}
+
+/*
+账单流水查询，按时间倒序
+
+HTTP请求
+GET /api/option/v3/accounts/<underlying>/ledger
+*/
+func (client *Client) GetOptionLedger(underlying string, page *CursorPage) ([]OptionLedger, error) {
+	return client.GetOptionLedgerCtx(context.Background(), underlying, page)
+}
+
+func (client *Client) GetOptionLedgerCtx(ctx context.Context, underlying string, page *CursorPage) ([]OptionLedger, error) {
+	var r []OptionLedger
+	uri := pageUri(GetUnderlyingUri(OPTION_ACCOUNT_LEDGER, underlying), page.addTo(nil))
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
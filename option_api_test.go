@@ -0,0 +1,104 @@
+package okex
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestClient_OptionMarketData(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/option/v3/instruments/BTC-USD":
+			assert.Equal(t, "200925", r.URL.Query().Get("delivery"))
+			w.Write([]byte(`[{"instrument_id":"BTC-USD-200925-9000-C","underlying":"BTC-USD","settlement_currency":"BTC",` +
+				`"contract_val":"0.1000","option_type":"C","strike":"9000","tick_size":"0.0005","lot_size":"1.0000",` +
+				`"listing":"2020-09-11T08:00:00.000Z","delivery":"2020-09-25T08:00:00.000Z","state":"2",` +
+				`"trading_start_time":"2020-09-11T08:00:00.000Z","timestamp":"2020-09-20T08:00:00.000Z"}]`))
+		case "/api/option/v3/instruments/BTC-USD/summary/BTC-USD-200925-9000-C":
+			w.Write([]byte(`{"instrument_id":"BTC-USD-200925-9000-C","underlying":"BTC-USD","best_ask":"0.1205","best_bid":"0.119",` +
+				`"delta":"0.9","gamma":"0.8","theta":"-0.001","vega":"0.0002","delta_bs":"0.95","mark_vol":"0.6129",` +
+				`"bid_vol":"0.5","ask_vol":"0.7","mark_price":"0.12","timestamp":"2020-09-20T08:00:00.000Z"}`))
+		case "/api/option/v3/instruments/BTC-USD-200925-9000-C/candles":
+			assert.Equal(t, "300", r.URL.Query().Get("granularity"))
+			assert.Equal(t, "2020-09-20T08:00:00.000Z", r.URL.Query().Get("end"))
+			assert.Equal(t, "", r.URL.Query().Get("start"))
+			w.Write([]byte(`[["2020-09-20T07:55:00.000Z","0.12","0.125","0.118","0.121","10"]]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	instruments, err := c.GetOptionInstruments("BTC-USD", "200925")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(instruments))
+	assert.Equal(t, OptionTypeCall, instruments[0].OptionType)
+	assert.Equal(t, Decimal("9000"), instruments[0].Strike)
+	assert.Equal(t, 25, instruments[0].Delivery.Day())
+
+	summary, err := c.GetOptionSummary("BTC-USD", "BTC-USD-200925-9000-C")
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.9"), summary.Delta)
+	assert.Equal(t, Decimal("0.95"), summary.DeltaBS)
+	assert.Equal(t, Decimal("0.6129"), summary.MarkVol)
+
+	candles, err := c.GetOptionCandles("BTC-USD-200925-9000-C", &CandlesParams{Granularity: CANDLES_5MIN,
+		End: time.Date(2020, 9, 20, 8, 0, 0, 0, time.UTC)})
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.121"), candles[0].Close)
+}
+
+func TestClient_OptionTrading(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + OPTION_ORDER:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "BTC-USD-200925-9000-C", gjson.GetBytes(body, "instrument_id").String())
+			assert.Equal(t, "buy", gjson.GetBytes(body, "side").String())
+			assert.Equal(t, "0.1205", gjson.GetBytes(body, "price").String())
+			assert.False(t, gjson.GetBytes(body, "match_price").Exists())
+			w.Write([]byte(`{"client_oid":"","order_id":"1","error_code":"0","error_message":""}`))
+		case "POST /api/option/v3/cancel_order/BTC-USD/1":
+			w.Write([]byte(`{"client_oid":"","order_id":"1","error_code":"0","error_message":""}`))
+		case "GET /api/option/v3/orders/BTC-USD":
+			assert.Equal(t, "6", r.URL.Query().Get("state"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+			w.Write([]byte(`{"order_info":[{"instrument_id":"BTC-USD-200925-9000-C","order_id":"1","side":"buy",` +
+				`"price":"0.1205","size":"2","filled_qty":"1","state":"1","timestamp":"2020-09-20T08:00:00.000Z"}]}`))
+		case "GET /api/option/v3/BTC-USD/position":
+			w.Write([]byte(`{"equity":"1.5","holding":[{"instrument_id":"BTC-USD-200925-9000-C","position":"-2",` +
+				`"avg_cost":"0.12","avail_position":"-2"}]}`))
+		case "GET /api/option/v3/accounts/BTC-USD":
+			w.Write([]byte(`{"underlying":"BTC-USD","currency":"BTC","equity":"1.5","avail_margin":"1.2"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	result, err := c.PostOptionOrder(OptionOrderParams{InstrumentId: "BTC-USD-200925-9000-C", Side: "buy", Price: "0.1205", Size: "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", result.OrderId)
+
+	orders, err := c.GetOptionOrders("BTC-USD", &OptionOrdersParams{CursorPage: CursorPage{Limit: 2}, State: "6"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(orders))
+	assert.Equal(t, Decimal("1"), orders[0].FilledQty)
+
+	cancelled, err := c.CancelOptionOrder("BTC-USD", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "0", cancelled.ErrorCode)
+
+	position, err := c.GetOptionPosition("BTC-USD", "")
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("-2"), position.Holding[0].Position)
+
+	account, err := c.GetOptionAccount("BTC-USD")
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("1.2"), account.AvailMargin)
+}
@@ -0,0 +1,84 @@
+package okex
+
+/*
+ OKEX option api parameter's definition
+*/
+
+const (
+	OptionTypeCall = "C"
+	OptionTypePut  = "P"
+)
+
+/*
+ A new option order.
+ Side: buy, sell
+ OrderType: 0 普通委托 1 只做Maker 2 全部成交或立即取消(FOK) 3 立即成交并取消剩余(IOC) 4 市价委托
+ MatchPrice "1" 以对手价下单, the price is ignored then.
+*/
+type OptionOrderParams struct {
+	ClientOid    string  `json:"client_oid,omitempty"`
+	InstrumentId string  `json:"instrument_id"`
+	Side         string  `json:"side"`
+	OrderType    string  `json:"order_type,omitempty"`
+	Price        Decimal `json:"price,omitempty"`
+	Size         Decimal `json:"size"`
+	MatchPrice   string  `json:"match_price,omitempty"`
+}
+
+// Up to 10 orders of an underlying
+type OptionBatchOrdersParams struct {
+	Underlying string               `json:"underlying"`
+	OrderData  []*OptionOrderParams `json:"order_data"`
+}
+
+// Either order ids or client oids, up to 10
+type OptionCancelBatchParams struct {
+	OrderIds   []string `json:"order_ids,omitempty"`
+	ClientOids []string `json:"client_oids,omitempty"`
+}
+
+/*
+ Page of the orders of an underlying.
+ State is required: -2 失败 -1 撤单成功 0 等待成交 1 部分成交 2 完全成交 3 下单中 4 撤单中 6 未完成 7 已完成
+ InstrumentId is optional.
+*/
+type OptionOrdersParams struct {
+	CursorPage
+	State        string
+	InstrumentId string
+}
+
+func (p *OptionOrdersParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	if p.State != "" {
+		params["state"] = p.State
+	}
+	if p.InstrumentId != "" {
+		params["instrument_id"] = p.InstrumentId
+	}
+	return params
+}
+
+// Page of the fills of an underlying, filtered by order and instrument if set
+type OptionFillsParams struct {
+	CursorPage
+	OrderId      string
+	InstrumentId string
+}
+
+func (p *OptionFillsParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	if p.OrderId != "" {
+		params["order_id"] = p.OrderId
+	}
+	if p.InstrumentId != "" {
+		params["instrument_id"] = p.InstrumentId
+	}
+	return params
+}
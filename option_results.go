@@ -0,0 +1,186 @@
+package okex
+
+import "time"
+
+/*
+ An option contract, eg: BTC-USD-200925-9000-C.
+ State: 1 待上线 2 交易中 3 暂停 4 已交割
+*/
+type OptionInstrument struct {
+	InstrumentId       string    `json:"instrument_id"`
+	Underlying         string    `json:"underlying"`
+	SettlementCurrency string    `json:"settlement_currency"`
+	ContractVal        Decimal   `json:"contract_val"`
+	OptionType         string    `json:"option_type"` // OptionTypeCall, OptionTypePut
+	Strike             Decimal   `json:"strike"`
+	TickSize           Decimal   `json:"tick_size"`
+	LotSize            Decimal   `json:"lot_size"`
+	Listing            time.Time `json:"listing"`
+	Delivery           time.Time `json:"delivery"`
+	State              string    `json:"state"`
+	TradingStartTime   time.Time `json:"trading_start_time"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+/*
+ Market summary and greeks of an option. The greeks are in coin, the *BS
+ greeks of the Black-Scholes model in usd. BidVol, AskVol and MarkVol are
+ implied volatilities.
+*/
+type OptionSummary struct {
+	InstrumentId   string    `json:"instrument_id"`
+	Underlying     string    `json:"underlying"`
+	BestAsk        Decimal   `json:"best_ask"`
+	BestBid        Decimal   `json:"best_bid"`
+	BestAskSize    Decimal   `json:"best_ask_size"`
+	BestBidSize    Decimal   `json:"best_bid_size"`
+	ChangeRate     Decimal   `json:"change_rate"`
+	Last           Decimal   `json:"last"`
+	Leverage       Decimal   `json:"leverage"`
+	MarkPrice      Decimal   `json:"mark_price"`
+	EstimatedPrice Decimal   `json:"estimated_price"`
+	OpenInterest   Decimal   `json:"open_interest"`
+	Delta          Decimal   `json:"delta"`
+	Gamma          Decimal   `json:"gamma"`
+	Theta          Decimal   `json:"theta"`
+	Vega           Decimal   `json:"vega"`
+	DeltaBS        Decimal   `json:"delta_bs"`
+	GammaBS        Decimal   `json:"gamma_bs"`
+	ThetaBS        Decimal   `json:"theta_bs"`
+	VegaBS         Decimal   `json:"vega_bs"`
+	BidVol         Decimal   `json:"bid_vol"`
+	AskVol         Decimal   `json:"ask_vol"`
+	MarkVol        Decimal   `json:"mark_vol"`
+	RealizedVol    Decimal   `json:"realized_vol"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type OptionTicker struct {
+	InstrumentId string    `json:"instrument_id"`
+	Last         Decimal   `json:"last"`
+	LastQty      Decimal   `json:"last_qty"`
+	BestAsk      Decimal   `json:"best_ask"`
+	BestAskSize  Decimal   `json:"best_ask_size"`
+	BestBid      Decimal   `json:"best_bid"`
+	BestBidSize  Decimal   `json:"best_bid_size"`
+	Open24H      Decimal   `json:"open_24h"`
+	High24H      Decimal   `json:"high_24h"`
+	Low24H       Decimal   `json:"low_24h"`
+	Volume24H    Decimal   `json:"volume_24h"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// price, size, 强平单数量, 订单数量
+type OptionBook struct {
+	Asks      [][]string `json:"asks"`
+	Bids      [][]string `json:"bids"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+type OptionTrade struct {
+	TradeId   string    `json:"trade_id"`
+	Price     Decimal   `json:"price"`
+	Qty       Decimal   `json:"qty"`
+	Side      string    `json:"side"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Result of placing or cancelling an order, ErrorCode "0" on success
+type OptionOrderResult struct {
+	ClientOid    string `json:"client_oid"`
+	OrderId      string `json:"order_id"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type OptionBatchOrdersResult struct {
+	OrderInfo []OptionOrderResult `json:"order_info"`
+}
+
+/*
+ An option order.
+ State: -2 失败 -1 撤单成功 0 等待成交 1 部分成交 2 完全成交 3 下单中 4 撤单中
+*/
+type OptionOrder struct {
+	InstrumentId string    `json:"instrument_id"`
+	ClientOid    string    `json:"client_oid"`
+	OrderId      string    `json:"order_id"`
+	Side         string    `json:"side"`
+	OrderType    string    `json:"order_type"`
+	Price        Decimal   `json:"price"`
+	PriceAvg     Decimal   `json:"price_avg"`
+	Size         Decimal   `json:"size"`
+	FilledQty    Decimal   `json:"filled_qty"`
+	Fee          Decimal   `json:"fee"`
+	State        string    `json:"state"`
+	LastFillPx   Decimal   `json:"last_fill_px"`
+	LastFillQty  Decimal   `json:"last_fill_qty"`
+	LastFillTime time.Time `json:"last_fill_time"`
+	CreatedAt    time.Time `json:"created_at"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+type OptionOrdersResult struct {
+	OrderInfo []OptionOrder `json:"order_info"`
+}
+
+type OptionFill struct {
+	TradeId      string    `json:"trade_id"`
+	FillId       string    `json:"fill_id"`
+	OrderId      string    `json:"order_id"`
+	InstrumentId string    `json:"instrument_id"`
+	Price        Decimal   `json:"price"`
+	Size         Decimal   `json:"size"`
+	Side         string    `json:"side"`
+	ExecType     string    `json:"exec_type"` // T taker, M maker
+	Fee          Decimal   `json:"fee"`
+	FeeCurrency  string    `json:"fee_currency"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+type OptionPositionHolding struct {
+	InstrumentId    string    `json:"instrument_id"`
+	Position        Decimal   `json:"position"` // 正数多仓 负数空仓
+	AvailPosition   Decimal   `json:"avail_position"`
+	AvgCost         Decimal   `json:"avg_cost"`
+	SettlementPrice Decimal   `json:"settlement_price"`
+	TotalPnl        Decimal   `json:"total_pnl"`
+	RealizedPnl     Decimal   `json:"realized_pnl"`
+	UnrealizedPnl   Decimal   `json:"unrealized_pnl"`
+	PosMargin       Decimal   `json:"pos_margin"`
+	OptionValue     Decimal   `json:"option_value"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type OptionPosition struct {
+	Equity    Decimal                 `json:"equity"`
+	Holding   []OptionPositionHolding `json:"holding"`
+	Timestamp time.Time               `json:"timestamp"`
+}
+
+type OptionAccount struct {
+	Underlying        string    `json:"underlying"`
+	Currency          string    `json:"currency"`
+	Equity            Decimal   `json:"equity"`
+	TotalAvailBalance Decimal   `json:"total_avail_balance"`
+	AvailMargin       Decimal   `json:"avail_margin"`
+	MarginBalance     Decimal   `json:"margin_balance"`
+	Margin            Decimal   `json:"margin"`
+	MarginForUnfilled Decimal   `json:"margin_for_unfilled"`
+	MarginFrozen      Decimal   `json:"margin_frozen"`
+	MarginRatio       Decimal   `json:"margin_ratio"`
+	MaintMarginRatio  Decimal   `json:"maint_margin_ratio"`
+	RealizedPnl       Decimal   `json:"realized_pnl"`
+	UnrealizedPnl     Decimal   `json:"unrealized_pnl"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+type OptionLedger struct {
+	LedgerId  string    `json:"ledger_id"`
+	Amount    Decimal   `json:"amount"`
+	Balance   Decimal   `json:"balance"`
+	Currency  string    `json:"currency"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,193 @@
+package okex
+
+import (
+	"fmt"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	TableOptionInstruments = "option/instruments"  // 公共-合约信息频道，按标的订阅
+	TableOptionSummary     = "option/summary"      // 公共-期权详细定价频道，按标的订阅
+	TableOptionTicker      = "option/ticker"       // 公共-Ticker频道
+	TableOptionTrade       = "option/trade"        // 公共-交易频道
+	TableOptionDepthL2Tbt  = "option/depth_l2_tbt" // 公共-400档增量数据频道
+	TableOptionPosition    = "option/position"     // 用户持仓频道，按标的订阅
+	TableOptionAccount     = "option/account"      // 用户账户频道，按标的订阅
+	TableOptionOrder       = "option/order"        // 用户交易频道，按标的订阅
+)
+
+type OptionWS struct {
+	*WSEngine
+
+	instrumentsCallback     func(instruments []OptionInstrument)
+	summaryCallback         func(summaries []OptionSummary)
+	tickersCallback         func(tickers []WSTicker)
+	tradesCallback          func(trades []WSTrade)
+	depthL2TbtCallback      func(action string, data []WSDepthL2Tbt)
+	depth20SnapshotCallback func(ob *OrderBook) // 20档盘口
+	accountCallback         func(accounts []OptionAccount)
+	positionCallback        func(positions []OptionPositionHolding)
+	orderCallback           func(orders []OptionOrder)
+}
+
+func (ws *OptionWS) SetInstrumentsCallback(callback func(instruments []OptionInstrument)) {
+	ws.instrumentsCallback = callback
+}
+
+func (ws *OptionWS) SetSummaryCallback(callback func(summaries []OptionSummary)) {
+	ws.summaryCallback = callback
+}
+
+func (ws *OptionWS) SetTickerCallback(callback func(tickers []WSTicker)) {
+	ws.tickersCallback = callback
+}
+
+func (ws *OptionWS) SetTradeCallback(callback func(trades []WSTrade)) {
+	ws.tradesCallback = callback
+}
+
+func (ws *OptionWS) SetDepthL2TbtCallback(callback func(action string, data []WSDepthL2Tbt)) {
+	ws.depthL2TbtCallback = callback
+}
+
+func (ws *OptionWS) SetDepth20SnapshotCallback(callback func(ob *OrderBook)) {
+	ws.depth20SnapshotCallback = callback
+}
+
+func (ws *OptionWS) SetAccountCallback(callback func(accounts []OptionAccount)) {
+	ws.accountCallback = callback
+}
+
+func (ws *OptionWS) SetPositionCallback(callback func(positions []OptionPositionHolding)) {
+	ws.positionCallback = callback
+}
+
+func (ws *OptionWS) SetOrderCallback(callback func(orders []OptionOrder)) {
+	ws.orderCallback = callback
+}
+
+// SubscribeInstruments 标的的合约上线、下线，underlying eg: BTC-USD
+func (ws *OptionWS) SubscribeInstruments(id string, underlying string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionInstruments, underlying)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeSummary 标的的所有合约的详细定价，包括希腊字母
+func (ws *OptionWS) SubscribeSummary(id string, underlying string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionSummary, underlying)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) SubscribeTicker(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionTicker, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) SubscribeTrade(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionTrade, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeDepthL2Tbt 公共-400档增量数据频道
+// 订阅后首次返回市场订单簿的400档深度数据并推送；后续只要订单簿深度有变化就推送有更改的数据。
+func (ws *OptionWS) SubscribeDepthL2Tbt(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionDepthL2Tbt, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) SubscribePosition(id string, underlying string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionPosition, underlying)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) SubscribeAccount(id string, underlying string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionAccount, underlying)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) SubscribeOrder(id string, underlying string) error {
+	ch := fmt.Sprintf("%v:%v", TableOptionOrder, underlying)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *OptionWS) handleInstruments(table string, msg []byte) error {
+	var result WSOptionInstrumentsResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.instrumentsCallback != nil {
+		ws.instrumentsCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *OptionWS) handleSummary(table string, msg []byte) error {
+	var result WSOptionSummaryResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.summaryCallback != nil {
+		ws.summaryCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *OptionWS) handleAccount(table string, msg []byte) error {
+	var result WSOptionAccountResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.accountCallback != nil {
+		ws.accountCallback(result.Data)
+	}
+	return nil
+}
+
+// The holdings of an underlying, either listed in "holding" or as the rows themselves
+func (ws *OptionWS) handlePosition(table string, msg []byte) error {
+	var positions []OptionPositionHolding
+	for _, row := range gjson.GetBytes(msg, "data").Array() {
+		raw := row.Raw
+		if holding := row.Get("holding"); holding.Exists() {
+			raw = holding.Raw
+		} else {
+			raw = "[" + raw + "]"
+		}
+		var holdings []OptionPositionHolding
+		if err := json.Unmarshal([]byte(raw), &holdings); err != nil {
+			return err
+		}
+		positions = append(positions, holdings...)
+	}
+	if ws.positionCallback != nil {
+		ws.positionCallback(positions)
+	}
+	return nil
+}
+
+func (ws *OptionWS) handleOrder(table string, msg []byte) error {
+	var result WSOptionOrderResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.orderCallback != nil {
+		ws.orderCallback(result.Data)
+	}
+	return nil
+}
+
+// NewOptionWS 创建期权WS
+// wsURL:
+// wss://real.okex.com:8443/ws/v3
+func NewOptionWS(wsURL string, accessKey string, secretKey string, passphrase string, debugMode bool, options ...WSOption) *OptionWS {
+	ws := &OptionWS{WSEngine: NewWSEngine(wsURL, accessKey, secretKey, passphrase, debugMode, options...)}
+	ws.Handle(TableOptionDepthL2Tbt, ws.depthL2TbtHandler(&ws.depthL2TbtCallback, &ws.depth20SnapshotCallback))
+	ws.Handle(TableOptionTicker, wsTickerHandler(&ws.tickersCallback))
+	ws.Handle(TableOptionTrade, wsTradeHandler(&ws.tradesCallback))
+	ws.Handle(TableOptionSummary, ws.handleSummary)
+	ws.Handle(TableOptionInstruments, ws.handleInstruments)
+	ws.Handle(TableOptionAccount, ws.handleAccount)
+	ws.Handle(TableOptionPosition, ws.handlePosition)
+	ws.Handle(TableOptionOrder, ws.handleOrder)
+	return ws
+}
@@ -0,0 +1,21 @@
+package okex
+
+type WSOptionInstrumentsResult struct {
+	Table string             `json:"table"`
+	Data  []OptionInstrument `json:"data"`
+}
+
+type WSOptionSummaryResult struct {
+	Table string          `json:"table"`
+	Data  []OptionSummary `json:"data"`
+}
+
+type WSOptionAccountResult struct {
+	Table string          `json:"table"`
+	Data  []OptionAccount `json:"data"`
+}
+
+type WSOptionOrderResult struct {
+	Table string        `json:"table"`
+	Data  []OptionOrder `json:"data"`
+}
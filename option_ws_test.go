@@ -0,0 +1,45 @@
+package okex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionWS_HandleMsg(t *testing.T) {
+	ws := NewOptionWS(WS_ENDPOINT, "", "", "", false)
+	var summaries []OptionSummary
+	var positions []OptionPositionHolding
+	var orders []OptionOrder
+	var tickers []WSTicker
+	ws.SetSummaryCallback(func(data []OptionSummary) { summaries = data })
+	ws.SetPositionCallback(func(data []OptionPositionHolding) { positions = append(positions, data...) })
+	ws.SetOrderCallback(func(data []OptionOrder) { orders = data })
+	ws.SetTickerCallback(func(data []WSTicker) { tickers = data })
+
+	ws.handleMsg(0, []byte(`{"table":"option/summary","data":[{"instrument_id":"BTC-USD-200925-9000-C","delta":"0.9","mark_vol":"0.61"}]}`))
+	assert.Equal(t, 1, len(summaries))
+	assert.Equal(t, Decimal("0.61"), summaries[0].MarkVol)
+
+	ws.handleMsg(0, []byte(`{"table":"option/position","data":[{"equity":"1","holding":[{"instrument_id":"BTC-USD-200925-9000-C","position":"3"}]}]}`))
+	ws.handleMsg(0, []byte(`{"table":"option/position","data":[{"instrument_id":"BTC-USD-200925-9000-P","position":"-1"}]}`))
+	assert.Equal(t, 2, len(positions))
+	assert.Equal(t, Decimal("3"), positions[0].Position)
+	assert.Equal(t, "BTC-USD-200925-9000-P", positions[1].InstrumentId)
+
+	ws.handleMsg(0, []byte(`{"table":"option/order","data":[{"instrument_id":"BTC-USD-200925-9000-C","order_id":"1","side":"sell","state":"0"}]}`))
+	assert.Equal(t, "sell", orders[0].Side)
+
+	ws.handleMsg(0, []byte(`{"table":"option/ticker","data":[{"instrument_id":"BTC-USD-200925-9000-C","last":"0.12"}]}`))
+	assert.Equal(t, Decimal("0.12"), tickers[0].Last)
+
+	// not started, the subscription is kept for the first connect
+	assert.NotNil(t, ws.SubscribeSummary("s", "BTC-USD"))
+	assert.Equal(t, []string{"option/summary:BTC-USD"}, subscriptionArgs(ws.subscriptions["s"]))
+}
+
+func TestOptionWS_Simulated(t *testing.T) {
+	ws := NewOptionWS(WS_ENDPOINT, "", "", "", false, WithWSSimulated())
+	assert.Equal(t, DEMO_WS_ENDPOINT, ws.wsURL)
+	assert.Equal(t, "1", ws.wsHeader.Get(X_SIMULATED_TRADING))
+}
@@ -3,7 +3,6 @@ package okex
 import (
 	"fmt"
 	"github.com/MauriceGit/skiplist"
-	"strconv"
 	"time"
 )
 
@@ -13,16 +12,23 @@ const (
 )
 
 type Item struct {
-	Price  float64
-	Amount float64
+	Price  Decimal
+	Amount Decimal
+
+	// Price as float, set by the order book
+	key float64
 }
 
+// the skiplist orders by float, the price itself stays exact
 func (e Item) ExtractKey() float64 {
-	return e.Price
+	if e.key != 0 {
+		return e.key
+	}
+	return e.Price.Float64()
 }
 
 func (e Item) String() string {
-	return fmt.Sprintf("%.8f", e.Price)
+	return e.Price.String()
 }
 
 type OrderBook struct {
@@ -41,74 +47,83 @@ func (d *DepthOrderBook) GetInstrumentID() string {
 	return d.instrumentID
 }
 
-func (d *DepthOrderBook) Update(action string, data *WSDepthL2Tbt) {
+// 举例: ["411.8", "10", "1", "4"]
+// 411.8为深度价格，10为此价格的合约张数，1为此价格的强平单个数，4为此价格的订单个数。
+func parseDepthItem(level []string) (Item, error) {
+	if len(level) < 2 {
+		return Item{}, fmt.Errorf("okex: illegal depth level %v", level)
+	}
+	price, err := ParseDecimal(level[0])
+	if err != nil {
+		return Item{}, err
+	}
+	amount, err := ParseDecimal(level[1])
+	if err != nil {
+		return Item{}, err
+	}
+	if price == "" {
+		return Item{}, fmt.Errorf("okex: illegal depth level %v", level)
+	}
+	return Item{Price: price, Amount: amount, key: price.Float64()}, nil
+}
+
+/*
+ Apply a partial (snapshot) or update message. A level which can't be parsed
+ fails the whole message and leaves the book as it was.
+*/
+func (d *DepthOrderBook) Update(action string, data *WSDepthL2Tbt) error {
+	asks, err := parseDepthItems(data.Asks)
+	if err != nil {
+		return err
+	}
+	bids, err := parseDepthItems(data.Bids)
+	if err != nil {
+		return err
+	}
+
 	if action == ActionDepthL2Partial {
 		d.asks = skiplist.NewSeedEps(time.Now().UTC().UnixNano(), 0.00000001)
 		d.bids = skiplist.NewSeedEps(time.Now().UTC().UnixNano(), 0.00000001)
 		//d.asks = skiplist.New()
 		//d.bids = skiplist.New()
-		// 举例: ["411.8", "10", "1", "4"]
-		// 411.8为深度价格，10为此价格的合约张数，1为此价格的强平单个数，4为此价格的订单个数。
-		for _, ask := range data.Asks {
-			price, _ := strconv.ParseFloat(ask[0], 64)
-			amount, _ := strconv.ParseFloat(ask[1], 64)
-			d.asks.Insert(Item{
-				Price:  price,
-				Amount: amount,
-			})
+		for _, item := range asks {
+			d.asks.Insert(item)
 		}
-		for _, bid := range data.Bids {
-			price, _ := strconv.ParseFloat(bid[0], 64)
-			amount, _ := strconv.ParseFloat(bid[1], 64)
-			d.bids.Insert(Item{
-				Price:  price,
-				Amount: amount,
-			})
+		for _, item := range bids {
+			d.bids.Insert(item)
 		}
-		return
+		return nil
 	}
 
 	if action == ActionDepthL2Update {
-		for _, ask := range data.Asks {
-			price, _ := strconv.ParseFloat(ask[0], 64)
-			amount, _ := strconv.ParseFloat(ask[1], 64)
-			if amount == 0 {
-				d.asks.Delete(Item{
-					Price:  price,
-					Amount: amount,
-				})
-			} else {
-				item := Item{
-					Price:  price,
-					Amount: amount,
-				}
-				elem, ok := d.asks.Find(item)
-				if ok {
-					d.asks.ChangeValue(elem, item)
-				} else {
-					d.asks.Insert(item)
-				}
-			}
+		updateDepthSide(&d.asks, asks)
+		updateDepthSide(&d.bids, bids)
+	}
+	return nil
+}
+
+func parseDepthItems(levels [][]string) ([]Item, error) {
+	items := make([]Item, 0, len(levels))
+	for _, level := range levels {
+		item, err := parseDepthItem(level)
+		if err != nil {
+			return nil, err
 		}
-		for _, bid := range data.Bids {
-			price, _ := strconv.ParseFloat(bid[0], 64)
-			amount, _ := strconv.ParseFloat(bid[1], 64)
-			if amount == 0 {
-				d.bids.Delete(Item{
-					Price:  price,
-					Amount: amount,
-				})
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func updateDepthSide(list *skiplist.SkipList, items []Item) {
+	for _, item := range items {
+		if item.Amount.IsZero() {
+			list.Delete(item)
+		} else {
+			elem, ok := list.Find(item)
+			if ok {
+				list.ChangeValue(elem, item)
 			} else {
-				item := Item{
-					Price:  price,
-					Amount: amount,
-				}
-				elem, ok := d.bids.Find(item)
-				if ok {
-					d.bids.ChangeValue(elem, item)
-				} else {
-					d.bids.Insert(item)
-				}
+				list.Insert(item)
 			}
 		}
 	}
@@ -10,23 +10,23 @@ import (
 
 func TestSkipList(t *testing.T) {
 	list := skiplist.New()
-	item0 := Item{Price: 7000.0, Amount: 10.0}
+	item0 := Item{Price: "7000.0", Amount: "10.0"}
 	list.Insert(item0)
 
-	fItem, ok := list.Find(Item{Price: 7000.0, Amount: 200.0})
+	fItem, ok := list.Find(Item{Price: "7000.0", Amount: "200.0"})
 	assert.True(t, ok)
 	v := fItem.GetValue().(Item)
 	assert.Equal(t, v.ExtractKey(), 7000.0)
-	assert.Equal(t, v.Amount, 10.0)
+	assert.Equal(t, v.Amount, Decimal("10.0"))
 
 	fItem, ok = list.Find(item0)
 	assert.True(t, ok)
 	v = fItem.GetValue().(Item)
 	assert.Equal(t, v.ExtractKey(), 7000.0)
-	assert.Equal(t, v.Price, 7000.0)
-	assert.Equal(t, v.Amount, 10.0)
+	assert.Equal(t, v.Price, Decimal("7000.0"))
+	assert.Equal(t, v.Amount, Decimal("10.0"))
 
-	ok = list.ChangeValue(fItem, Item{7000.0, 20.0})
+	ok = list.ChangeValue(fItem, Item{Price: "7000.0", Amount: "20.0"})
 	assert.True(t, ok)
 	smallest := list.GetSmallestNode()
 	largest := list.GetLargestNode()
@@ -34,9 +34,9 @@ func TestSkipList(t *testing.T) {
 	assert.NotNil(t, largest)
 	assert.Equal(t, smallest, largest)
 
-	assert.Equal(t, smallest.GetValue().(Item).Amount, 20.0)
+	assert.Equal(t, smallest.GetValue().(Item).Amount, Decimal("20.0"))
 
-	fItem, ok = list.Find(Item{Price: 6000.0, Amount: 100})
+	fItem, ok = list.Find(Item{Price: "6000.0", Amount: "100"})
 	assert.False(t, ok)
 	assert.Nil(t, fItem)
 }
@@ -71,40 +71,40 @@ func TestDepthOrderBook_GetOrderBook(t *testing.T) {
 	//	t.Logf("Ask: %v,%v", v.Price, v.Amount)
 	//}
 
-	assert.Equal(t, ob.Asks[0].Price, 6773.41)
-	assert.Equal(t, ob.Asks[0].Amount, 344.0)
+	assert.Equal(t, ob.Asks[0].Price, Decimal("6773.41"))
+	assert.Equal(t, ob.Asks[0].Amount, Decimal("344"))
 
-	assert.Equal(t, ob.Asks[1].Price, 6773.8)
-	assert.Equal(t, ob.Asks[1].Amount, 13.0)
+	assert.Equal(t, ob.Asks[1].Price, Decimal("6773.8"))
+	assert.Equal(t, ob.Asks[1].Amount, Decimal("13"))
 
 	// ["6773.4","29","0","4"],["6773.3","3","0","1"]
-	assert.Equal(t, ob.Bids[0].Price, 6773.4)
-	assert.Equal(t, ob.Bids[0].Amount, 29.0)
+	assert.Equal(t, ob.Bids[0].Price, Decimal("6773.4"))
+	assert.Equal(t, ob.Bids[0].Amount, Decimal("29"))
 
-	assert.Equal(t, ob.Bids[1].Price, 6773.3)
-	assert.Equal(t, ob.Bids[1].Amount, 3.0)
+	assert.Equal(t, ob.Bids[1].Price, Decimal("6773.3"))
+	assert.Equal(t, ob.Bids[1].Amount, Decimal("3"))
 
 	// 更新
 	updateString := `{"table":"futures/depth_l2_tbt","action":"update","data":[{"instrument_id":"BTC-USD-200626","asks":[["6773.3","0","0","0"],["6773.39","0","0","0"]],"bids":[["6774.41","0","0","0"],["6773.51","0","0","0"],["6773.42","0","0","0"]],"timestamp":"2020-04-12T10:24:19.925Z","checksum":854586422}]}`
 	depthL2 = parseWSDepthL2TbtResult(updateString)
 
-	_, ok := dob.asks.Find(Item{Price: 6773.3})
+	_, ok := dob.asks.Find(Item{Price: "6773.3"})
 	assert.False(t, ok)
 
 	dob.Update(ActionDepthL2Update, &depthL2.Data[0])
 
 	// "asks":[["6773.3","0","0","0"],["6773.39","0","0","0"]],"bids":[["6774.41","0","0","0"],["6773.51","0","0","0"],["6773.42","0","0","0"]]
 
-	_, ok = dob.asks.Find(Item{Price: 6773.3})
+	_, ok = dob.asks.Find(Item{Price: "6773.3"})
 	assert.False(t, ok)
-	_, ok = dob.asks.Find(Item{Price: 6773.39})
+	_, ok = dob.asks.Find(Item{Price: "6773.39"})
 	assert.False(t, ok)
 
-	_, ok = dob.bids.Find(Item{Price: 6774.41})
+	_, ok = dob.bids.Find(Item{Price: "6774.41"})
 	assert.False(t, ok)
-	_, ok = dob.bids.Find(Item{Price: 6773.51})
+	_, ok = dob.bids.Find(Item{Price: "6773.51"})
 	assert.False(t, ok)
-	_, ok = dob.bids.Find(Item{Price: 6773.42})
+	_, ok = dob.bids.Find(Item{Price: "6773.42"})
 	assert.False(t, ok)
 	//assert.Equal(t, item6773_3.GetValue().(Item).Amount, 3.0)
 
@@ -112,13 +112,13 @@ func TestDepthOrderBook_GetOrderBook(t *testing.T) {
 	depthL2 = parseWSDepthL2TbtResult(updateString)
 	dob.Update(ActionDepthL2Update, &depthL2.Data[0])
 
-	fv, ok := dob.asks.Find(Item{Price: 6782.5})
+	fv, ok := dob.asks.Find(Item{Price: "6782.5"})
 	assert.True(t, ok)
-	assert.Equal(t, fv.GetValue().(Item).Amount, 11.0)
+	assert.Equal(t, fv.GetValue().(Item).Amount, Decimal("11"))
 
-	fv, ok = dob.bids.Find(Item{Price: 6766.01})
+	fv, ok = dob.bids.Find(Item{Price: "6766.01"})
 	assert.True(t, ok)
-	assert.Equal(t, fv.GetValue().(Item).Amount, 133.0)
+	assert.Equal(t, fv.GetValue().(Item).Amount, Decimal("133"))
 
 	n := int64(1000000)
 	start := time.Now()
@@ -131,24 +131,24 @@ func TestDepthOrderBook_GetOrderBook(t *testing.T) {
 
 func TestSkipList1(t *testing.T) {
 	list := skiplist.New()
-	item0 := Item{Price: 7000.0, Amount: 10.0}
+	item0 := Item{Price: "7000.0", Amount: "10.0"}
 	list.Insert(item0)
 
-	fItem, ok := list.Find(Item{Price: 7000.0, Amount: 200000.0})
+	fItem, ok := list.Find(Item{Price: "7000.0", Amount: "200000.0"})
 	t.Logf("ok:%v", ok)
 	//assert.True(t, ok)
 	v := fItem.GetValue().(Item)
 	assert.Equal(t, v.ExtractKey(), 7000.0)
-	assert.Equal(t, v.Amount, 10.0)
+	assert.Equal(t, v.Amount, Decimal("10.0"))
 
 	fItem, ok = list.Find(item0)
 	assert.True(t, ok)
 	v = fItem.GetValue().(Item)
 	assert.Equal(t, v.ExtractKey(), 7000.0)
-	assert.Equal(t, v.Price, 7000.0)
-	assert.Equal(t, v.Amount, 10.0)
+	assert.Equal(t, v.Price, Decimal("7000.0"))
+	assert.Equal(t, v.Amount, Decimal("10.0"))
 
-	ok = list.ChangeValue(fItem, Item{7000.0, 20.0})
+	ok = list.ChangeValue(fItem, Item{Price: "7000.0", Amount: "20.0"})
 	assert.True(t, ok)
 	smallest := list.GetSmallestNode()
 	largest := list.GetLargestNode()
@@ -156,9 +156,9 @@ func TestSkipList1(t *testing.T) {
 	assert.NotNil(t, largest)
 	assert.Equal(t, smallest, largest)
 
-	assert.Equal(t, smallest.GetValue().(Item).Amount, 20.0)
+	assert.Equal(t, smallest.GetValue().(Item).Amount, Decimal("20.0"))
 
-	fItem, ok = list.Find(Item{Price: 6000.0, Amount: 100})
+	fItem, ok = list.Find(Item{Price: "6000.0", Amount: "100"})
 	assert.False(t, ok)
 	assert.Nil(t, fItem)
 }
@@ -181,7 +181,7 @@ func TestDepthOrderBook_GetOrderBook1(t *testing.T) {
 	var ok bool
 	//_,ok=dob.asks.Find(Item{Price:0.01824})
 	//t.Logf("查找0.01824%+v",ok)
-	_, ok = dob.asks.Find(Item{Price: 0.01826})
+	_, ok = dob.asks.Find(Item{Price: "0.01826"})
 	t.Logf("查找0.01826%+v", ok)
 	assert.True(t, ok)
 
@@ -192,7 +192,7 @@ func TestDepthOrderBook_GetOrderBook1(t *testing.T) {
 	dob.Update(ActionDepthL2Update, &depthL2.Data[0])
 	t.Logf("! %+v", dob.asks.String())
 
-	_, ok = dob.asks.Find(Item{Price: 0.01826})
+	_, ok = dob.asks.Find(Item{Price: "0.01826"})
 	t.Logf("查找0.01826%+v", ok)
 
 	ob := dob.GetOrderBook(20)
@@ -0,0 +1,376 @@
+package okex
+
+/*
+ Cursor pagination iterators.
+
+ OKEx returns paged arrays newest first. An iterator requests the first page
+ and keeps requesting older pages with after=<pagination id> until a page is
+ empty or the Since bound is passed. The pagination id is taken from the
+ OK-AFTER response header, or from the id field of the oldest row. Candles
+ have no id and are paged by time with end=<oldest bar time>.
+
+ Every page is a normal Client request, so rate limiting and retries apply.
+
+	it := client.IterSwapFills(ctx, "BTC-USD-SWAP", IterOptions{Since: yesterday})
+	for it.Next() {
+		var fill BaseFillInfo
+		if err := it.Decode(&fill); err != nil { ... }
+	}
+	if err := it.Err(); err != nil { ... }
+*/
+
+import (
+	"context"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+type IterOptions struct {
+	// Number of results per request. Maximum 100. (default 100)
+	Limit int
+	// Stop at the first row older than Since. Zero iterates to the oldest row.
+	Since time.Time
+	// Skip rows newer than Until. Zero starts at the newest row.
+	Until time.Time
+	// Extra query params, eg: "state" of the order list, "type" of the ledger
+	Params map[string]string
+}
+
+type PageIterator struct {
+	client *Client
+	ctx    context.Context
+	path   string
+	opts   IterOptions
+
+	// json field of the rows holding the pagination id, "" pages by the OK-AFTER header only
+	cursorField string
+	// page candles by bar time instead of pagination id
+	byTime bool
+	// path of the rows array in the response, "" for a top level array
+	rowsPath string
+
+	rows    []gjson.Result
+	row     gjson.Result
+	after   string
+	lastBar time.Time
+	started bool
+	done    bool
+	err     error
+}
+
+func (client *Client) newPageIterator(ctx context.Context, path, cursorField, rowsPath string, opts IterOptions) *PageIterator {
+	if opts.Limit <= 0 || opts.Limit > 100 {
+		opts.Limit = 100
+	}
+	return &PageIterator{
+		client:      client,
+		ctx:         ctx,
+		path:        path,
+		opts:        opts,
+		cursorField: cursorField,
+		rowsPath:    rowsPath,
+	}
+}
+
+func (client *Client) newCandleIterator(ctx context.Context, path string, granularity Granularity, opts IterOptions) *PageIterator {
+	it := client.newPageIterator(ctx, path, "", "", withParam(opts, "granularity", Int2String(int(granularity))))
+	it.byTime = true
+	return it
+}
+
+/*
+ Advance to the next (older) row, false when exhausted or on error.
+*/
+func (it *PageIterator) Next() bool {
+	for {
+		for len(it.rows) > 0 {
+			it.row = it.rows[0]
+			it.rows = it.rows[1:]
+			ts, ok := rowTime(it.row)
+			if ok && !it.opts.Until.IsZero() && ts.After(it.opts.Until) {
+				continue
+			}
+			if ok && !it.opts.Since.IsZero() && ts.Before(it.opts.Since) {
+				it.done = true
+				it.rows = nil
+				return false
+			}
+			return true
+		}
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+}
+
+func (it *PageIterator) fetch() error {
+	params := NewParams()
+	for k, v := range it.opts.Params {
+		params[k] = v
+	}
+	params["limit"] = Int2String(it.opts.Limit)
+	if it.byTime {
+		if it.started {
+			params["end"] = isoTimeOf(it.lastBar)
+		} else if !it.opts.Until.IsZero() {
+			params["end"] = isoTimeOf(it.opts.Until)
+		}
+	} else if it.after != "" {
+		params["after"] = it.after
+	}
+	it.started = true
+
+	respBody, response, err := it.client.RequestCtx(it.ctx, GET, BuildParams(it.path, params), nil, nil)
+	if err != nil {
+		return err
+	}
+	ret := gjson.ParseBytes(respBody)
+	if it.rowsPath != "" {
+		ret = ret.Get(it.rowsPath)
+	}
+	rows := ret.Array()
+
+	if it.byTime {
+		// candles: drop bars overlapping the previous page
+		var fresh []gjson.Result
+		for _, row := range rows {
+			if ts, ok := rowTime(row); ok && (it.lastBar.IsZero() || ts.Before(it.lastBar)) {
+				fresh = append(fresh, row)
+			}
+		}
+		if len(fresh) == 0 {
+			it.done = true
+			return nil
+		}
+		it.rows = fresh
+		it.lastBar, _ = rowTime(fresh[len(fresh)-1])
+		return nil
+	}
+
+	if len(rows) == 0 {
+		it.done = true
+		return nil
+	}
+	it.rows = rows
+	after := response.Header.Get(OK_AFTER)
+	if after == "" && it.cursorField != "" {
+		after = rows[len(rows)-1].Get(it.cursorField).String()
+	}
+	if after == "" || after == it.after || len(rows) < it.opts.Limit {
+		it.done = true
+	}
+	it.after = after
+	return nil
+}
+
+// Raw json of the current row
+func (it *PageIterator) Raw() []byte {
+	return []byte(it.row.Raw)
+}
+
+// Decode the current row into v
+func (it *PageIterator) Decode(v interface{}) error {
+	return JsonBytes2Struct(it.Raw(), v)
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// rowTime reads the timestamp of a row, or the bar time of a candle array
+func rowTime(row gjson.Result) (time.Time, bool) {
+	var ts string
+	if row.IsArray() {
+		ts = row.Get("0").String()
+	} else if v := row.Get("timestamp"); v.Exists() {
+		ts = v.String()
+	} else {
+		ts = row.Get("created_at").String()
+	}
+	if ts == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	return t, err == nil
+}
+
+func withParam(opts IterOptions, key, value string) IterOptions {
+	if value == "" {
+		return opts
+	}
+	params := NewParams()
+	for k, v := range opts.Params {
+		params[k] = v
+	}
+	params[key] = value
+	opts.Params = params
+	return opts
+}
+
+/*
+ Swap
+*/
+
+func (client *Client) IterSwapLedger(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(SWAP_ACCOUNTS_LEDGER, instrumentId), "ledger_id", "", opts)
+}
+
+func (client *Client) IterSwapFills(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, SWAP_FILLS, "trade_id", "", withParam(opts, "instrument_id", instrumentId))
+}
+
+// state is required, eg: "2" filled, "7" open
+func (client *Client) IterSwapOrders(ctx context.Context, instrumentId string, state string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(SWAP_INSTRUMENT_ORDER_LIST, instrumentId), "order_id", "order_info",
+		withParam(opts, "state", state))
+}
+
+func (client *Client) IterSwapTrades(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(SWAP_INSTRUMENT_TRADES, instrumentId), "trade_id", "", opts)
+}
+
+func (client *Client) IterSwapCandles(ctx context.Context, instrumentId string, granularity Granularity, opts IterOptions) *PageIterator {
+	return client.newCandleIterator(ctx, GetInstrumentIdUri(SWAP_INSTRUMENT_CANDLES, instrumentId), granularity, opts)
+}
+
+// status: "0" unfilled, "1" filled
+func (client *Client) IterSwapLiquidations(ctx context.Context, instrumentId string, status string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(SWAP_INSTRUMENT_LIQUIDATION, instrumentId), "", "",
+		withParam(opts, "status", status))
+}
+
+/*
+ Futures
+*/
+
+func (client *Client) IterFuturesLedger(ctx context.Context, underlying string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetCurrencyUri(FUTURES_ACCOUNT_CURRENCY_LEDGER, underlying), "ledger_id", "", opts)
+}
+
+func (client *Client) IterFuturesFills(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, FUTURES_FILLS, "trade_id", "", withParam(opts, "instrument_id", instrumentId))
+}
+
+// state is required, eg: "2" filled, "6" open
+func (client *Client) IterFuturesOrders(ctx context.Context, instrumentId string, state string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(FUTURES_INSTRUMENT_ORDER_LIST, instrumentId), "order_id", "order_info",
+		withParam(opts, "state", state))
+}
+
+func (client *Client) IterFuturesTrades(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(FUTURES_INSTRUMENT_TRADES, instrumentId), "trade_id", "", opts)
+}
+
+func (client *Client) IterFuturesCandles(ctx context.Context, instrumentId string, granularity Granularity, opts IterOptions) *PageIterator {
+	return client.newCandleIterator(ctx, GetInstrumentIdUri(FUTURES_INSTRUMENT_CANDLES, instrumentId), granularity, opts)
+}
+
+// status: "0" unfilled, "1" filled
+func (client *Client) IterFuturesLiquidations(ctx context.Context, instrumentId string, status string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(FUTURES_INSTRUMENT_LIQUIDATION, instrumentId), "", "",
+		withParam(opts, "status", status))
+}
+
+/*
+ Spot
+*/
+
+func (client *Client) IterSpotLedger(ctx context.Context, currency string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetCurrencyUri(SPOT_ACCOUNTS_CURRENCY_LEDGER, currency), "ledger_id", "", opts)
+}
+
+func (client *Client) IterSpotFills(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, SPOT_FILLS, "ledger_id", "", withParam(opts, "instrument_id", instrumentId))
+}
+
+// state is required, eg: "2" filled, "7" open
+func (client *Client) IterSpotOrders(ctx context.Context, instrumentId string, state string, opts IterOptions) *PageIterator {
+	opts = withParam(opts, "instrument_id", instrumentId)
+	return client.newPageIterator(ctx, SPOT_ORDERS, "order_id", "", withParam(opts, "state", state))
+}
+
+func (client *Client) IterSpotTrades(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(SPOT_INSTRUMENT_TRADES, instrumentId), "trade_id", "", opts)
+}
+
+func (client *Client) IterSpotCandles(ctx context.Context, instrumentId string, granularity Granularity, opts IterOptions) *PageIterator {
+	return client.newCandleIterator(ctx, GetInstrumentIdUri(SPOT_INSTRUMENT_CANDLES, instrumentId), granularity, opts)
+}
+
+/*
+ Margin
+*/
+
+func (client *Client) IterMarginLedger(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(MARGIN_ACCOUNTS_INSTRUMENT_LEDGER, instrumentId), "ledger_id", "", opts)
+}
+
+func (client *Client) IterMarginFills(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, MARGIN_FILLS, "ledger_id", "", withParam(opts, "instrument_id", instrumentId))
+}
+
+// state is required, eg: "2" filled, "7" open
+func (client *Client) IterMarginOrders(ctx context.Context, instrumentId string, state string, opts IterOptions) *PageIterator {
+	opts = withParam(opts, "instrument_id", instrumentId)
+	return client.newPageIterator(ctx, MARGIN_ORDERS, "order_id", "", withParam(opts, "state", state))
+}
+
+/*
+ Option
+*/
+
+func (client *Client) IterOptionLedger(ctx context.Context, underlying string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetUnderlyingUri(OPTION_ACCOUNT_LEDGER, underlying), "ledger_id", "", opts)
+}
+
+// instrumentId is optional
+func (client *Client) IterOptionFills(ctx context.Context, underlying, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetUnderlyingUri(OPTION_FILLS, underlying), "trade_id", "",
+		withParam(opts, "instrument_id", instrumentId))
+}
+
+// state is required, eg: "2" filled, "6" open. instrumentId is optional
+func (client *Client) IterOptionOrders(ctx context.Context, underlying, instrumentId, state string, opts IterOptions) *PageIterator {
+	opts = withParam(opts, "instrument_id", instrumentId)
+	return client.newPageIterator(ctx, GetUnderlyingUri(OPTION_ORDERS, underlying), "order_id", "order_info",
+		withParam(opts, "state", state))
+}
+
+func (client *Client) IterOptionTrades(ctx context.Context, instrumentId string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, GetInstrumentIdUri(OPTION_INSTRUMENT_TRADES, instrumentId), "trade_id", "", opts)
+}
+
+func (client *Client) IterOptionCandles(ctx context.Context, instrumentId string, granularity Granularity, opts IterOptions) *PageIterator {
+	return client.newCandleIterator(ctx, GetInstrumentIdUri(OPTION_INSTRUMENT_CANDLES, instrumentId), granularity, opts)
+}
+
+/*
+ Account
+*/
+
+// currency is optional
+func (client *Client) IterAccountLedger(ctx context.Context, currency string, opts IterOptions) *PageIterator {
+	return client.newPageIterator(ctx, ACCOUNT_LEDGER, "ledger_id", "", withParam(opts, "currency", currency))
+}
+
+func (client *Client) IterAccountDeposits(ctx context.Context, currency string, opts IterOptions) *PageIterator {
+	path := ACCOUNT_DEPOSIT_HISTORY
+	if currency != "" {
+		path = GetCurrencyUri(ACCOUNT_DEPOSIT_HISTORY_CURRENCY, currency)
+	}
+	return client.newPageIterator(ctx, path, "deposit_id", "", opts)
+}
+
+func (client *Client) IterAccountWithdrawals(ctx context.Context, currency string, opts IterOptions) *PageIterator {
+	path := ACCOUNT_WITHRAWAL_HISTORY
+	if currency != "" {
+		path = GetCurrencyUri(ACCOUNT_WITHRAWAL_HISTORY_CURRENCY, currency)
+	}
+	return client.newPageIterator(ctx, path, "withdrawal_id", "", opts)
+}
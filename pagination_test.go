@@ -0,0 +1,102 @@
+package okex
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageIterator_SwapFills(t *testing.T) {
+	var afters []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, SWAP_FILLS, r.URL.Path)
+		assert.Equal(t, "BTC-USD-SWAP", r.URL.Query().Get("instrument_id"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		switch after {
+		case "":
+			w.Write([]byte(`[{"trade_id":"5","timestamp":"2020-01-01T00:05:00.000Z"},{"trade_id":"4","timestamp":"2020-01-01T00:04:00.000Z"}]`))
+		case "4":
+			w.Header().Set(OK_AFTER, "3")
+			w.Write([]byte(`[{"trade_id":"3","timestamp":"2020-01-01T00:03:00.000Z"},{"trade_id":"2","timestamp":"2020-01-01T00:02:00.000Z"}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	})
+	defer server.Close()
+
+	it := c.IterSwapFills(context.Background(), "BTC-USD-SWAP", IterOptions{Limit: 2})
+	var ids []string
+	for it.Next() {
+		var fill BaseFillInfo
+		assert.Nil(t, it.Decode(&fill))
+		ids = append(ids, fill.TradeId)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"5", "4", "3", "2"}, ids)
+	assert.Equal(t, []string{"", "4", "3"}, afters)
+}
+
+func TestPageIterator_TimeBounds(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("state"))
+		w.Write([]byte(`{"order_info":[{"order_id":"3","timestamp":"2020-01-01T00:03:00.000Z"},{"order_id":"2","timestamp":"2020-01-01T00:02:00.000Z"},{"order_id":"1","timestamp":"2020-01-01T00:01:00.000Z"}]}`))
+	})
+	defer server.Close()
+
+	it := c.IterFuturesOrders(context.Background(), "BTC-USD-200327", "2", IterOptions{
+		Since: time.Date(2020, 1, 1, 0, 1, 30, 0, time.UTC),
+		Until: time.Date(2020, 1, 1, 0, 2, 30, 0, time.UTC),
+	})
+	var ids []string
+	for it.Next() {
+		var order BaseOrderInfo
+		assert.Nil(t, it.Decode(&order))
+		ids = append(ids, order.OrderId)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"2"}, ids)
+}
+
+func TestPageIterator_Candles(t *testing.T) {
+	var ends []string
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "60", r.URL.Query().Get("granularity"))
+		end := r.URL.Query().Get("end")
+		ends = append(ends, end)
+		switch end {
+		case "":
+			w.Write([]byte(`[["2020-01-01T00:03:00.000Z","1","1","1","1","1"],["2020-01-01T00:02:00.000Z","1","1","1","1","1"]]`))
+		case "2020-01-01T00:02:00.000Z":
+			w.Write([]byte(`[["2020-01-01T00:02:00.000Z","1","1","1","1","1"],["2020-01-01T00:01:00.000Z","1","1","1","1","1"]]`))
+		default:
+			w.Write([]byte(`[["2020-01-01T00:01:00.000Z","1","1","1","1","1"]]`))
+		}
+	})
+	defer server.Close()
+
+	it := c.IterSwapCandles(context.Background(), "BTC-USD-SWAP", CANDLES_1MIN, IterOptions{})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"", "2020-01-01T00:02:00.000Z", "2020-01-01T00:01:00.000Z"}, ends)
+}
+
+func TestPageIterator_Error(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":30032,"message":"pair suspended"}`))
+	})
+	defer server.Close()
+
+	it := c.IterAccountDeposits(context.Background(), "BTC", IterOptions{})
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Err())
+}
@@ -0,0 +1,279 @@
+package okex
+
+/*
+ Client side rate limiting for rest requests.
+
+ Every limit is a token bucket keyed by an uri constant of uri_constants.go,
+ optionally prefixed by the http method when an uri has different limits per
+ method, eg: "POST /api/spot/v3/orders". A request is matched against the
+ configured keys by its path, "{...}" segments of a key match any value.
+*/
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RateLimit struct {
+	// Requests allowed per Per. <= 0 disables the limit.
+	Requests int
+	Per      time.Duration
+}
+
+type RateLimitMode int
+
+const (
+	// Block until a token is available or the request context is done.
+	RateLimitWait RateLimitMode = iota
+	// Return a *RateLimitError immediately.
+	RateLimitFailFast
+)
+
+type RateLimitConfig struct {
+	Mode RateLimitMode
+	// Overrides of DefaultRateLimits, keyed by uri constant or "METHOD uri".
+	Limits map[string]RateLimit
+	// Only use Limits, ignore DefaultRateLimits.
+	DisableDefaults bool
+}
+
+/*
+ Limits documented by OKEx, eg: 限速规则：20次/2s
+*/
+func DefaultRateLimits() map[string]RateLimit {
+	per2s := func(n int) RateLimit { return RateLimit{Requests: n, Per: 2 * time.Second} }
+	return map[string]RateLimit{
+		POST + " " + ACCOUNT_TRANSFER: {Requests: 2, Per: time.Second},
+
+		GET + " " + FUTURES_INSTRUMENT_BOOK:            per2s(20),
+		GET + " " + FUTURES_ACCOUNT_CURRENCY_LEVERAGE:  per2s(5),
+		POST + " " + FUTURES_ACCOUNT_CURRENCY_LEVERAGE: per2s(5),
+		POST + " " + FUTURES_CLOSE_POSITION:            per2s(2),
+		POST + " " + FUTURES_ORDER_ALGO:                per2s(40),
+		POST + " " + FUTURES_CANCEL_ALGOS:              per2s(20),
+		GET + " " + FUTURES_INSTRUMENT_ORDER_ALGO:      per2s(20),
+
+		GET + " " + MARGIN_ACCOUNTS:                         per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_INSTRUMENT:              per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_INSTRUMENT_LEDGER:       per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_AVAILABILITY:            per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_INSTRUMENT_AVAILABILITY: per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_BORROWED:                per2s(20),
+		GET + " " + MARGIN_ACCOUNTS_INSTRUMENT_BORROWED:     per2s(20),
+		GET + " " + MARGIN_ORDERS:                           per2s(20),
+		GET + " " + MARGIN_ORDERS_BY_ID:                     per2s(20),
+		GET + " " + MARGIN_ORDERS_PENDING:                   per2s(20),
+		GET + " " + MARGIN_FILLS:                            per2s(20),
+		POST + " " + MARGIN_ACCOUNTS_BORROW:                 per2s(100),
+		POST + " " + MARGIN_ACCOUNTS_REPAYMENT:              per2s(100),
+		POST + " " + MARGIN_ORDERS:                          per2s(100),
+		POST + " " + MARGIN_BATCH_ORDERS:                    per2s(50),
+		POST + " " + MARGIN_CANCEL_ORDERS_BY_ID:             per2s(100),
+		POST + " " + MARGIN_CANCEL_BATCH_ORDERS:             per2s(50),
+
+		GET + " " + OPTION_INSTRUMENTS:          per2s(20),
+		GET + " " + OPTION_INSTRUMENTS_SUMMARY:  per2s(20),
+		GET + " " + OPTION_INSTRUMENT_SUMMARY:   per2s(20),
+		GET + " " + OPTION_FILLS:                per2s(20),
+		GET + " " + OPTION_POSITION:             per2s(20),
+		GET + " " + OPTION_ACCOUNT:              per2s(20),
+		POST + " " + OPTION_ORDER:               per2s(20),
+		POST + " " + OPTION_BATCH_ORDERS:        per2s(20),
+		POST + " " + OPTION_CANCEL_ORDER:        per2s(20),
+		POST + " " + OPTION_CANCEL_BATCH_ORDERS: per2s(20),
+
+		GET + " " + SPOT_ACCOUNTS:                 per2s(20),
+		GET + " " + SPOT_ACCOUNTS_CURRENCY:        per2s(20),
+		GET + " " + SPOT_ACCOUNTS_CURRENCY_LEDGER: per2s(20),
+		GET + " " + SPOT_ORDERS:                   per2s(20),
+		GET + " " + SPOT_ORDERS_PENDING:           per2s(20),
+		GET + " " + SPOT_ORDERS_BY_ID:             per2s(20),
+		GET + " " + SPOT_FILLS:                    per2s(20),
+		GET + " " + SPOT_INSTRUMENTS:              per2s(20),
+		GET + " " + SPOT_INSTRUMENT_BOOK:          per2s(20),
+		GET + " " + SPOT_INSTRUMENTS_TICKER:       per2s(50),
+		GET + " " + SPOT_INSTRUMENT_TICKER:        per2s(20),
+		GET + " " + SPOT_INSTRUMENT_TRADES:        per2s(20),
+		GET + " " + SPOT_INSTRUMENT_CANDLES:       per2s(20),
+		POST + " " + SPOT_ORDERS:                  per2s(100),
+		POST + " " + SPOT_BATCH_ORDERS:            per2s(50),
+		POST + " " + SPOT_CANCEL_ORDERS_BY_ID:     per2s(100),
+		POST + " " + SPOT_CANCEL_BATCH_ORDERS:     per2s(50),
+		POST + " " + SPOT_ORDER_ALGO:              per2s(40),
+		POST + " " + SPOT_CANCEL_BATCH_ALGOS:      per2s(20),
+		GET + " " + SPOT_ALGO:                     per2s(20),
+
+		GET + " " + SWAP_POSITION:               {Requests: 1, Per: 10 * time.Second},
+		GET + " " + SWAP_INSTRUMENT_ORDER_BY_ID: per2s(40),
+		POST + " " + SWAP_CLOSE_POSITION:        per2s(2),
+		POST + " " + SWAP_ORDER_ALGO:            per2s(40),
+		POST + " " + SWAP_CANCEL_ALGOS:          per2s(20),
+		GET + " " + SWAP_INSTRUMENT_ORDER_ALGO:  per2s(20),
+	}
+}
+
+/*
+ Returned by requests rejected by the client side limiter in RateLimitFailFast mode.
+ errors.Is(err, ErrRateLimit) matches it as well as the server side 429.
+*/
+type RateLimitError struct {
+	Key        string // the matched uri constant, eg: "POST /api/spot/v3/orders"
+	Limit      RateLimit
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("okex: client rate limit %d/%s of %s exceeded, retry after %s",
+		e.Limit.Requests, e.Limit.Per, e.Key, e.RetryAfter)
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimit
+}
+
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long to wait before it may be used.
+// With failFast the token is only taken when no wait is needed.
+func (b *tokenBucket) reserve(now time.Time, failFast bool) time.Duration {
+	rate := float64(b.limit.Requests) / float64(b.limit.Per)
+	b.tokens += float64(now.Sub(b.last)) * rate
+	if b.tokens > float64(b.limit.Requests) {
+		b.tokens = float64(b.limit.Requests)
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	wait := time.Duration((1 - b.tokens) / rate)
+	if !failFast {
+		b.tokens--
+	}
+	return wait
+}
+
+// refund gives back a token reserved by a request which was abandoned while waiting.
+func (b *tokenBucket) refund() {
+	b.tokens++
+	if b.tokens > float64(b.limit.Requests) {
+		b.tokens = float64(b.limit.Requests)
+	}
+}
+
+type rateLimitRule struct {
+	key      string
+	method   string
+	segments []string
+	literals int
+}
+
+func (r *rateLimitRule) match(method string, segments []string) bool {
+	if (r.method != "" && r.method != method) || len(r.segments) != len(segments) {
+		return false
+	}
+	for i, s := range r.segments {
+		if !strings.HasPrefix(s, "{") && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type rateLimiter struct {
+	mode    RateLimitMode
+	rules   []*rateLimitRule
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	if config == nil {
+		return nil
+	}
+	limits := map[string]RateLimit{}
+	if !config.DisableDefaults {
+		limits = DefaultRateLimits()
+	}
+	for key, limit := range config.Limits {
+		limits[key] = limit
+	}
+
+	limiter := &rateLimiter{mode: config.Mode, buckets: map[string]*tokenBucket{}}
+	for key, limit := range limits {
+		if limit.Requests <= 0 || limit.Per <= 0 {
+			continue
+		}
+		rule := &rateLimitRule{key: key}
+		uri := key
+		if i := strings.Index(key, " "); i > 0 {
+			rule.method, uri = key[:i], key[i+1:]
+		}
+		rule.segments = strings.Split(strings.Trim(uri, "/"), "/")
+		for _, s := range rule.segments {
+			if !strings.HasPrefix(s, "{") {
+				rule.literals++
+			}
+		}
+		limiter.rules = append(limiter.rules, rule)
+		limiter.buckets[key] = &tokenBucket{limit: limit, tokens: float64(limit.Requests), last: time.Now()}
+	}
+	return limiter
+}
+
+// rule returns the most specific rule matching the request, method specific rules first.
+func (l *rateLimiter) rule(method, requestPath string) *rateLimitRule {
+	if i := strings.Index(requestPath, "?"); i >= 0 {
+		requestPath = requestPath[:i]
+	}
+	segments := strings.Split(strings.Trim(requestPath, "/"), "/")
+	var best *rateLimitRule
+	for _, r := range l.rules {
+		if !r.match(method, segments) {
+			continue
+		}
+		if best == nil || r.literals > best.literals ||
+			(r.literals == best.literals && r.method != "" && best.method == "") {
+			best = r
+		}
+	}
+	return best
+}
+
+/*
+ Wait for a token of the endpoint's bucket, or fail fast in RateLimitFailFast mode.
+*/
+func (l *rateLimiter) wait(ctx context.Context, method, requestPath string) error {
+	if l == nil {
+		return nil
+	}
+	rule := l.rule(method, requestPath)
+	if rule == nil {
+		return nil
+	}
+	failFast := l.mode == RateLimitFailFast
+	l.mu.Lock()
+	bucket := l.buckets[rule.key]
+	delay := bucket.reserve(time.Now(), failFast)
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	if failFast {
+		return &RateLimitError{Key: rule.key, Limit: bucket.limit, RetryAfter: delay}
+	}
+	if err := sleepCtx(ctx, delay); err != nil {
+		l.mu.Lock()
+		bucket.refund()
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
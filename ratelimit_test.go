@@ -0,0 +1,93 @@
+package okex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Rule(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitConfig{})
+
+	rule := limiter.rule(GET, "/api/spot/v3/orders/12345?instrument_id=BTC-USDT")
+	assert.Equal(t, GET+" "+SPOT_ORDERS_BY_ID, rule.key)
+	rule = limiter.rule(GET, "/api/spot/v3/orders_pending")
+	assert.Equal(t, GET+" "+SPOT_ORDERS_PENDING, rule.key)
+	rule = limiter.rule(POST, SPOT_ORDERS)
+	assert.Equal(t, POST+" "+SPOT_ORDERS, rule.key)
+	rule = limiter.rule(GET, "/api/spot/v3/instruments/ticker")
+	assert.Equal(t, GET+" "+SPOT_INSTRUMENTS_TICKER, rule.key)
+	assert.Nil(t, limiter.rule(GET, OKEX_TIME_URI))
+}
+
+func TestRateLimiter_FailFast(t *testing.T) {
+	var calls int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	c.limiter = newRateLimiter(&RateLimitConfig{
+		Mode:            RateLimitFailFast,
+		Limits:          map[string]RateLimit{OKEX_TIME_URI: {Requests: 2, Per: time.Minute}},
+		DisableDefaults: true,
+	})
+
+	_, err := c.GetServerTime()
+	assert.Nil(t, err)
+	_, err = c.GetServerTime()
+	assert.Nil(t, err)
+	_, err = c.GetServerTime()
+	var rateErr *RateLimitError
+	assert.True(t, errors.As(err, &rateErr))
+	assert.Equal(t, OKEX_TIME_URI, rateErr.Key)
+	assert.True(t, rateErr.RetryAfter > 0)
+	assert.True(t, errors.Is(err, ErrRateLimit))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	c.limiter = newRateLimiter(&RateLimitConfig{
+		Limits: map[string]RateLimit{OKEX_TIME_URI: {Requests: 1, Per: 100 * time.Millisecond}},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.GetServerTime()
+		assert.Nil(t, err)
+	}
+	assert.True(t, time.Since(start) >= 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetServerTimeCtx(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestRateLimiter_RefundCancelled(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitConfig{
+		Limits:          map[string]RateLimit{OKEX_TIME_URI: {Requests: 1, Per: 200 * time.Millisecond}},
+		DisableDefaults: true,
+	})
+	assert.Nil(t, limiter.wait(context.Background(), GET, OKEX_TIME_URI))
+
+	// abandoned waits give their tokens back
+	for i := 0; i < 5; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		assert.Equal(t, context.DeadlineExceeded, limiter.wait(ctx, GET, OKEX_TIME_URI))
+		cancel()
+	}
+
+	start := time.Now()
+	assert.Nil(t, limiter.wait(context.Background(), GET, OKEX_TIME_URI))
+	assert.True(t, time.Since(start) < 300*time.Millisecond)
+}
@@ -0,0 +1,151 @@
+package okex
+
+/*
+ Retry policy for rest requests.
+
+ GET requests are retried by Client.Request itself. Order placement is only
+ retried when the order carries a client_oid: after an ambiguous failure
+ (network error or 5xx, the order may or may not have reached the matching
+ engine) the order is looked up by client_oid first, and only resubmitted
+ when it does not exist. So a retry can never place the same order twice.
+*/
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"time"
+)
+
+type RetryPolicy struct {
+	// Total attempts, including the first one. <= 1 disables retrying.
+	MaxAttempts int
+	// Backoff before the second attempt, multiplied by Multiplier for every further attempt and capped by MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Randomize each backoff by +/- Jitter (0~1) of its value.
+	Jitter float64
+	// Error classes which are retried. Network errors are always retried.
+	RetryOn []ErrorClass
+}
+
+/*
+ 3 attempts, 200ms -> 400ms backoff, retry on 5xx and rate limit errors
+*/
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryOn:        []ErrorClass{ErrorClassServer, ErrorClassRateLimit},
+	}
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxAttempts > 1
+}
+
+// backoff returns the delay after the given (1-based) failed attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if isNetworkError(err) {
+		return true
+	}
+	class := ErrorClassOf(err)
+	for _, c := range p.RetryOn {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func isNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+/*
+ Whether the request may have been executed by the server although it failed.
+*/
+func isAmbiguousError(err error) bool {
+	return isNetworkError(err) || ErrorClassOf(err) == ErrorClassServer
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+/*
+ Place an order with retries. place sends the order once; lookup queries the
+ order by client_oid and reports whether it exists.
+ Without a retry policy or a client_oid the order is sent exactly once.
+*/
+func (client *Client) placeOrderWithRetry(ctx context.Context, clientOid string,
+	place func() error, lookup func() (bool, error)) error {
+	policy := client.Config.Retry
+	if !policy.enabled() || clientOid == "" {
+		return place()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := place()
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		if isAmbiguousError(err) {
+			found, lookupErr := lookup()
+			if lookupErr != nil && !errors.Is(lookupErr, ErrOrderNotFound) {
+				// the order state is unknown, resubmitting could double-fill
+				return err
+			}
+			if found {
+				return nil
+			}
+		}
+		if sleepCtx(ctx, policy.backoff(attempt)) != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package okex
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRetryTestPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetry_GetOnServerError(t *testing.T) {
+	var calls int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	serverTime, err := c.GetServerTime()
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-04-12T10:24:19.913Z", serverTime.Iso)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_GetNotRetriedOnBadRequest(t *testing.T) {
+	var calls int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":30023,"message":"required parameter cannot be blank"}`))
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	_, err := c.GetServerTime()
+	assert.True(t, errors.Is(err, ErrBadRequest))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_OrderWithoutClientOid(t *testing.T) {
+	var calls int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	_, _, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{Price: "6000", Size: "1", Type: "1"})
+	assert.True(t, errors.Is(err, ErrServer))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetry_OrderFoundByClientOid(t *testing.T) {
+	var posts, gets int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == POST {
+			atomic.AddInt32(&posts, 1)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		atomic.AddInt32(&gets, 1)
+		assert.Equal(t, "/api/swap/v3/orders/BTC-USD-SWAP/oid123", r.URL.Path)
+		w.Write([]byte(`{"order_id":"64-2a-26132f931-3","client_oid":"oid123","state":"0"}`))
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	_, result, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{ClientOid: "oid123", Price: "6000", Size: "1", Type: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "64-2a-26132f931-3", result.OrderId)
+	assert.Equal(t, "oid123", result.ClientOid)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&gets))
+}
+
+func TestRetry_OrderResubmittedWhenNotFound(t *testing.T) {
+	var posts int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == GET {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":35029,"message":"Order does not exist"}`))
+			return
+		}
+		if atomic.AddInt32(&posts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"client_oid":"oid123","order_id":"64-2a-26132f931-3","result":"true"}`))
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	_, result, err := c.PostSwapOrder("BTC-USD-SWAP", BasePlaceOrderInfo{ClientOid: "oid123", Price: "6000", Size: "1", Type: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "64-2a-26132f931-3", result.OrderId)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&posts))
+}
+
+func TestRetry_OrderNotResubmittedWhenLookupFails(t *testing.T) {
+	var posts int32
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == GET {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":30013,"message":"invalid sign"}`))
+			return
+		}
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer server.Close()
+	c.Config.Retry = newRetryTestPolicy()
+
+	var params FuturesNewOrderParams
+	params.InstrumentId = "BTC-USD-200626"
+	params.ClientOid = "oid123"
+	params.Type = "1"
+	params.Price = "6000"
+	params.Size = "1"
+	_, _, err := c.FuturesOrder(params)
+	assert.True(t, errors.Is(err, ErrServer))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&posts))
+}
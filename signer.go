@@ -0,0 +1,116 @@
+package okex
+
+/*
+ Request signing.
+
+ The rest client and the WS login sign their pre hash string through a Signer,
+ so the secret key doesn't have to live in the trading process: a
+ UnixSocketSigner asks a local signing daemon instead.
+*/
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Signer interface {
+	// Sign returns the base64 encoded signature of the pre hash string, @see PreHashString
+	Sign(message string) (string, error)
+}
+
+// HmacSigner signs with hmac sha256 + base64 over the secret key.
+type HmacSigner struct {
+	SecretKey string
+}
+
+func (s HmacSigner) Sign(message string) (string, error) {
+	return HmacSha256Base64Signer(message, s.SecretKey)
+}
+
+/*
+ Signer calling a local signing daemon over a unix socket.
+
+ Every message is sent as http POST /sign with the json body
+	{"api_key":"...","message":"2018-03-08T10:59:25.789ZGET/api/swap/v3/position"}
+ and the daemon answers 200 with
+	{"signature":"TO6uwdqz+31SIPkd4I+9NiZGmVH74dXi+Fd5X0EzzSQ="}
+*/
+type UnixSocketSigner struct {
+	// Which key the daemon signs with
+	ApiKey     string
+	SocketPath string
+	Timeout    time.Duration
+
+	httpClient *http.Client
+}
+
+func NewUnixSocketSigner(socketPath string, apiKey string) *UnixSocketSigner {
+	s := &UnixSocketSigner{ApiKey: apiKey, SocketPath: socketPath, Timeout: 5 * time.Second}
+	s.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", s.SocketPath)
+			},
+		},
+	}
+	return s
+}
+
+func (s *UnixSocketSigner) Sign(message string) (string, error) {
+	reqBody, err := json.Marshal(struct {
+		ApiKey  string `json:"api_key"`
+		Message string `json:"message"`
+	}{s.ApiKey, message})
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	request, err := http.NewRequestWithContext(ctx, POST, "http://signer/sign", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set(CONTENT_TYPE, APPLICATION_JSON)
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("okex: signer %s: http status %d: %s", s.SocketPath, response.StatusCode, body)
+	}
+	var result struct {
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if result.Signature == "" {
+		return "", fmt.Errorf("okex: signer %s: empty signature", s.SocketPath)
+	}
+	return result.Signature, nil
+}
+
+/*
+ The configured signer, or HMAC over the secret key.
+*/
+func newSigner(signer Signer, secretKey string) Signer {
+	if signer != nil {
+		return signer
+	}
+	return HmacSigner{SecretKey: secretKey}
+}
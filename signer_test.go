@@ -0,0 +1,64 @@
+package okex
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixSocketSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "okex-signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", socketPath)
+	assert.Nil(t, err)
+
+	secret := HmacSigner{SecretKey: "test-secret-key"}
+	daemon := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ApiKey  string `json:"api_key"`
+			Message string `json:"message"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(body, &req))
+		assert.Equal(t, "/sign", r.URL.Path)
+		assert.Equal(t, "test-api-key", req.ApiKey)
+		sign, _ := secret.Sign(req.Message)
+		w.Write([]byte(`{"signature":"` + sign + `"}`))
+	})}
+	go daemon.Serve(listener)
+	defer daemon.Close()
+
+	signer := NewUnixSocketSigner(socketPath, "test-api-key")
+	expected, _ := HmacSha256Base64Signer("message", "test-secret-key")
+	sign, err := signer.Sign("message")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, sign)
+
+	// the rest client signs through the daemon, without a secret key
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		preHash := PreHashString(r.Header.Get(OK_ACCESS_TIMESTAMP), r.Method, r.URL.RequestURI(), "")
+		expected, _ := secret.Sign(preHash)
+		assert.Equal(t, expected, r.Header.Get(OK_ACCESS_SIGN))
+		w.Write([]byte(`{"iso":"2020-04-12T10:24:19.913Z","epoch":"1586687059.913"}`))
+	})
+	defer server.Close()
+	config := c.Config
+	config.SecretKey = ""
+	config.Signer = signer
+	c = NewClient(config)
+	_, err = c.GetServerTime()
+	assert.Nil(t, err)
+}
+
+func TestUnixSocketSigner_Unavailable(t *testing.T) {
+	signer := NewUnixSocketSigner(filepath.Join(os.TempDir(), "okex-signer-missing.sock"), "test-api-key")
+	_, err := signer.Sign("message")
+	assert.Error(t, err)
+}
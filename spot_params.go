@@ -0,0 +1,91 @@
+package okex
+
+/*
+ OKEX spot and margin api request params
+*/
+
+/*
+ One order, of PostSpotOrders, PostMarginOrders or of a batch.
+ Type: limit or market. A limit order needs Price and Size, a market buy Notional
+ and a market sell Size.
+ MarginTrading: 1 spot, 2 margin. The margin api sets it to 2.
+*/
+type SpotOrderItem struct {
+	ClientOid     string  `json:"client_oid,omitempty"`
+	Type          string  `json:"type"`
+	Side          string  `json:"side"`
+	InstrumentId  string  `json:"instrument_id"`
+	OrderType     string  `json:"order_type,omitempty"`
+	Price         Decimal `json:"price,omitempty"`
+	Size          Decimal `json:"size,omitempty"`
+	Notional      Decimal `json:"notional,omitempty"`
+	MarginTrading string  `json:"margin_trading,omitempty"`
+}
+
+// Orders of one instrument to cancel, by order ids or by client oids
+type SpotCancelOrdersItem struct {
+	InstrumentId string   `json:"instrument_id"`
+	OrderIds     []string `json:"order_ids,omitempty"`
+	ClientOids   []string `json:"client_oids,omitempty"`
+}
+
+/*
+ Page of the spot and margin ledgers.
+ Type: 1 deposit, 2 withdrawal, 7 buy, 8 sell ... empty for all.
+*/
+type LedgerParams struct {
+	CursorPage
+	Type string
+}
+
+func (p *LedgerParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	if p.Type != "" {
+		params["type"] = p.Type
+	}
+	return params
+}
+
+// Status: 0 not repaid, 1 repaid, empty for all
+type MarginBorrowedParams struct {
+	CursorPage
+	Status string
+}
+
+func (p *MarginBorrowedParams) params() map[string]string {
+	if p == nil {
+		return NewParams()
+	}
+	params := p.CursorPage.addTo(nil)
+	if p.Status != "" {
+		params["status"] = p.Status
+	}
+	return params
+}
+
+/*
+ Depth of the book, zero values request the defaults.
+ Size: number of price levels, maximum 200.
+ Depth: price aggregation of the levels, eg: 0.1
+*/
+type SpotBookParams struct {
+	Size  int
+	Depth Decimal
+}
+
+func (p *SpotBookParams) params() map[string]string {
+	params := NewParams()
+	if p == nil {
+		return params
+	}
+	if p.Size > 0 {
+		params["size"] = Int2String(p.Size)
+	}
+	if p.Depth != "" {
+		params["depth"] = p.Depth.String()
+	}
+	return params
+}
@@ -1,6 +1,9 @@
 package okex
 
-import "strings"
+import (
+	"context"
+	"strings"
+)
 
 /*
 币币账户信息
@@ -11,11 +14,15 @@ HTTP请求
 GET /api/spot/v3/accounts
 
 */
-func (client *Client) GetSpotAccounts() (GetSpotAccountsResult, error) { //*[]map[string]interface{}
+func (client *Client) GetSpotAccounts() (GetSpotAccountsResult, error) {
+	return client.GetSpotAccountsCtx(context.Background())
+}
+
+func (client *Client) GetSpotAccountsCtx(ctx context.Context) (GetSpotAccountsResult, error) { //*[]map[string]interface{}
 	//r := []map[string]interface{}{}
 	var r GetSpotAccountsResult
 
-	if _, _, err := client.Request(GET, SPOT_ACCOUNTS, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SPOT_ACCOUNTS, nil, &r); err != nil {
 		return nil, err
 	}
 	return r, nil
@@ -30,9 +37,13 @@ HTTP请求
 GET /api/spot/v3/accounts/<currency>
 */
 func (client *Client) GetSpotAccountsCurrency(currency string) (GetSpotAccountsCurrencyResult, error) {
+	return client.GetSpotAccountsCurrencyCtx(context.Background(), currency)
+}
+
+func (client *Client) GetSpotAccountsCurrencyCtx(ctx context.Context, currency string) (GetSpotAccountsCurrencyResult, error) {
 	var r GetSpotAccountsCurrencyResult
 	uri := GetCurrencyUri(SPOT_ACCOUNTS_CURRENCY, currency)
-	_, _, err := client.Request(GET, uri, nil, &r)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
 	return r, err
 }
 
@@ -44,19 +55,19 @@ func (client *Client) GetSpotAccountsCurrency(currency string) (GetSpotAccountsC
 HTTP请求
 GET /api/spot/v3/accounts/<currency>/ledger
 */
-func (client *Client) GetSpotAccountsCurrencyLeger(currency string, optionalParams *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetSpotAccountsCurrencyLeger(currency string, params *LedgerParams) ([]SpotLedger, error) {
+	return client.GetSpotAccountsCurrencyLegerCtx(context.Background(), currency, params)
+}
 
-	baseUri := GetCurrencyUri(SPOT_ACCOUNTS_CURRENCY_LEDGER, currency)
-	uri := baseUri
-	if optionalParams != nil && len(*optionalParams) > 0 {
-		uri = BuildParams(baseUri, *optionalParams)
-	}
+func (client *Client) GetSpotAccountsCurrencyLegerCtx(ctx context.Context, currency string, params *LedgerParams) ([]SpotLedger, error) {
+	var r []SpotLedger
+
+	uri := pageUri(GetCurrencyUri(SPOT_ACCOUNTS_CURRENCY_LEDGER, currency), params.params())
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -66,25 +77,26 @@ func (client *Client) GetSpotAccountsCurrencyLeger(currency string, optionalPara
 限速规则：20次/2s
 HTTP请求
 GET /api/spot/v3/orders
+
+state: -2:失败 -1:撤单成功 0:等待成交 1:部分成交 2:完全成交 3:下单中 4:撤单中 6:未完成（等待成交+部分成交） 7:已完成（撤单成功+完全成交）
 */
-func (client *Client) GetSpotOrders(status, instrument_id string, options *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-
-	fullOptions := NewParams()
-	fullOptions["instrument_id"] = instrument_id
-	fullOptions["status"] = status
-	if options != nil && len(*options) > 0 {
-		fullOptions["from"] = (*options)["from"]
-		fullOptions["to"] = (*options)["to"]
-		fullOptions["limit"] = (*options)["limit"]
-	}
+func (client *Client) GetSpotOrders(state, instrumentId string, page *CursorPage) ([]SpotGetOrderResult, error) {
+	return client.GetSpotOrdersCtx(context.Background(), state, instrumentId, page)
+}
+
+func (client *Client) GetSpotOrdersCtx(ctx context.Context, state, instrumentId string, page *CursorPage) ([]SpotGetOrderResult, error) {
+	var r []SpotGetOrderResult
+
+	fullOptions := page.addTo(nil)
+	fullOptions["instrument_id"] = instrumentId
+	fullOptions["state"] = state
 
 	uri := BuildParams(SPOT_ORDERS, fullOptions)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -95,23 +107,23 @@ func (client *Client) GetSpotOrders(status, instrument_id string, options *map[s
 HTTP请求
 GET /api/spot/v3/orders_pending
 */
-func (client *Client) GetSpotOrdersPending(options *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-
-	fullOptions := NewParams()
-	uri := SPOT_ORDERS_PENDING
-	if options != nil && len(*options) > 0 {
-		fullOptions["instrument_id"] = (*options)["instrument_id"]
-		fullOptions["from"] = (*options)["from"]
-		fullOptions["to"] = (*options)["to"]
-		fullOptions["limit"] = (*options)["limit"]
-		uri = BuildParams(SPOT_ORDERS_PENDING, fullOptions)
+func (client *Client) GetSpotOrdersPending(instrumentId string, page *CursorPage) ([]SpotGetOrderResult, error) {
+	return client.GetSpotOrdersPendingCtx(context.Background(), instrumentId, page)
+}
+
+func (client *Client) GetSpotOrdersPendingCtx(ctx context.Context, instrumentId string, page *CursorPage) ([]SpotGetOrderResult, error) {
+	var r []SpotGetOrderResult
+
+	fullOptions := page.addTo(nil)
+	if instrumentId != "" {
+		fullOptions["instrument_id"] = instrumentId
 	}
+	uri := pageUri(SPOT_ORDERS_PENDING, fullOptions)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -126,13 +138,17 @@ GET /api/spot/v3/orders/<order_id>
 GET /api/spot/v3/orders/<client_oid>
 */
 func (client *Client) GetSpotOrdersById(instrumentId, orderOrClientId string) (SpotGetOrderResult, error) {
+	return client.GetSpotOrdersByIdCtx(context.Background(), instrumentId, orderOrClientId)
+}
+
+func (client *Client) GetSpotOrdersByIdCtx(ctx context.Context, instrumentId, orderOrClientId string) (SpotGetOrderResult, error) {
 	var order SpotGetOrderResult
 	uri := strings.Replace(SPOT_ORDERS_BY_ID, "{order_client_id}", orderOrClientId, -1)
 	options := NewParams()
 	options["instrument_id"] = instrumentId
 	uri = BuildParams(uri, options)
 
-	_, _, err := client.Request(GET, uri, nil, &order)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &order)
 	return order, err
 }
 
@@ -144,24 +160,25 @@ func (client *Client) GetSpotOrdersById(instrumentId, orderOrClientId string) (S
 HTTP请求
 GET /api/spot/v3/fills
 */
-func (client *Client) GetSpotFills(order_id, instrument_id string, options *map[string]string) (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-
-	fullOptions := NewParams()
-	fullOptions["instrument_id"] = instrument_id
-	fullOptions["order_id"] = order_id
-	if options != nil && len(*options) > 0 {
-		fullOptions["from"] = (*options)["from"]
-		fullOptions["to"] = (*options)["to"]
-		fullOptions["limit"] = (*options)["limit"]
+func (client *Client) GetSpotFills(orderId, instrumentId string, page *CursorPage) ([]FillItem, error) {
+	return client.GetSpotFillsCtx(context.Background(), orderId, instrumentId, page)
+}
+
+func (client *Client) GetSpotFillsCtx(ctx context.Context, orderId, instrumentId string, page *CursorPage) ([]FillItem, error) {
+	var r []FillItem
+
+	fullOptions := page.addTo(nil)
+	fullOptions["instrument_id"] = instrumentId
+	if orderId != "" {
+		fullOptions["order_id"] = orderId
 	}
 
 	uri := BuildParams(SPOT_FILLS, fullOptions)
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -174,13 +191,17 @@ func (client *Client) GetSpotFills(order_id, instrument_id string, options *map[
 HTTP请求
 GET /api/spot/v3/instruments
 */
-func (client *Client) GetSpotInstruments() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetSpotInstruments() ([]SpotInstrument, error) {
+	return client.GetSpotInstrumentsCtx(context.Background())
+}
+
+func (client *Client) GetSpotInstrumentsCtx(ctx context.Context) ([]SpotInstrument, error) {
+	var r []SpotInstrument
 
-	if _, _, err := client.Request(GET, SPOT_INSTRUMENTS, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SPOT_INSTRUMENTS, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -191,21 +212,15 @@ func (client *Client) GetSpotInstruments() (*[]map[string]interface{}, error) {
 HTTP请求
 GET /api/spot/v3/instruments/<instrument_id>/book
 */
-func (client *Client) GetSpotInstrumentBook(instrumentId string, optionalParams map[string]string) (SpotInstrumentBookResult, error) {
+func (client *Client) GetSpotInstrumentBook(instrumentId string, params *SpotBookParams) (SpotInstrumentBookResult, error) {
+	return client.GetSpotInstrumentBookCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetSpotInstrumentBookCtx(ctx context.Context, instrumentId string, params *SpotBookParams) (SpotInstrumentBookResult, error) {
 	var book SpotInstrumentBookResult
-	uri := GetInstrumentIdUri(SPOT_INSTRUMENT_BOOK, instrumentId)
-	if optionalParams != nil && len(optionalParams) > 0 {
-		optionals := NewParams()
-		if size, ok := optionalParams["size"]; ok {
-			optionals["size"] = size
-		}
-		if depth, ok := optionalParams["depth"]; ok {
-			optionals["depth"] = depth
-		}
-		uri = BuildParams(uri, optionals)
-	}
+	uri := pageUri(GetInstrumentIdUri(SPOT_INSTRUMENT_BOOK, instrumentId), params.params())
 
-	_, _, err := client.Request(GET, uri, nil, &book)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &book)
 	return book, err
 }
 
@@ -217,13 +232,17 @@ func (client *Client) GetSpotInstrumentBook(instrumentId string, optionalParams
 HTTP请求
 GET /api/spot/v3/instruments/ticker
 */
-func (client *Client) GetSpotInstrumentsTicker() (*[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
+func (client *Client) GetSpotInstrumentsTicker() ([]SpotTicker, error) {
+	return client.GetSpotInstrumentsTickerCtx(context.Background())
+}
 
-	if _, _, err := client.Request(GET, SPOT_INSTRUMENTS_TICKER, nil, &r); err != nil {
+func (client *Client) GetSpotInstrumentsTickerCtx(ctx context.Context) ([]SpotTicker, error) {
+	var r []SpotTicker
+
+	if _, _, err := client.RequestCtx(ctx, GET, SPOT_INSTRUMENTS_TICKER, nil, &r); err != nil {
 		return nil, err
 	}
-	return &r, nil
+	return r, nil
 }
 
 /*
@@ -234,14 +253,16 @@ func (client *Client) GetSpotInstrumentsTicker() (*[]map[string]interface{}, err
 HTTP请求
 GET /api/spot/v3/instruments/<instrument-id>/ticker
 */
-func (client *Client) GetSpotInstrumentTicker(instrument_id string) (*map[string]interface{}, error) {
-	r := map[string]interface{}{}
+func (client *Client) GetSpotInstrumentTicker(instrumentId string) (SpotTicker, error) {
+	return client.GetSpotInstrumentTickerCtx(context.Background(), instrumentId)
+}
 
-	uri := GetInstrumentIdUri(SPOT_INSTRUMENT_TICKER, instrument_id)
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
-		return nil, err
-	}
-	return &r, nil
+func (client *Client) GetSpotInstrumentTickerCtx(ctx context.Context, instrumentId string) (SpotTicker, error) {
+	var r SpotTicker
+
+	uri := GetInstrumentIdUri(SPOT_INSTRUMENT_TICKER, instrumentId)
+	_, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	return r, err
 }
 
 /*
@@ -252,23 +273,20 @@ func (client *Client) GetSpotInstrumentTicker(instrument_id string) (*map[string
 HTTP请求
 GET /api/spot/v3/instruments/<instrument_id>/trades
 */
-func (client *Client) GetSpotInstrumentTrade(instrument_id string, options *map[string]string) ([]byte, *[]map[string]interface{}, error) {
-	r := []map[string]interface{}{}
-
-	uri := GetInstrumentIdUri(SPOT_INSTRUMENT_TRADES, instrument_id)
-	fullOptions := NewParams()
-	if options != nil && len(*options) > 0 {
-		fullOptions["from"] = (*options)["from"]
-		fullOptions["to"] = (*options)["to"]
-		fullOptions["limit"] = (*options)["limit"]
-		uri = BuildParams(uri, fullOptions)
-	}
+func (client *Client) GetSpotInstrumentTrade(instrumentId string, page *CursorPage) ([]byte, []SpotTrade, error) {
+	return client.GetSpotInstrumentTradeCtx(context.Background(), instrumentId, page)
+}
 
-	var respBody []byte
-	if respBody, _, err := client.Request(GET, uri, nil, &r); err != nil {
+func (client *Client) GetSpotInstrumentTradeCtx(ctx context.Context, instrumentId string, page *CursorPage) ([]byte, []SpotTrade, error) {
+	var r []SpotTrade
+
+	uri := pageUri(GetInstrumentIdUri(SPOT_INSTRUMENT_TRADES, instrumentId), page.addTo(nil))
+
+	respBody, _, err := client.RequestCtx(ctx, GET, uri, nil, &r)
+	if err != nil {
 		return respBody, nil, err
 	}
-	return respBody, &r, nil
+	return respBody, r, nil
 }
 
 /*
@@ -279,23 +297,19 @@ func (client *Client) GetSpotInstrumentTrade(instrument_id string, options *map[
 HTTP请求
 GET /api/spot/v3/instruments/<instrument_id>/candles
 */
-func (client *Client) GetSpotInstrumentCandles(instrumentID string, options *map[string]string) ([]byte, *[]interface{}, error) {
-	r := []interface{}{}
-
-	uri := GetInstrumentIdUri(SPOT_INSTRUMENT_CANDLES, instrumentID)
-	fullOptions := NewParams()
-	if options != nil && len(*options) > 0 {
-		fullOptions["start"] = (*options)["start"]
-		fullOptions["end"] = (*options)["end"]
-		fullOptions["granularity"] = (*options)["granularity"]
-		uri = BuildParams(uri, fullOptions)
-	}
+func (client *Client) GetSpotInstrumentCandles(instrumentID string, params *CandlesParams) ([]Candle, error) {
+	return client.GetSpotInstrumentCandlesCtx(context.Background(), instrumentID, params)
+}
 
-	var respBody []byte
-	if respBody, _, err := client.Request(GET, uri, nil, &r); err != nil {
-		return respBody, nil, err
+func (client *Client) GetSpotInstrumentCandlesCtx(ctx context.Context, instrumentID string, params *CandlesParams) ([]Candle, error) {
+	var r []Candle
+
+	uri := pageUri(GetInstrumentIdUri(SPOT_INSTRUMENT_CANDLES, instrumentID), params.params())
+
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
+		return nil, err
 	}
-	return respBody, &r, nil
+	return r, nil
 }
 
 /*
@@ -308,27 +322,30 @@ OKEx币币交易提供限价单和市价单两种下单模式(更多下单模式
 HTTP请求
 POST /api/spot/v3/orders
 */
-func (client *Client) PostSpotOrders(side, instrumentID string, optionalOrderInfo *map[string]string) (respBody []byte, result SpotNewOrderResult, err error) {
+func (client *Client) PostSpotOrders(order SpotOrderItem) (respBody []byte, result SpotNewOrderResult, err error) {
+	return client.PostSpotOrdersCtx(context.Background(), order)
+}
+
+func (client *Client) PostSpotOrdersCtx(ctx context.Context, order SpotOrderItem) (respBody []byte, result SpotNewOrderResult, err error) {
 	var r SpotNewOrderResult
-	postParams := NewParams()
-	postParams["side"] = side
-	postParams["instrument_id"] = instrumentID
-
-	if optionalOrderInfo != nil && len(*optionalOrderInfo) > 0 {
-		postParams["client_oid"] = (*optionalOrderInfo)["client_oid"]
-		postParams["type"] = (*optionalOrderInfo)["type"]
-		postParams["margin_trading"] = (*optionalOrderInfo)["margin_trading"]
-
-		if postParams["type"] == "limit" {
-			postParams["price"] = (*optionalOrderInfo)["price"]
-			postParams["size"] = (*optionalOrderInfo)["size"]
-		} else if postParams["type"] == "market" {
-			postParams["size"] = (*optionalOrderInfo)["size"]
-			postParams["notional"] = (*optionalOrderInfo)["notional"]
-		}
+	if err = client.validateOrder(ctx, order.InstrumentId, order.Price, order.Size, ""); err != nil {
+		return nil, r, err
 	}
 
-	respBody, _, err = client.Request(POST, SPOT_ORDERS, postParams, &r)
+	clientOid := order.ClientOid
+	err = client.placeOrderWithRetry(ctx, clientOid, func() (err error) {
+		r = SpotNewOrderResult{}
+		respBody, _, err = client.RequestCtx(ctx, POST, SPOT_ORDERS, order, &r)
+		return err
+	}, func() (bool, error) {
+		placed, err := client.GetSpotOrdersByIdCtx(ctx, order.InstrumentId, clientOid)
+		if err != nil || placed.OrderID == "" {
+			return false, err
+		}
+		r = SpotNewOrderResult{ClientOid: clientOid, OrderID: placed.OrderID, Result: true}
+		respBody = nil
+		return true, nil
+	})
 	return respBody, r, err
 }
 
@@ -340,14 +357,18 @@ func (client *Client) PostSpotOrders(side, instrumentID string, optionalOrderInf
 HTTP请求
 POST /api/spot/v3/batch_orders
 */
-func (client *Client) PostSpotBatchOrders(orderInfos *[]map[string]string) ([]byte, *map[string]interface{}, error) {
-	r := map[string]interface{}{}
+func (client *Client) PostSpotBatchOrders(orders []SpotOrderItem) ([]byte, SpotBatchOrdersResult, error) {
+	return client.PostSpotBatchOrdersCtx(context.Background(), orders)
+}
+
+func (client *Client) PostSpotBatchOrdersCtx(ctx context.Context, orders []SpotOrderItem) ([]byte, SpotBatchOrdersResult, error) {
+	var r SpotBatchOrdersResult
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, SPOT_BATCH_ORDERS, orderInfos, &r); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, SPOT_BATCH_ORDERS, orders, &r); err != nil {
 		return respBody, nil, err
 	}
-	return respBody, &r, nil
+	return respBody, r, nil
 }
 
 /*
@@ -360,19 +381,16 @@ POST /api/spot/v3/cancel_orders/<order_id>
 或者
 POST /api/spot/v3/cancel_orders/<client_oid>
 */
-func (client *Client) PostSpotCancelOrders(instrumentId, orderOrClientId string) ([]byte, *map[string]interface{}, error) {
-	r := map[string]interface{}{}
-
-	uri := strings.Replace(SPOT_CANCEL_ORDERS_BY_ID, "{order_client_id}", orderOrClientId, -1)
-	options := NewParams()
-	options["instrument_id"] = instrumentId
+func (client *Client) PostSpotCancelOrders(instrumentId, orderOrClientId string) ([]byte, SpotNewOrderResult, error) {
+	return client.PostSpotCancelOrdersCtx(context.Background(), instrumentId, orderOrClientId)
+}
 
-	var respBody []byte
-	if respBody, _, err := client.Request(POST, uri, options, &r); err != nil {
-		return respBody, nil, err
-	}
-	return respBody, &r, nil
+func (client *Client) PostSpotCancelOrdersCtx(ctx context.Context, instrumentId, orderOrClientId string) ([]byte, SpotNewOrderResult, error) {
+	var r SpotNewOrderResult
 
+	uri := strings.Replace(SPOT_CANCEL_ORDERS_BY_ID, "{order_client_id}", orderOrClientId, -1)
+	respBody, _, err := client.RequestCtx(ctx, POST, uri, SpotCancelOrdersItem{InstrumentId: instrumentId}, &r)
+	return respBody, r, err
 }
 
 /*
@@ -383,11 +401,16 @@ func (client *Client) PostSpotCancelOrders(instrumentId, orderOrClientId string)
 HTTP请求
 POST /api/spot/v3/cancel_batch_orders
 */
-func (client *Client) PostSpotCancelBatchOrders(orderInfos *[]map[string]interface{}) ([]byte, *map[string]interface{}, error) {
-	r := map[string]interface{}{}
+func (client *Client) PostSpotCancelBatchOrders(orders []SpotCancelOrdersItem) ([]byte, SpotBatchOrdersResult, error) {
+	return client.PostSpotCancelBatchOrdersCtx(context.Background(), orders)
+}
+
+func (client *Client) PostSpotCancelBatchOrdersCtx(ctx context.Context, orders []SpotCancelOrdersItem) ([]byte, SpotBatchOrdersResult, error) {
+	var r SpotBatchOrdersResult
 	var respBody []byte
-	if respBody, _, err := client.Request(POST, SPOT_CANCEL_BATCH_ORDERS, orderInfos, &r); err != nil {
+	var err error
+	if respBody, _, err = client.RequestCtx(ctx, POST, SPOT_CANCEL_BATCH_ORDERS, orders, &r); err != nil {
 		return respBody, nil, err
 	}
-	return respBody, &r, nil
+	return respBody, r, nil
 }
@@ -2,7 +2,10 @@ package okex
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,45 +36,38 @@ func TestGetSpotAccountsCurrencyLeger(t *testing.T) {
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
-	options := map[string]string{}
-	options["from"] = "1"
-	options["to"] = "2"
-	options["limit"] = "100"
-
-	ac2, err2 := c.GetSpotAccountsCurrencyLeger("btc", &options)
+	ac2, err2 := c.GetSpotAccountsCurrencyLeger("btc", &LedgerParams{CursorPage: CursorPage{Limit: 100}})
 	assert.True(t, ac2 != nil && err2 == nil)
 }
 
 func TestGetSpotOrders(t *testing.T) {
 	c := NewTestClient()
-	ac, err := c.GetSpotOrders("filled", "BTC-USDT", nil)
+	ac, err := c.GetSpotOrders("2", "BTC-USDT", nil)
 	assert.True(t, err == nil)
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
 	// Fore. 20190305. TODO: {"message":"System error"} returned by following request.
 	// Url: http://coinmainweb.new.docker.okex.com/api/spot/v3/fills?instrument_id=BTC-USDT&order_id=2365709152770048
-	filledOrderId := (*ac)[0]["order_id"].(string)
+	filledOrderId := ac[0].OrderID
 	sf, err := c.GetSpotFills(filledOrderId, "BTC-USDT", nil)
 	assert.True(t, sf != nil && err == nil)
 }
 
 func TestGetSpotOrdersPending(t *testing.T) {
 	c := NewTestClient()
-	ac, err := c.GetSpotOrdersPending(nil)
+	ac, err := c.GetSpotOrdersPending("", nil)
 	assert.True(t, err == nil)
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
-	options := NewParams()
-	options["instrument_id"] = "BTC-USDT"
-	ac, err = c.GetSpotOrdersPending(&options)
+	ac, err = c.GetSpotOrdersPending("BTC-USDT", nil)
 	assert.True(t, err == nil)
 	jstr, _ = Struct2JsonString(ac)
 	println(jstr)
 
-	testOrderId := (*ac)[0]["order_id"]
-	_, err = c.GetSpotOrdersById("BTC-USDT", testOrderId.(string))
+	testOrderId := ac[0].OrderID
+	_, err = c.GetSpotOrdersById("BTC-USDT", testOrderId)
 	assert.True(t, err == nil)
 }
 
@@ -114,12 +110,7 @@ func TestGetSpotInstrumentTrade(t *testing.T) {
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
 
-	options := map[string]string{}
-	options["from"] = "1"
-	options["to"] = "2"
-	options["limit"] = "100"
-
-	_, ac2, err := c.GetSpotInstrumentTrade("BTC-USDT", &options)
+	_, ac2, err := c.GetSpotInstrumentTrade("BTC-USDT", &CursorPage{Limit: 100})
 	assert.True(t, err == nil)
 	jstr, _ = Struct2JsonString(ac2)
 	println(jstr)
@@ -127,7 +118,7 @@ func TestGetSpotInstrumentTrade(t *testing.T) {
 
 func TestGetSpotInstrumentCandles(t *testing.T) {
 	c := NewTestClient()
-	_, ac, err := c.GetSpotInstrumentCandles("BTC-USDT", nil)
+	ac, err := c.GetSpotInstrumentCandles("BTC-USDT", nil)
 	assert.True(t, err == nil)
 	jstr, _ := Struct2JsonString(ac)
 	println(jstr)
@@ -136,19 +127,14 @@ func TestGetSpotInstrumentCandles(t *testing.T) {
 func TestPostSpotOrders(t *testing.T) {
 	c := NewTestClient()
 
-	optionals := NewParams()
-	optionals["type"] = "limit"
-	optionals["price"] = "100"
-	optionals["size"] = "0.01"
-
-	_, r0, err := c.PostSpotOrders("sell", "btc-usdt", &optionals)
+	_, r0, err := c.PostSpotOrders(SpotOrderItem{Type: "limit", Side: "sell", InstrumentId: "btc-usdt", Price: "100", Size: "0.01"})
 	assert.True(t, err == nil)
 	jstr, _ := Struct2JsonString(r0)
 	println(jstr)
 
 	orderId := r0.OrderID
 	_, r, err := c.PostSpotCancelOrders("btc-usdt", orderId)
-	assert.True(t, r != nil && err == nil)
+	assert.True(t, r.Result && err == nil)
 	jstr, _ = Struct2JsonString(r)
 	println(jstr)
 
@@ -157,12 +143,12 @@ func TestPostSpotOrders(t *testing.T) {
 func TestClient_PostSpotBatchOrders(t *testing.T) {
 	c := NewTestClient()
 
-	orderInfos := []map[string]string{
-		map[string]string{"client_oid": "w20180728w", "instrument_id": "btc-usdt", "side": "sell", "type": "limit", "size": "0.001", "price": "10001", "margin_trading ": "1"},
-		map[string]string{"client_oid": "r20180728r", "instrument_id": "btc-usdt", "side": "sell", "type": "limit", " size ": "0.001", "notional": "10002", "margin_trading ": "1"},
+	orderInfos := []SpotOrderItem{
+		{ClientOid: "w20180728w", InstrumentId: "btc-usdt", Side: "sell", Type: "limit", Size: "0.001", Price: "10001", MarginTrading: "1"},
+		{ClientOid: "r20180728r", InstrumentId: "btc-usdt", Side: "sell", Type: "limit", Size: "0.001", Price: "10002", MarginTrading: "1"},
 	}
 
-	_, r, err := c.PostSpotBatchOrders(&orderInfos)
+	_, r, err := c.PostSpotBatchOrders(orderInfos)
 	assert.True(t, r != nil && err == nil)
 	jstr, _ := Struct2JsonString(r)
 	println(jstr)
@@ -171,13 +157,98 @@ func TestClient_PostSpotBatchOrders(t *testing.T) {
 func TestClient_PostSpotCancelBatchOrders(t *testing.T) {
 	c := NewTestClient()
 
-	orderInfos := []map[string]interface{}{
-		map[string]interface{}{"instrument_id": "btc-usdt", "client_oid": "16ee593327162368"},
-		map[string]interface{}{"instrument_id": "ltc-usdt", "client_oid": "243464oo234465"},
+	orderInfos := []SpotCancelOrdersItem{
+		{InstrumentId: "btc-usdt", ClientOids: []string{"16ee593327162368"}},
+		{InstrumentId: "ltc-usdt", ClientOids: []string{"243464oo234465"}},
 	}
 
-	_, r, err := c.PostSpotCancelBatchOrders(&orderInfos)
+	_, r, err := c.PostSpotCancelBatchOrders(orderInfos)
 	assert.True(t, r != nil && err == nil)
 	jstr, _ := Struct2JsonString(r)
 	println(jstr)
 }
+
+func TestClient_SpotTypedResults(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SPOT_ORDERS:
+			assert.Equal(t, "2", r.URL.Query().Get("state"))
+			assert.Equal(t, "5", r.URL.Query().Get("limit"))
+			assert.Equal(t, "", r.URL.Query().Get("before"))
+			w.Write([]byte(`[{"order_id":"2510789768709120","price":"3.927","size":"0.1","filled_size":"0.1","state":"2",` +
+				`"instrument_id":"EOS-USDT","side":"buy","created_at":"2019-03-15T02:39:03.000Z","timestamp":"2019-03-15T02:39:03.000Z"}]`))
+		case SPOT_INSTRUMENTS:
+			w.Write([]byte(`[{"base_currency":"BTC","instrument_id":"BTC-USDT","min_size":"0.001","quote_currency":"USDT","size_increment":"0.00000001","tick_size":"0.1"}]`))
+		case SPOT_BATCH_ORDERS:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `[{"type":"limit","side":"buy","instrument_id":"btc-usdt","price":"6500.1","size":"0.01"}]`, string(body))
+			w.Write([]byte(`{"btc-usdt":[{"client_oid":"","error_code":"0","error_message":"","order_id":"2510832677225473","result":true}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	orders, err := c.GetSpotOrders("2", "EOS-USDT", &CursorPage{Limit: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("3.927"), orders[0].Price)
+	assert.Equal(t, 2, orders[0].State)
+
+	instruments, err := c.GetSpotInstruments()
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.1"), instruments[0].TickSize)
+	assert.Equal(t, "USDT", instruments[0].QuoteCurrency)
+
+	_, placed, err := c.PostSpotBatchOrders([]SpotOrderItem{{InstrumentId: "btc-usdt", Side: "buy", Type: "limit", Price: "6500.1", Size: "0.01"}})
+	assert.Nil(t, err)
+	assert.True(t, placed["btc-usdt"][0].Result)
+	assert.Equal(t, "2510832677225473", placed["btc-usdt"][0].OrderID)
+}
+
+func TestClient_SpotTypedParams(t *testing.T) {
+	c, server := newLocalTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/spot/v3/instruments/BTC-USDT/book":
+			assert.Equal(t, "5", r.URL.Query().Get("size"))
+			assert.Equal(t, "0.1", r.URL.Query().Get("depth"))
+			w.Write([]byte(`{"asks":[],"bids":[],"timestamp":"2019-03-15T02:39:03.000Z"}`))
+		case "/api/spot/v3/instruments/BTC-USDT/candles":
+			assert.Equal(t, "3600", r.URL.Query().Get("granularity"))
+			assert.Equal(t, "2020-01-01T00:00:00.000Z", r.URL.Query().Get("start"))
+			assert.Equal(t, "", r.URL.Query().Get("end"))
+			w.Write([]byte(`[]`))
+		case SPOT_ORDERS:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"client_oid":"a1","type":"limit","side":"buy","instrument_id":"BTC-USDT","order_type":"1","price":"6500.1","size":"0.01"}`, string(body))
+			w.Write([]byte(`{"client_oid":"a1","error_code":"","error_message":"","order_id":"2510832677225473","result":true}`))
+		case MARGIN_ORDERS:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, `{"type":"market","side":"buy","instrument_id":"BTC-USDT","notional":"100","margin_trading":"2"}`, string(body))
+			w.Write([]byte(`{"client_oid":"","error_code":"","error_message":"","order_id":"2510832677225474","result":true}`))
+		case MARGIN_FILLS:
+			assert.Equal(t, "BTC-USDT", r.URL.Query().Get("instrument_id"))
+			assert.Equal(t, "", r.URL.Query().Get("order_id"))
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	_, err := c.GetSpotInstrumentBook("BTC-USDT", &SpotBookParams{Size: 5, Depth: "0.1"})
+	assert.Nil(t, err)
+	_, err = c.GetSpotInstrumentCandles("BTC-USDT", &CandlesParams{Granularity: CANDLES_1HOUR, Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Nil(t, err)
+
+	_, placed, err := c.PostSpotOrders(SpotOrderItem{ClientOid: "a1", Type: "limit", Side: "buy", InstrumentId: "BTC-USDT",
+		OrderType: "1", Price: "6500.1", Size: "0.01"})
+	assert.Nil(t, err)
+	assert.Equal(t, "2510832677225473", placed.OrderID)
+	_, margin, err := c.PostMarginOrders(SpotOrderItem{Type: "market", Side: "buy", InstrumentId: "BTC-USDT", Notional: "100"})
+	assert.Nil(t, err)
+	assert.Equal(t, "2510832677225474", margin.OrderID)
+
+	_, err = c.GetMarginFills("BTC-USDT", "", &CursorPage{Limit: 10})
+	assert.Nil(t, err)
+}
@@ -5,13 +5,13 @@ import "time"
 type GetSpotAccountsResult []GetSpotAccountsResultItem
 
 type GetSpotAccountsResultItem struct {
-	Frozen    string  `json:"frozen"`
-	Hold      string  `json:"hold"`
+	Frozen    Decimal `json:"frozen"`
+	Hold      Decimal `json:"hold"`
 	ID        string  `json:"id"`
 	Currency  string  `json:"currency"`
-	Balance   float64 `json:"balance,string"`
-	Available float64 `json:"available,string"`
-	Holds     string  `json:"holds"`
+	Balance   Decimal `json:"balance"`
+	Available Decimal `json:"available"`
+	Holds     Decimal `json:"holds"`
 }
 
 // price	String	价格
@@ -34,17 +34,17 @@ type SpotNewOrderResult struct {
 type SpotGetOrderResult struct {
 	ClientOid      string    `json:"client_oid"`
 	CreatedAt      time.Time `json:"created_at"`
-	FilledNotional float64   `json:"filled_notional,string"`
-	FilledSize     float64   `json:"filled_size,string"`
-	Funds          string    `json:"funds"`
+	FilledNotional Decimal   `json:"filled_notional"`
+	FilledSize     Decimal   `json:"filled_size"`
+	Funds          Decimal   `json:"funds"`
 	InstrumentID   string    `json:"instrument_id"`
-	Notional       string    `json:"notional"`
+	Notional       Decimal   `json:"notional"`
 	OrderID        string    `json:"order_id"`
 	OrderType      string    `json:"order_type"`
-	Price          string    `json:"price"`
+	Price          Decimal   `json:"price"`
 	ProductID      string    `json:"product_id"`
 	Side           string    `json:"side"`
-	Size           string    `json:"size"`
+	Size           Decimal   `json:"size"`
 	Status         string    `json:"status"`
 	State          int       `json:"state,string"`
 	Timestamp      time.Time `json:"timestamp"`
@@ -52,11 +52,71 @@ type SpotGetOrderResult struct {
 }
 
 type GetSpotAccountsCurrencyResult struct {
-	Frozen    string `json:"frozen"`
-	Hold      string `json:"hold"`
-	ID        string `json:"id"`
-	Currency  string `json:"currency"`
-	Balance   string `json:"balance"`
-	Available string `json:"available"`
-	Holds     string `json:"holds"`
+	Frozen    Decimal `json:"frozen"`
+	Hold      Decimal `json:"hold"`
+	ID        string  `json:"id"`
+	Currency  string  `json:"currency"`
+	Balance   Decimal `json:"balance"`
+	Available Decimal `json:"available"`
+	Holds     Decimal `json:"holds"`
+}
+
+type SpotLedgerDetails struct {
+	OrderID      string `json:"order_id"`
+	InstrumentID string `json:"instrument_id"`
+	ProductID    string `json:"product_id"`
+}
+
+// A spot or margin ledger entry, InstrumentID is set by the margin ledger only
+type SpotLedger struct {
+	LedgerID     string            `json:"ledger_id"`
+	InstrumentID string            `json:"instrument_id,omitempty"`
+	Currency     string            `json:"currency"`
+	Balance      Decimal           `json:"balance"`
+	Amount       Decimal           `json:"amount"`
+	Type         string            `json:"type"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Timestamp    time.Time         `json:"timestamp"`
+	Details      SpotLedgerDetails `json:"details"`
+}
+
+type SpotInstrument struct {
+	InstrumentID  string  `json:"instrument_id"`
+	BaseCurrency  string  `json:"base_currency"`
+	QuoteCurrency string  `json:"quote_currency"`
+	MinSize       Decimal `json:"min_size"`
+	SizeIncrement Decimal `json:"size_increment"`
+	TickSize      Decimal `json:"tick_size"`
+	Category      string  `json:"category"`
 }
+
+type SpotTicker struct {
+	InstrumentID   string    `json:"instrument_id"`
+	ProductID      string    `json:"product_id"`
+	Last           Decimal   `json:"last"`
+	LastQty        Decimal   `json:"last_qty"`
+	BestBid        Decimal   `json:"best_bid"`
+	BestBidSize    Decimal   `json:"best_bid_size"`
+	BestAsk        Decimal   `json:"best_ask"`
+	BestAskSize    Decimal   `json:"best_ask_size"`
+	Bid            Decimal   `json:"bid"`
+	Ask            Decimal   `json:"ask"`
+	Open24h        Decimal   `json:"open_24h"`
+	High24h        Decimal   `json:"high_24h"`
+	Low24h         Decimal   `json:"low_24h"`
+	BaseVolume24h  Decimal   `json:"base_volume_24h"`
+	QuoteVolume24h Decimal   `json:"quote_volume_24h"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type SpotTrade struct {
+	TradeID   string    `json:"trade_id"`
+	Price     Decimal   `json:"price"`
+	Size      Decimal   `json:"size"`
+	Side      string    `json:"side"`
+	Time      time.Time `json:"time"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// The placed or canceled orders by instrument id (lower case), eg: "btc-usdt"
+type SpotBatchOrdersResult map[string][]SpotNewOrderResult
@@ -0,0 +1,194 @@
+package okex
+
+/*
+ Websocket of the spot and margin markets.
+
+ Margin shares the spot channels: the margin account of an instrument is
+ pushed by spot/margin_account, margin orders by spot/order with
+ WSSpotOrder.MarginTrading "2".
+*/
+
+import (
+	"fmt"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	TableSpotTicker        = "spot/ticker"         // 公共-Ticker频道
+	TableSpotTrade         = "spot/trade"          // 公共-交易频道
+	TableSpotDepthL2Tbt    = "spot/depth_l2_tbt"   // 公共-400档增量数据频道
+	TableSpotCandle        = "spot/candle"         // 公共-K线频道，eg: spot/candle60s
+	TableSpotAccount       = "spot/account"        // 用户币币账户频道，按币种订阅
+	TableSpotMarginAccount = "spot/margin_account" // 用户币币杠杆账户频道，按币对订阅
+	TableSpotOrder         = "spot/order"          // 用户交易频道
+)
+
+type SpotWS struct {
+	*WSEngine
+
+	tickersCallback         func(tickers []WSSpotTicker)
+	tradesCallback          func(trades []WSSpotTrade)
+	depthL2TbtCallback      func(action string, data []WSDepthL2Tbt)
+	depth20SnapshotCallback func(ob *OrderBook) // 20档盘口
+	candlesCallback         func(candles []WSCandle)
+	accountCallback         func(accounts []WSSpotAccount)
+	marginAccountCallback   func(accounts []WSMarginAccount)
+	orderCallback           func(orders []WSSpotOrder)
+}
+
+func (ws *SpotWS) SetTickerCallback(callback func(tickers []WSSpotTicker)) {
+	ws.tickersCallback = callback
+}
+
+func (ws *SpotWS) SetTradeCallback(callback func(trades []WSSpotTrade)) {
+	ws.tradesCallback = callback
+}
+
+func (ws *SpotWS) SetDepthL2TbtCallback(callback func(action string, data []WSDepthL2Tbt)) {
+	ws.depthL2TbtCallback = callback
+}
+
+func (ws *SpotWS) SetDepth20SnapshotCallback(callback func(ob *OrderBook)) {
+	ws.depth20SnapshotCallback = callback
+}
+
+func (ws *SpotWS) SetCandleCallback(callback func(candles []WSCandle)) {
+	ws.candlesCallback = callback
+}
+
+func (ws *SpotWS) SetAccountCallback(callback func(accounts []WSSpotAccount)) {
+	ws.accountCallback = callback
+}
+
+func (ws *SpotWS) SetMarginAccountCallback(callback func(accounts []WSMarginAccount)) {
+	ws.marginAccountCallback = callback
+}
+
+func (ws *SpotWS) SetOrderCallback(callback func(orders []WSSpotOrder)) {
+	ws.orderCallback = callback
+}
+
+func (ws *SpotWS) SubscribeTicker(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotTicker, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *SpotWS) SubscribeTrade(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotTrade, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeDepthL2Tbt 公共-400档增量数据频道
+// 订阅后首次返回市场订单簿的400档深度数据并推送；后续只要订单簿深度有变化就推送有更改的数据。
+func (ws *SpotWS) SubscribeDepthL2Tbt(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotDepthL2Tbt, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeCandle K线频道，eg: spot/candle60s:BTC-USDT
+func (ws *SpotWS) SubscribeCandle(id string, symbol string, granularity Granularity) error {
+	if !granularity.Valid() {
+		return fmt.Errorf("okex: illegal granularity %d", granularity)
+	}
+	ch := fmt.Sprintf("%v%ds:%v", TableSpotCandle, granularity, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeAccount 币币账户，currency eg: BTC
+func (ws *SpotWS) SubscribeAccount(id string, currency string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotAccount, currency)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeMarginAccount 币币杠杆账户，symbol eg: BTC-USDT
+func (ws *SpotWS) SubscribeMarginAccount(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotMarginAccount, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+// SubscribeOrder 币币和币币杠杆订单
+func (ws *SpotWS) SubscribeOrder(id string, symbol string) error {
+	ch := fmt.Sprintf("%v:%v", TableSpotOrder, symbol)
+	return ws.Subscribe(id, []string{ch})
+}
+
+func (ws *SpotWS) handleTicker(table string, msg []byte) error {
+	var result WSSpotTickerResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.tickersCallback != nil {
+		ws.tickersCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *SpotWS) handleTrade(table string, msg []byte) error {
+	var result WSSpotTradeResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.tradesCallback != nil {
+		ws.tradesCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *SpotWS) handleCandle(table string, msg []byte) error {
+	candles, err := parseWSCandles(gjson.ParseBytes(msg))
+	if err != nil {
+		return err
+	}
+	if ws.candlesCallback != nil {
+		ws.candlesCallback(candles)
+	}
+	return nil
+}
+
+func (ws *SpotWS) handleAccount(table string, msg []byte) error {
+	var result WSSpotAccountResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.accountCallback != nil {
+		ws.accountCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *SpotWS) handleMarginAccount(table string, msg []byte) error {
+	var result WSMarginAccountResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.marginAccountCallback != nil {
+		ws.marginAccountCallback(result.Data)
+	}
+	return nil
+}
+
+func (ws *SpotWS) handleOrder(table string, msg []byte) error {
+	var result WSSpotOrderResult
+	if err := json.Unmarshal(msg, &result); err != nil {
+		return err
+	}
+	if ws.orderCallback != nil {
+		ws.orderCallback(result.Data)
+	}
+	return nil
+}
+
+// NewSpotWS 创建币币/币币杠杆WS
+// wsURL:
+// wss://real.okex.com:8443/ws/v3
+func NewSpotWS(wsURL string, accessKey string, secretKey string, passphrase string, debugMode bool, options ...WSOption) *SpotWS {
+	ws := &SpotWS{WSEngine: NewWSEngine(wsURL, accessKey, secretKey, passphrase, debugMode, options...)}
+	ws.Handle(TableSpotDepthL2Tbt, ws.depthL2TbtHandler(&ws.depthL2TbtCallback, &ws.depth20SnapshotCallback))
+	ws.Handle(TableSpotTicker, ws.handleTicker)
+	ws.Handle(TableSpotTrade, ws.handleTrade)
+	ws.Handle(TableSpotCandle+"*", ws.handleCandle)
+	ws.Handle(TableSpotAccount, ws.handleAccount)
+	ws.Handle(TableSpotMarginAccount, ws.handleMarginAccount)
+	ws.Handle(TableSpotOrder, ws.handleOrder)
+	return ws
+}
@@ -0,0 +1,176 @@
+package okex
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+type WSSpotTicker struct {
+	InstrumentID   string    `json:"instrument_id"`
+	Last           Decimal   `json:"last"`
+	LastQty        Decimal   `json:"last_qty"`
+	BestBid        Decimal   `json:"best_bid"`
+	BestBidSize    Decimal   `json:"best_bid_size"`
+	BestAsk        Decimal   `json:"best_ask"`
+	BestAskSize    Decimal   `json:"best_ask_size"`
+	Open24H        Decimal   `json:"open_24h"`
+	High24H        Decimal   `json:"high_24h"`
+	Low24H         Decimal   `json:"low_24h"`
+	BaseVolume24H  Decimal   `json:"base_volume_24h"`
+	QuoteVolume24H Decimal   `json:"quote_volume_24h"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type WSSpotTickerResult struct {
+	Table string         `json:"table"`
+	Data  []WSSpotTicker `json:"data"`
+}
+
+type WSSpotTrade struct {
+	InstrumentID string    `json:"instrument_id"`
+	TradeID      string    `json:"trade_id"`
+	Price        Decimal   `json:"price"`
+	Size         Decimal   `json:"size"`
+	Side         string    `json:"side"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+type WSSpotTradeResult struct {
+	Table string        `json:"table"`
+	Data  []WSSpotTrade `json:"data"`
+}
+
+// A bar of a candle channel, eg: spot/candle60s
+type WSCandle struct {
+	InstrumentID string
+	Granularity  Granularity
+	Candle       Candle
+}
+
+/*
+ Parse a candle channel message:
+	{"table":"spot/candle60s","data":[{"candle":["2019-04-16T10:49:00.000Z","162.03","162.04","161.96","161.98","336.452694"],"instrument_id":"ETH-USDT"}]}
+*/
+func parseWSCandles(ret gjson.Result) ([]WSCandle, error) {
+	table := ret.Get("table").String()
+	channel := table[strings.LastIndex(table, "/")+1:]
+	seconds := strings.TrimSuffix(strings.TrimPrefix(channel, "candle"), "s")
+	granularity := Granularity(StringToInt(seconds))
+	if !granularity.Valid() {
+		return nil, fmt.Errorf("okex: illegal candle table %s", table)
+	}
+	var candles []WSCandle
+	for _, row := range ret.Get("data").Array() {
+		candle, err := parseCandle(row.Get("candle"))
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, WSCandle{InstrumentID: row.Get("instrument_id").String(), Granularity: granularity, Candle: candle})
+	}
+	return candles, nil
+}
+
+type WSSpotAccount struct {
+	Currency  string  `json:"currency"`
+	Balance   Decimal `json:"balance"`
+	Available Decimal `json:"available"`
+	Hold      Decimal `json:"hold"`
+	ID        string  `json:"id"`
+}
+
+type WSSpotAccountResult struct {
+	Table string          `json:"table"`
+	Data  []WSSpotAccount `json:"data"`
+}
+
+// One currency of a margin account
+type WSMarginCurrency struct {
+	Available   Decimal `json:"available"`
+	Balance     Decimal `json:"balance"`
+	Borrowed    Decimal `json:"borrowed"`
+	CanWithdraw Decimal `json:"can_withdraw"`
+	Frozen      Decimal `json:"frozen"`
+	Hold        Decimal `json:"hold"`
+	Holds       Decimal `json:"holds"`
+	LendingFee  Decimal `json:"lending_fee"`
+}
+
+/*
+ The margin account of an instrument, its currencies by name, eg:
+	{"BTC":{"available":"0.01","borrowed":"0",...},"USDT":{...},"instrument_id":"BTC-USDT","liquidation_price":"0","risk_rate":"10000"}
+*/
+type WSMarginAccount struct {
+	InstrumentID     string
+	LiquidationPrice Decimal
+	RiskRate         Decimal
+	Currencies       map[string]WSMarginCurrency
+}
+
+func (a *WSMarginAccount) UnmarshalJSON(data []byte) error {
+	ret := gjson.ParseBytes(data)
+	if !ret.IsObject() {
+		return fmt.Errorf("okex: illegal margin account %s", ret.Raw)
+	}
+	account := WSMarginAccount{
+		InstrumentID:     ret.Get("instrument_id").String(),
+		LiquidationPrice: Decimal(ret.Get("liquidation_price").String()),
+		RiskRate:         Decimal(ret.Get("risk_rate").String()),
+		Currencies:       make(map[string]WSMarginCurrency),
+	}
+	var err error
+	ret.ForEach(func(key, value gjson.Result) bool {
+		if !value.IsObject() {
+			return true
+		}
+		var currency WSMarginCurrency
+		if err = json.Unmarshal([]byte(value.Raw), &currency); err != nil {
+			return false
+		}
+		account.Currencies[key.String()] = currency
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	*a = account
+	return nil
+}
+
+type WSMarginAccountResult struct {
+	Table string            `json:"table"`
+	Data  []WSMarginAccount `json:"data"`
+}
+
+/*
+ A spot or margin order.
+ MarginTrading: 1 币币 2 币币杠杆
+ State: -2 失败 -1 撤单成功 0 等待成交 1 部分成交 2 完全成交 3 下单中 4 撤单中
+*/
+type WSSpotOrder struct {
+	InstrumentID   string    `json:"instrument_id"`
+	ClientOid      string    `json:"client_oid"`
+	OrderID        string    `json:"order_id"`
+	Side           string    `json:"side"`
+	Type           string    `json:"type"`
+	OrderType      string    `json:"order_type"`
+	Price          Decimal   `json:"price"`
+	Size           Decimal   `json:"size"`
+	Notional       Decimal   `json:"notional"`
+	FilledSize     Decimal   `json:"filled_size"`
+	FilledNotional Decimal   `json:"filled_notional"`
+	LastFillPx     Decimal   `json:"last_fill_px"`
+	LastFillQty    Decimal   `json:"last_fill_qty"`
+	LastFillTime   time.Time `json:"last_fill_time"`
+	MarginTrading  string    `json:"margin_trading"`
+	State          string    `json:"state"`
+	CreatedAt      time.Time `json:"created_at"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type WSSpotOrderResult struct {
+	Table string        `json:"table"`
+	Data  []WSSpotOrder `json:"data"`
+}
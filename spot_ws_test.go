@@ -0,0 +1,65 @@
+package okex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpotWS_HandleMsg(t *testing.T) {
+	ws := NewSpotWS(WS_ENDPOINT, "", "", "", false)
+	var tickers []WSSpotTicker
+	var candles []WSCandle
+	var margins []WSMarginAccount
+	var orders []WSSpotOrder
+	var books []OrderBook
+	ws.SetTickerCallback(func(data []WSSpotTicker) { tickers = data })
+	ws.SetCandleCallback(func(data []WSCandle) { candles = data })
+	ws.SetMarginAccountCallback(func(data []WSMarginAccount) { margins = data })
+	ws.SetOrderCallback(func(data []WSSpotOrder) { orders = data })
+	ws.SetDepth20SnapshotCallback(func(ob *OrderBook) { books = append(books, *ob) })
+
+	ws.handleMsg(0, []byte(`{"table":"spot/ticker","data":[{"instrument_id":"BTC-USDT","last":"9120.1","base_volume_24h":"12.5","quote_volume_24h":"114001.25"}]}`))
+	assert.Equal(t, Decimal("9120.1"), tickers[0].Last)
+	assert.Equal(t, Decimal("12.5"), tickers[0].BaseVolume24H)
+
+	ws.handleMsg(0, []byte(`{"table":"spot/candle60s","data":[{"candle":["2020-09-20T08:01:00.000Z","9120","9125","9110","9121","3.5"],"instrument_id":"BTC-USDT"}]}`))
+	assert.Equal(t, 1, len(candles))
+	assert.Equal(t, CANDLES_1MIN, candles[0].Granularity)
+	assert.Equal(t, "BTC-USDT", candles[0].InstrumentID)
+	assert.Equal(t, time.Date(2020, 9, 20, 8, 1, 0, 0, time.UTC), candles[0].Candle.Time)
+	assert.Equal(t, Decimal("9125"), candles[0].Candle.High)
+
+	ws.handleMsg(0, []byte(`{"table":"spot/margin_account","data":[{"BTC":{"available":"0.5","borrowed":"0.1","lending_fee":"0.0001"},`+
+		`"USDT":{"available":"100","borrowed":"0"},"instrument_id":"BTC-USDT","liquidation_price":"5000","risk_rate":"3.2"}]}`))
+	assert.Equal(t, 1, len(margins))
+	assert.Equal(t, "BTC-USDT", margins[0].InstrumentID)
+	assert.Equal(t, Decimal("3.2"), margins[0].RiskRate)
+	assert.Equal(t, 2, len(margins[0].Currencies))
+	assert.Equal(t, Decimal("0.1"), margins[0].Currencies["BTC"].Borrowed)
+
+	ws.handleMsg(0, []byte(`{"table":"spot/order","data":[{"instrument_id":"BTC-USDT","order_id":"1","side":"buy","margin_trading":"2","filled_size":"0.1"}]}`))
+	assert.Equal(t, "2", orders[0].MarginTrading)
+	assert.Equal(t, Decimal("0.1"), orders[0].FilledSize)
+
+	ws.handleMsg(0, []byte(`{"table":"spot/depth_l2_tbt","action":"partial","data":[{"instrument_id":"BTC-USDT",`+
+		`"asks":[["9121","1","2"],["9122","2","1"]],"bids":[["9120","3","1"]],"timestamp":"2020-09-20T08:01:00.000Z"}]}`))
+	ws.handleMsg(0, []byte(`{"table":"spot/depth_l2_tbt","action":"update","data":[{"instrument_id":"BTC-USDT",`+
+		`"asks":[["9121","0","0"]],"bids":[],"timestamp":"2020-09-20T08:01:01.000Z"}]}`))
+	assert.Equal(t, 2, len(books))
+	assert.Equal(t, Decimal("9122"), books[1].Asks[0].Price)
+	assert.Equal(t, Decimal("9120"), books[1].Bids[0].Price)
+
+	// not started, the subscription is kept for the first connect
+	assert.NotNil(t, ws.SubscribeCandle("c", "BTC-USDT", CANDLES_5MIN))
+	assert.Equal(t, []string{"spot/candle300s:BTC-USDT"}, subscriptionArgs(ws.subscriptions["c"]))
+	assert.NotNil(t, ws.SubscribeCandle("x", "BTC-USDT", Granularity(120)))
+	assert.Nil(t, ws.subscriptions["x"])
+}
+
+func TestSpotWS_Simulated(t *testing.T) {
+	ws := NewSpotWS(WS_ENDPOINT, "", "", "", false, WithWSSimulated())
+	assert.Equal(t, DEMO_WS_ENDPOINT, ws.wsURL)
+	assert.Equal(t, "1", ws.wsHeader.Get(X_SIMULATED_TRADING))
+}
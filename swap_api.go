@@ -8,8 +8,8 @@ package okex
 */
 
 import (
+	"context"
 	"errors"
-	"log"
 	"strings"
 )
 
@@ -18,9 +18,13 @@ import (
 GET /api/swap/v3/<instrument_id>/position
 */
 func (client *Client) GetSwapPositionByInstrument(instrumentId string) (SwapPosition, error) {
+	return client.GetSwapPositionByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapPositionByInstrumentCtx(ctx context.Context, instrumentId string) (SwapPosition, error) {
 
 	sp := SwapPosition{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_POSITION, instrumentId), nil, &sp); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_POSITION, instrumentId), nil, &sp); err != nil {
 		return SwapPosition{}, err
 	}
 	return sp, nil
@@ -33,17 +37,21 @@ func (client *Client) GetSwapPositionByInstrument(instrumentId string) (SwapPosi
 GET /api/swap/v3/position
 */
 func (client *Client) GetSwapPositions() (*SwapPositionList, error) {
+	return client.GetSwapPositionsCtx(context.Background())
+}
+
+func (client *Client) GetSwapPositionsCtx(ctx context.Context) (*SwapPositionList, error) {
 
 	sp := SwapPositionList{}
-	if _, _, err := client.Request(GET, SWAP_POSITION, nil, &sp); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SWAP_POSITION, nil, &sp); err != nil {
 		return nil, err
 	}
 	return &sp, nil
 }
 
-func (client *Client) getSwapAccounts(uri string) (SwapAccounts, error) {
+func (client *Client) getSwapAccounts(ctx context.Context, uri string) (SwapAccounts, error) {
 	sa := SwapAccounts{}
-	if _, _, err := client.Request(GET, uri, nil, &sa); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &sa); err != nil {
 		return SwapAccounts{}, err
 	}
 	return sa, nil
@@ -55,7 +63,11 @@ HTTP请求
 GET /api/swap/v3/accounts
 */
 func (client *Client) GetSwapAccounts() (SwapAccounts, error) {
-	return client.getSwapAccounts(SWAP_ACCOUNTS)
+	return client.GetSwapAccountsCtx(context.Background())
+}
+
+func (client *Client) GetSwapAccountsCtx(ctx context.Context) (SwapAccounts, error) {
+	return client.getSwapAccounts(ctx, SWAP_ACCOUNTS)
 }
 
 /*
@@ -64,10 +76,14 @@ HTTP请求
 GET /api/swap/v3/<instrument_id>/accounts
 */
 func (client *Client) GetSwapAccount(instrumentId string) (SwapAccount, error) {
+	return client.GetSwapAccountCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapAccountCtx(ctx context.Context, instrumentId string) (SwapAccount, error) {
 
 	sa := SwapAccount{}
 	uri := GetInstrumentIdUri(SWAP_INSTRUMENT_ACCOUNT, instrumentId)
-	if _, _, err := client.Request(GET, uri, nil, &sa); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &sa); err != nil {
 		return SwapAccount{}, err
 	}
 	return sa, nil
@@ -80,8 +96,12 @@ HTTP请求
 GET /api/swap/v3/accounts/<instrument_id>/settings
 */
 func (client *Client) GetSwapAccountsSettingsByInstrument(instrumentId string) (SwapAccountsSetting, error) {
+	return client.GetSwapAccountsSettingsByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapAccountsSettingsByInstrumentCtx(ctx context.Context, instrumentId string) (SwapAccountsSetting, error) {
 	as := SwapAccountsSetting{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_ACCOUNTS_SETTINGS, instrumentId), nil, &as); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_ACCOUNTS_SETTINGS, instrumentId), nil, &as); err != nil {
 		return SwapAccountsSetting{}, err
 	}
 	return as, nil
@@ -94,13 +114,17 @@ HTTP请求
 POST /api/swap/v3/accounts/<instrument_id>/leverage
 */
 func (client *Client) PostSwapAccountsLeverage(instrumentId string, leverage string, side string) ([]byte, SwapAccountsSetting, error) {
+	return client.PostSwapAccountsLeverageCtx(context.Background(), instrumentId, leverage, side)
+}
+
+func (client *Client) PostSwapAccountsLeverageCtx(ctx context.Context, instrumentId string, leverage string, side string) ([]byte, SwapAccountsSetting, error) {
 	params := make(map[string]string)
 	params["leverage"] = leverage
 	params["side"] = side
 	as := SwapAccountsSetting{}
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, GetInstrumentIdUri(SWAP_ACCOUNTS_LEVERAGE, instrumentId), params, &as); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, GetInstrumentIdUri(SWAP_ACCOUNTS_LEVERAGE, instrumentId), params, &as); err != nil {
 		return respBody, SwapAccountsSetting{}, err
 	}
 	return respBody, as, nil
@@ -114,13 +138,17 @@ HTTP请求
 GET /api/swap/v3/accounts/<instrument_id>/ledger
 */
 func (client *Client) GetSwapAccountLedger(instrumentId string, optionalParams map[string]string) (*SwapAccountsLedgerList, error) {
+	return client.GetSwapAccountLedgerCtx(context.Background(), instrumentId, optionalParams)
+}
+
+func (client *Client) GetSwapAccountLedgerCtx(ctx context.Context, instrumentId string, optionalParams map[string]string) (*SwapAccountsLedgerList, error) {
 	baseUri := GetInstrumentIdUri(SWAP_ACCOUNTS_LEDGER, instrumentId)
 	uri := baseUri
 	if optionalParams != nil {
 		uri = BuildParams(baseUri, optionalParams)
 	}
 	ll := SwapAccountsLedgerList{}
-	if _, _, err := client.Request(GET, uri, nil, &ll); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &ll); err != nil {
 		return nil, err
 	}
 	return &ll, nil
@@ -133,11 +161,33 @@ HTTP请求
 POST /api/swap/v3/order
 */
 func (client *Client) PostSwapOrder(instrumentId string, order BasePlaceOrderInfo) ([]byte, SwapOrderResult, error) {
+	return client.PostSwapOrderCtx(context.Background(), instrumentId, order)
+}
+
+func (client *Client) PostSwapOrderCtx(ctx context.Context, instrumentId string, order BasePlaceOrderInfo) ([]byte, SwapOrderResult, error) {
+	if err := client.validateOrder(ctx, instrumentId, order.Price, order.Size, order.MatchPrice); err != nil {
+		return nil, SwapOrderResult{}, err
+	}
 	or := SwapOrderResult{}
 	info := PlaceOrderInfo{order, instrumentId}
 	var respBody []byte
-	var err error
-	if respBody, _, err = client.Request(POST, SWAP_ORDER, info, &or); err != nil {
+	err := client.placeOrderWithRetry(ctx, order.ClientOid, func() (err error) {
+		or = SwapOrderResult{}
+		respBody, _, err = client.RequestCtx(ctx, POST, SWAP_ORDER, info, &or)
+		return err
+	}, func() (bool, error) {
+		orderInfo, err := client.GetSwapOrderByIdCtx(ctx, instrumentId, order.ClientOid)
+		if err != nil || orderInfo.OrderId == "" {
+			return false, err
+		}
+		or = SwapOrderResult{}
+		or.OrderId = orderInfo.OrderId
+		or.ClientOid = order.ClientOid
+		or.Result = "true"
+		respBody = nil
+		return true, nil
+	})
+	if err != nil {
 		return respBody, SwapOrderResult{}, err
 	}
 	return respBody, or, nil
@@ -150,11 +200,15 @@ HTTP请求
 POST /api/swap/v3/orders
 */
 func (client *Client) PostSwapOrders(instrumentId string, orders []*BasePlaceOrderInfo) ([]byte, *SwapOrdersResult, error) {
+	return client.PostSwapOrdersCtx(context.Background(), instrumentId, orders)
+}
+
+func (client *Client) PostSwapOrdersCtx(ctx context.Context, instrumentId string, orders []*BasePlaceOrderInfo) ([]byte, *SwapOrdersResult, error) {
 	sor := SwapOrdersResult{}
 	orderData := PlaceOrdersInfo{InstrumentId: instrumentId, OrderData: orders}
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, SWAP_ORDERS, orderData, &sor); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, SWAP_ORDERS, orderData, &sor); err != nil {
 		return respBody, nil, err
 	}
 	return respBody, &sor, nil
@@ -167,11 +221,15 @@ HTTP请求
 POST /api/swap/v3/cancel_order/<instrument_id>/<order_id>
 */
 func (client *Client) PostSwapCancelOrder(instrumentId string, orderId string) ([]byte, SwapCancelOrderResult, error) {
+	return client.PostSwapCancelOrderCtx(context.Background(), instrumentId, orderId)
+}
+
+func (client *Client) PostSwapCancelOrderCtx(ctx context.Context, instrumentId string, orderId string) ([]byte, SwapCancelOrderResult, error) {
 	uri := "/api/swap/v3/cancel_order/" + instrumentId + "/" + orderId
 	or := SwapCancelOrderResult{}
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, uri, nil, &or); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, uri, nil, &or); err != nil {
 		return respBody, SwapCancelOrderResult{}, err
 	}
 	return respBody, or, nil
@@ -184,6 +242,10 @@ HTTP请求
 POST /api/swap/v3/cancel_batch_orders/<instrument_id>
 */
 func (client *Client) PostSwapBatchCancelOrders(instrumentId string, orderIds []string) ([]byte, *SwapCancelOrderResult, error) {
+	return client.PostSwapBatchCancelOrdersCtx(context.Background(), instrumentId, orderIds)
+}
+
+func (client *Client) PostSwapBatchCancelOrdersCtx(ctx context.Context, instrumentId string, orderIds []string) ([]byte, *SwapCancelOrderResult, error) {
 	uri := GetInstrumentIdUri(SWAP_CANCEL_BATCH_ORDERS, instrumentId)
 	or := SwapCancelOrderResult{}
 
@@ -192,7 +254,7 @@ func (client *Client) PostSwapBatchCancelOrders(instrumentId string, orderIds []
 
 	var respBody []byte
 	var err error
-	if respBody, _, err = client.Request(POST, uri, params, &or); err != nil {
+	if respBody, _, err = client.RequestCtx(ctx, POST, uri, params, &or); err != nil {
 		return respBody, nil, err
 	}
 	return respBody, &or, nil
@@ -208,6 +270,10 @@ GET /api/swap/v3/orders/<instrument_id>
 GET /api/swap/v3/orders/BTC-USD-SWAP?status=2&from=4&limit=30
 */
 func (client *Client) GetSwapOrderByInstrumentId(instrumentId string, paramMap map[string]string) (*SwapOrdersInfo, error) {
+	return client.GetSwapOrderByInstrumentIdCtx(context.Background(), instrumentId, paramMap)
+}
+
+func (client *Client) GetSwapOrderByInstrumentIdCtx(ctx context.Context, instrumentId string, paramMap map[string]string) (*SwapOrdersInfo, error) {
 	if paramMap["status"] == "" || len(instrumentId) == 0 {
 		return nil, errors.New("Request Parameter's not correct, instrument_id and status is required.")
 	}
@@ -217,7 +283,7 @@ func (client *Client) GetSwapOrderByInstrumentId(instrumentId string, paramMap m
 	uri := baseUri + "?" + kvParams
 	soi := SwapOrdersInfo{}
 
-	if _, _, err := client.Request(GET, uri, nil, &soi); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &soi); err != nil {
 		return nil, err
 	}
 	return &soi, nil
@@ -233,7 +299,11 @@ GET /api/swap/v3/orders/<instrument_id>/<order_id>
 GET /api/swap/v3/orders/BTC-USD-SWAP/64-2a-26132f931-3
 */
 func (client *Client) GetSwapOrderByOrderId(instrumentId string, orderId string) (BaseOrderInfo, error) {
-	return client.GetSwapOrderById(instrumentId, orderId)
+	return client.GetSwapOrderByOrderIdCtx(context.Background(), instrumentId, orderId)
+}
+
+func (client *Client) GetSwapOrderByOrderIdCtx(ctx context.Context, instrumentId string, orderId string) (BaseOrderInfo, error) {
+	return client.GetSwapOrderByIdCtx(ctx, instrumentId, orderId)
 }
 
 /*
@@ -247,12 +317,16 @@ or
 GET /api/swap/v3/orders/<instrument_id>/<client_oid>
 */
 func (client *Client) GetSwapOrderById(instrumentId, orderOrClientId string) (BaseOrderInfo, error) {
+	return client.GetSwapOrderByIdCtx(context.Background(), instrumentId, orderOrClientId)
+}
+
+func (client *Client) GetSwapOrderByIdCtx(ctx context.Context, instrumentId, orderOrClientId string) (BaseOrderInfo, error) {
 	orderInfo := BaseOrderInfo{}
 	baseUri := GetInstrumentIdUri(SWAP_INSTRUMENT_ORDER_BY_ID, instrumentId)
 	uri := strings.Replace(baseUri, "{order_client_id}", orderOrClientId, -1)
 
-	if r, _, err := client.Request(GET, uri, nil, &orderInfo); err != nil {
-		log.Printf("r: %v", string(r))
+	if r, _, err := client.RequestCtx(ctx, GET, uri, nil, &orderInfo); err != nil {
+		client.logger.Log(LogLevelDebug, "get swap order failed", F("body", string(r)), F("error", err))
 		return BaseOrderInfo{}, err
 	}
 
@@ -269,6 +343,10 @@ GET /api/swap/v3/fills
 GET /api/swap/v3/fills?order_id=64-2b-16122f931-3&instrument_id=BTC-USD-SWAP&from=1&limit=50(返回BTC-USD-SWAP中order_id为64-2b-16122f931-3的订单中第1页前50笔成交信息)
 */
 func (client *Client) GetSwapFills(instrumentId string, orderId string, options map[string]string) (interface{}, error) {
+	return client.GetSwapFillsCtx(context.Background(), instrumentId, orderId, options)
+}
+
+func (client *Client) GetSwapFillsCtx(ctx context.Context, instrumentId string, orderId string, options map[string]string) (interface{}, error) {
 	m := make(map[string]string)
 	m["instrument_id"] = instrumentId
 	m["order_id"] = orderId
@@ -280,7 +358,7 @@ func (client *Client) GetSwapFills(instrumentId string, orderId string, options
 	uri := BuildParams(SWAP_FILLS, m)
 	sfi := SwapFillsInfo{}
 
-	if _, _, err := client.Request(GET, uri, nil, &sfi); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &sfi); err != nil {
 		return nil, err
 	}
 
@@ -294,8 +372,12 @@ HTTP请求
 GET /api/swap/v3/instruments
 */
 func (client *Client) GetSwapInstruments() (SwapInstrumentList, error) {
+	return client.GetSwapInstrumentsCtx(context.Background())
+}
+
+func (client *Client) GetSwapInstrumentsCtx(ctx context.Context) (SwapInstrumentList, error) {
 	sil := SwapInstrumentList{}
-	if _, _, err := client.Request(GET, SWAP_INSTRUMENTS, nil, &sil); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SWAP_INSTRUMENTS, nil, &sil); err != nil {
 		return SwapInstrumentList{}, err
 	}
 
@@ -312,6 +394,10 @@ GET /api/swap/v3/instruments/<instrument_id>/depth
 GET /api/swap/v3/instruments/<instrument_id>/depth?size=50
 */
 func (client *Client) GetSwapDepthByInstrumentId(instrumentId string, optionalParams map[string]string) (SwapInstrumentDepth, error) {
+	return client.GetSwapDepthByInstrumentIdCtx(context.Background(), instrumentId, optionalParams)
+}
+
+func (client *Client) GetSwapDepthByInstrumentIdCtx(ctx context.Context, instrumentId string, optionalParams map[string]string) (SwapInstrumentDepth, error) {
 	sid := SwapInstrumentDepth{}
 	params := NewParams()
 	if optionalParams != nil {
@@ -324,7 +410,7 @@ func (client *Client) GetSwapDepthByInstrumentId(instrumentId string, optionalPa
 	}
 	requestPath := BuildParams(GetInstrumentIdUri(SWAP_INSTRUMENT_DEPTH, instrumentId), params)
 
-	if _, _, err := client.Request(GET, requestPath, nil, &sid); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, requestPath, nil, &sid); err != nil {
 		return SwapInstrumentDepth{}, err
 	}
 
@@ -338,8 +424,12 @@ HTTP请求
 GET /api/swap/v3/instruments/ticker
 */
 func (client *Client) GetSwapInstrumentsTicker() (*SwapTickerList, error) {
+	return client.GetSwapInstrumentsTickerCtx(context.Background())
+}
+
+func (client *Client) GetSwapInstrumentsTickerCtx(ctx context.Context) (*SwapTickerList, error) {
 	stl := SwapTickerList{}
-	if _, _, err := client.Request(GET, SWAP_INSTRUMENTS_TICKER, nil, &stl); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SWAP_INSTRUMENTS_TICKER, nil, &stl); err != nil {
 		return nil, err
 	}
 
@@ -353,9 +443,13 @@ HTTP请求
 GET /api/swap/v3/instruments/<instrument_id>/ticker
 */
 func (client *Client) GetSwapTickerByInstrument(instrumentId string) (*BaseTickerInfo, error) {
+	return client.GetSwapTickerByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapTickerByInstrumentCtx(ctx context.Context, instrumentId string) (*BaseTickerInfo, error) {
 	bti := BaseTickerInfo{}
 	uri := GetInstrumentIdUri(SWAP_INSTRUMENT_TICKER, instrumentId)
-	if _, _, err := client.Request(GET, uri, nil, &bti); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &bti); err != nil {
 		return nil, err
 	}
 
@@ -373,10 +467,14 @@ GET /api/swap/v3/instruments/<instrument_id>/trades
 GET /api/swap/v3/instruments/BTC-USD-SWAP/trades?from=1&limit=50
 */
 func (client *Client) GetSwapTradesByInstrument(instrumentId string, optionalParams map[string]string) (*SwapTradeList, error) {
+	return client.GetSwapTradesByInstrumentCtx(context.Background(), instrumentId, optionalParams)
+}
+
+func (client *Client) GetSwapTradesByInstrumentCtx(ctx context.Context, instrumentId string, optionalParams map[string]string) (*SwapTradeList, error) {
 	stl := SwapTradeList{}
 	baseUri := GetInstrumentIdUri(SWAP_INSTRUMENT_TRADES, instrumentId)
 	uri := BuildParams(baseUri, optionalParams)
-	if _, _, err := client.Request(GET, uri, nil, &stl); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &stl); err != nil {
 		return nil, err
 	}
 	return &stl, nil
@@ -391,18 +489,17 @@ GET /api/swap/v3/instruments/<instrument_id>/candles
 请求示例
 GET /api/swap/v3/instruments/BTC-USD-SWAP/candles?start=2018-10-26T02:31:00.000Z&end=2018-10-26T02:55:00.000Z&granularity=60(查询BTC-USD-SWAP的2018年10月26日02点31分到2018年10月26日02点55分的1分钟K线数据)
 */
-func (client *Client) GetSwapCandlesByInstrument(instrumentId string, optionalParams map[string]string) (*SwapCandleList, error) {
-	scl := SwapCandleList{}
-	baseUri := GetInstrumentIdUri(SWAP_INSTRUMENT_CANDLES, instrumentId)
-	uri := baseUri
-	if len(optionalParams) > 0 {
-		uri = BuildParams(baseUri, optionalParams)
-	}
-	if _, _, err := client.Request(GET, uri, nil, &scl); err != nil {
+func (client *Client) GetSwapCandlesByInstrument(instrumentId string, params *CandlesParams) ([]Candle, error) {
+	return client.GetSwapCandlesByInstrumentCtx(context.Background(), instrumentId, params)
+}
+
+func (client *Client) GetSwapCandlesByInstrumentCtx(ctx context.Context, instrumentId string, params *CandlesParams) ([]Candle, error) {
+	var candles []Candle
+	uri := pageUri(GetInstrumentIdUri(SWAP_INSTRUMENT_CANDLES, instrumentId), params.params())
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &candles); err != nil {
 		return nil, err
 	}
-	return &scl, nil
-
+	return candles, nil
 }
 
 /*
@@ -415,8 +512,12 @@ GET /api/swap/v3/instruments/<instrument_id>/index
 GET /api/swap/v3/instruments/BTC-USD-SWAP/index
 */
 func (client *Client) GetSwapIndexByInstrument(instrumentId string) (*SwapIndexInfo, error) {
+	return client.GetSwapIndexByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapIndexByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapIndexInfo, error) {
 	sii := SwapIndexInfo{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_INDEX, instrumentId), nil, &sii); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_INDEX, instrumentId), nil, &sii); err != nil {
 		return nil, err
 	}
 	return &sii, nil
@@ -429,8 +530,12 @@ HTTP请求
 GET /api/swap/v3/instruments/<instrument_id>/open_interest
 */
 func (client *Client) GetSwapOpenInterestByInstrument(instrumentId string) (*SwapOpenInterest, error) {
+	return client.GetSwapOpenInterestByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapOpenInterestByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapOpenInterest, error) {
 	sii := SwapOpenInterest{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_OPEN_INTEREST, instrumentId), nil, &sii); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_OPEN_INTEREST, instrumentId), nil, &sii); err != nil {
 		return nil, err
 	}
 	return &sii, nil
@@ -443,8 +548,12 @@ HTTP请求
 GET /api/swap/v3/instruments/<instrument_id>/price_limit
 */
 func (client *Client) GetSwapPriceLimitByInstrument(instrumentId string) (*SwapPriceLimit, error) {
+	return client.GetSwapPriceLimitByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapPriceLimitByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapPriceLimit, error) {
 	sii := SwapPriceLimit{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_PRICE_LIMIT, instrumentId), nil, &sii); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_PRICE_LIMIT, instrumentId), nil, &sii); err != nil {
 		return nil, err
 	}
 	return &sii, nil
@@ -460,6 +569,10 @@ GET /api/swap/v3/instruments/<instrument_id>/liquidation
 GET /api/swap/v3/instruments/BTC-USD-SWAP/liquidation?status=0&from=1&limit=50
 */
 func (client *Client) GetSwapLiquidationByInstrument(instrumentId string, status string, optionalParams map[string]string) (*SwapLiquidationList, error) {
+	return client.GetSwapLiquidationByInstrumentCtx(context.Background(), instrumentId, status, optionalParams)
+}
+
+func (client *Client) GetSwapLiquidationByInstrumentCtx(ctx context.Context, instrumentId string, status string, optionalParams map[string]string) (*SwapLiquidationList, error) {
 	scl := SwapLiquidationList{}
 	baseUri := GetInstrumentIdUri(SWAP_INSTRUMENT_LIQUIDATION, instrumentId)
 	uri := baseUri
@@ -471,7 +584,7 @@ func (client *Client) GetSwapLiquidationByInstrument(instrumentId string, status
 		oParams["status"] = status
 		uri = BuildParams(baseUri, oParams)
 	}
-	if _, _, err := client.Request(GET, uri, nil, &scl); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &scl); err != nil {
 		return nil, err
 	}
 	return &scl, nil
@@ -484,8 +597,12 @@ HTTP请求
 GET /api/swap/v3/accounts/<instrument_id>/holds
 */
 func (client *Client) GetSwapAccountsHoldsByInstrument(instrumentId string) (*SwapAccountHolds, error) {
+	return client.GetSwapAccountsHoldsByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapAccountsHoldsByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapAccountHolds, error) {
 	r := SwapAccountHolds{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_ACCOUNTS_HOLDS, instrumentId), nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_ACCOUNTS_HOLDS, instrumentId), nil, &r); err != nil {
 		return nil, err
 	}
 	return &r, nil
@@ -498,8 +615,12 @@ HTTP请求
 GET /api/swap/v3/instruments/<instrument_id>/funding_time
 */
 func (client *Client) GetSwapFundingTimeByInstrument(instrumentId string) (*SwapFundingTime, error) {
+	return client.GetSwapFundingTimeByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapFundingTimeByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapFundingTime, error) {
 	r := SwapFundingTime{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_FUNDING_TIME, instrumentId), nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_FUNDING_TIME, instrumentId), nil, &r); err != nil {
 		return nil, err
 	}
 	return &r, nil
@@ -512,8 +633,12 @@ HTTP请求
 GET /api/swap/v3/instruments/<instrument_id>/mark_price
 */
 func (client *Client) GetSwapMarkPriceByInstrument(instrumentId string) (*SwapMarkPrice, error) {
+	return client.GetSwapMarkPriceByInstrumentCtx(context.Background(), instrumentId)
+}
+
+func (client *Client) GetSwapMarkPriceByInstrumentCtx(ctx context.Context, instrumentId string) (*SwapMarkPrice, error) {
 	r := SwapMarkPrice{}
-	if _, _, err := client.Request(GET, GetInstrumentIdUri(SWAP_INSTRUMENT_MARK_PRICE, instrumentId), nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, GetInstrumentIdUri(SWAP_INSTRUMENT_MARK_PRICE, instrumentId), nil, &r); err != nil {
 		return nil, err
 	}
 	return &r, nil
@@ -529,6 +654,10 @@ GET /api/swap/v3/instruments/<instrument_id>/historical_funding_rate
 GET /api/swap/v3/instruments/BTC-USD-SWAP/historical_funding_rate?from=1&limit=50
 */
 func (client *Client) GetSwapHistoricalFundingRateByInstrument(instrumentId string, optionalParams map[string]string) (*SwapHistoricalFundingRateList, error) {
+	return client.GetSwapHistoricalFundingRateByInstrumentCtx(context.Background(), instrumentId, optionalParams)
+}
+
+func (client *Client) GetSwapHistoricalFundingRateByInstrumentCtx(ctx context.Context, instrumentId string, optionalParams map[string]string) (*SwapHistoricalFundingRateList, error) {
 	r := SwapHistoricalFundingRateList{}
 	baseUri := GetInstrumentIdUri(SWAP_INSTRUMENT_HISTORICAL_FUNDING_RATE, instrumentId)
 	uri := baseUri
@@ -536,7 +665,7 @@ func (client *Client) GetSwapHistoricalFundingRateByInstrument(instrumentId stri
 		uri = BuildParams(baseUri, optionalParams)
 	}
 
-	if _, _, err := client.Request(GET, uri, nil, &r); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, uri, nil, &r); err != nil {
 		return nil, err
 	}
 	return &r, nil
@@ -549,8 +678,12 @@ HTTP请求
 GET /api/swap/v3/rate
 */
 func (client *Client) GetSwapRate() (*SwapRate, error) {
+	return client.GetSwapRateCtx(context.Background())
+}
+
+func (client *Client) GetSwapRateCtx(ctx context.Context) (*SwapRate, error) {
 	sr := SwapRate{}
-	if _, _, err := client.Request(GET, SWAP_RATE, nil, &sr); err != nil {
+	if _, _, err := client.RequestCtx(ctx, GET, SWAP_RATE, nil, &sr); err != nil {
 		return nil, err
 	}
 	return &sr, nil
@@ -8,12 +8,12 @@ package okex
 */
 
 type BasePlaceOrderInfo struct {
-	ClientOid  string `json:"client_oid"`
-	Price      string `json:"price"`
-	MatchPrice string `json:"match_price"`
-	Type       string `json:"type"`
-	OrderType  string `json:"order_type"`
-	Size       string `json:"size"`
+	ClientOid  string  `json:"client_oid"`
+	Price      Decimal `json:"price"`
+	MatchPrice string  `json:"match_price"`
+	Type       string  `json:"type"`
+	OrderType  string  `json:"order_type"`
+	Size       Decimal `json:"size"`
 }
 
 type PlaceOrderInfo struct {
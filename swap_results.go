@@ -8,17 +8,17 @@ package okex
 */
 
 type SwapPositionHolding struct {
-	LiquidationPrice string `json:"liquidation_price"`
-	Position         string `json:"position"`
-	AvailPosition    string `json:"avail_position"`
-	AvgCost          string `json:"avg_cost"`
-	SettlementPrice  string `json:"settlement_price"`
-	InstrumentId     string `json:"instrument_id"`
-	Leverage         string `json:"leverage"`
-	RealizedPnl      string `json:"realized_pnl"`
-	Side             string `json:"side"`
-	Timestamp        string `json:"timestamp"`
-	Margin           string `json:"margin";default:""`
+	LiquidationPrice Decimal `json:"liquidation_price"`
+	Position         Decimal `json:"position"`
+	AvailPosition    Decimal `json:"avail_position"`
+	AvgCost          Decimal `json:"avg_cost"`
+	SettlementPrice  Decimal `json:"settlement_price"`
+	InstrumentId     string  `json:"instrument_id"`
+	Leverage         Decimal `json:"leverage"`
+	RealizedPnl      Decimal `json:"realized_pnl"`
+	Side             string  `json:"side"`
+	Timestamp        string  `json:"timestamp"`
+	Margin           Decimal `json:"margin";default:""`
 }
 
 type SwapPosition struct {
@@ -29,18 +29,24 @@ type SwapPosition struct {
 
 type SwapPositionList []SwapPosition
 
+// @see FuturesClosePositionResult
+type SwapClosePositionResult struct {
+	Result
+	ClosePositionInfo []ClosePositionInfo `json:"close_position_info"`
+}
+
 type SwapAccountInfo struct {
-	InstrumentId      string `json:"instrument_id"`
-	Timestamp         string `json:"timestamp"`
-	MarginFrozen      string `json:"margin_frozen"`
-	TotalAvailBalance string `json:"total_avail_balance"`
-	MarginRatio       string `json:"margin_ratio"`
-	RealizedPnl       string `json:"realized_pnl"`
-	UnrealizedPnl     string `json:"unrealized_pnl"`
-	FixedBalance      string `json:"fixed_balance"`
-	Equity            string `json:"equity"`
-	Margin            string `json:"margin"`
-	MarginMode        string `json:"margin_mode"`
+	InstrumentId      string  `json:"instrument_id"`
+	Timestamp         string  `json:"timestamp"`
+	MarginFrozen      Decimal `json:"margin_frozen"`
+	TotalAvailBalance Decimal `json:"total_avail_balance"`
+	MarginRatio       Decimal `json:"margin_ratio"`
+	RealizedPnl       Decimal `json:"realized_pnl"`
+	UnrealizedPnl     Decimal `json:"unrealized_pnl"`
+	FixedBalance      Decimal `json:"fixed_balance"`
+	Equity            Decimal `json:"equity"`
+	Margin            Decimal `json:"margin"`
+	MarginMode        string  `json:"margin_mode"`
 }
 
 type SwapAccounts struct {
@@ -88,13 +94,14 @@ type BaseOrderInfo struct {
 	InstrumentId string  `json:"instrument_id"`
 	Status       string  `json:"status"`
 	OrderId      string  `json:"order_id"`
+	ClientOid    string  `json:"client_oid"`
 	Timestamp    string  `json:"timestamp"`
-	Price        float64 `json:"price,string"`
-	PriceAvg     float64 `json:"price_avg,string"`
+	Price        Decimal `json:"price"`
+	PriceAvg     Decimal `json:"price_avg"`
 	Size         int64   `json:"size,string"`
-	Fee          float64 `json:"fee,string"`
-	FilledQty    float64 `json:"filled_qty,string"`
-	ContractVal  string  `json:"contract_val"`
+	Fee          Decimal `json:"fee"`
+	FilledQty    Decimal `json:"filled_qty"`
+	ContractVal  Decimal `json:"contract_val"`
 	Type         float64 `json:"type,string"`
 	OrderType    string  `json:"order_type"`
 	State        int     `json:"state,string"`
@@ -106,48 +113,54 @@ type SwapOrdersInfo struct {
 }
 
 type BaseFillInfo struct {
-	InstrumentId string `json:"instrument_id"`
-	OrderQty     string `json:"order_qty"`
-	TradeId      string `json:"trade_id"`
-	Fee          string `json:"fee"`
-	OrderId      string `json:"order_id"`
-	Timestamp    string `json:"timestamp"`
-	Price        string `json:"price"`
-	Side         string `json:"side"`
-	ExecType     string `json:"exec_type"`
+	InstrumentId string  `json:"instrument_id"`
+	OrderQty     Decimal `json:"order_qty"`
+	TradeId      string  `json:"trade_id"`
+	Fee          Decimal `json:"fee"`
+	OrderId      string  `json:"order_id"`
+	Timestamp    string  `json:"timestamp"`
+	Price        Decimal `json:"price"`
+	Side         string  `json:"side"`
+	ExecType     string  `json:"exec_type"`
 }
 
 type SwapFillsInfo []BaseFillInfo
 
 type SwapAccountsSetting struct {
 	BizWarmTips
-	InstrumentId  string `json:"instrument_id"`
-	LongLeverage  string `json:"long_leverage"`
-	ShortLeverage string `json:"short_leverage"`
-	MarginMode    string `json:"margin_mode"`
+	InstrumentId  string  `json:"instrument_id"`
+	LongLeverage  Decimal `json:"long_leverage"`
+	ShortLeverage Decimal `json:"short_leverage"`
+	MarginMode    string  `json:"margin_mode"`
 }
 
 type BaseLedgerInfo struct {
-	InstrumentId string `json:"instrument_id"`
-	Fee          string `json:"fee"`
-	Timestamp    string `json:"timestamp"`
-	Amount       string `json:"amount"`
-	LedgerId     string `json:"ledger_id"`
-	Type         string `json:"type"`
+	InstrumentId string  `json:"instrument_id"`
+	Fee          Decimal `json:"fee"`
+	Timestamp    string  `json:"timestamp"`
+	Amount       Decimal `json:"amount"`
+	LedgerId     string  `json:"ledger_id"`
+	Type         string  `json:"type"`
 }
 
 type SwapAccountsLedgerList []BaseLedgerInfo
 
 type BaseInstrumentInfo struct {
-	InstrumentId    string `json:"instrument_id"`
-	QuoteCurrency   string `json:"quote_currency"`
-	TickSize        string `json:"tick_size"`
-	ContractVal     string `json:"contract_val"`
-	Listing         string `json:"listing"`
-	UnderlyingIndex string `json:"underlying_index"`
-	Delivery        string `json:"delivery"`
-	Coin            string `json:"coin"`
-	SizeIncrement   string `json:"size_increment"`
+	InstrumentId    string  `json:"instrument_id"`
+	QuoteCurrency   string  `json:"quote_currency"`
+	TickSize        Decimal `json:"tick_size"`
+	ContractVal     Decimal `json:"contract_val"`
+	Listing         string  `json:"listing"`
+	UnderlyingIndex string  `json:"underlying_index"`
+	Delivery        string  `json:"delivery"`
+	Coin            string  `json:"coin"`
+	SizeIncrement   Decimal `json:"size_increment"`
+	// USDT margined swaps and newer api versions only
+	Underlying          string `json:"underlying"`
+	BaseCurrency        string `json:"base_currency"`
+	SettlementCurrency  string `json:"settlement_currency"`
+	IsInverse           bool   `json:"is_inverse,string"`
+	ContractValCurrency string `json:"contract_val_currency"`
 }
 
 type SwapInstrumentList []BaseInstrumentInfo
@@ -161,66 +174,63 @@ type SwapInstrumentDepth struct {
 }
 
 type BaseTickerInfo struct {
-	InstrumentId string `json:"instrument_id"`
-	Last         string `json:"last"`
-	Timestamp    string `json:"timestamp"`
-	High24h      string `json:"high_24h"`
-	Volume24h    string `json:"volume_24h"`
-	Low24h       string `json:"low_24h"`
+	InstrumentId string  `json:"instrument_id"`
+	Last         Decimal `json:"last"`
+	Timestamp    string  `json:"timestamp"`
+	High24h      Decimal `json:"high_24h"`
+	Volume24h    Decimal `json:"volume_24h"`
+	Low24h       Decimal `json:"low_24h"`
 }
 
 type SwapTickerList []BaseTickerInfo
 
 type BaseTradeInfo struct {
-	Timestamp string `json:"timestamp"`
-	TradeId   string `json:"trade_id"`
-	Side      string `json:"side"`
-	Price     string `json:"price"`
-	Size      string `json:"size"`
+	Timestamp string  `json:"timestamp"`
+	TradeId   string  `json:"trade_id"`
+	Side      string  `json:"side"`
+	Price     Decimal `json:"price"`
+	Size      Decimal `json:"size"`
 }
 
 type SwapTradeList []BaseTradeInfo
 
-type BaseCandleInfo []string
-type SwapCandleList []BaseCandleInfo
-
 type SwapIndexInfo struct {
 	BizWarmTips
-	InstrumentId string `json:"instrument_id"`
-	Index        string `json:"index"`
-	Timestamp    string `json:"timestamp"`
+	InstrumentId string  `json:"instrument_id"`
+	Index        Decimal `json:"index"`
+	Timestamp    string  `json:"timestamp"`
 }
 
 type SwapRate struct {
-	InstrumentId string `json:"instrument_id"`
-	Timestamp    string `json:"timestamp"`
-	Rate         string `json:"rate"`
+	InstrumentId string  `json:"instrument_id"`
+	Timestamp    string  `json:"timestamp"`
+	Rate         Decimal `json:"rate"`
 }
 
 type BaseInstrumentAmount struct {
 	BizWarmTips
-	InstrumentId string `json:"instrument_id"`
-	Timestamp    string `json:"timestamp"`
-	Amount       string `json:"amount"`
+	InstrumentId string  `json:"instrument_id"`
+	Timestamp    string  `json:"timestamp"`
+	Amount       Decimal `json:"amount"`
 }
 
 type SwapOpenInterest BaseInstrumentAmount
 
 type SwapPriceLimit struct {
 	BizWarmTips
-	InstrumentId string `json:"instrument_id"`
-	Lowest       string `json:"lowest"`
-	Highest      string `json:"highest"`
-	Timestamp    string `json:"timestamp"`
+	InstrumentId string  `json:"instrument_id"`
+	Lowest       Decimal `json:"lowest"`
+	Highest      Decimal `json:"highest"`
+	Timestamp    string  `json:"timestamp"`
 }
 
 type BaseLiquidationInfo struct {
-	InstrumentId string `json:"instrument_id"`
-	Loss         string `json:"loss"`
-	CreatedAt    string `json:"created_at"`
-	Type         string `json:"type"`
-	Price        string `json:"price"`
-	Size         string `json:"size"`
+	InstrumentId string  `json:"instrument_id"`
+	Loss         Decimal `json:"loss"`
+	CreatedAt    string  `json:"created_at"`
+	Type         string  `json:"type"`
+	Price        Decimal `json:"price"`
+	Size         Decimal `json:"size"`
 }
 
 type SwapLiquidationList []BaseLiquidationInfo
@@ -235,17 +245,17 @@ type SwapFundingTime struct {
 
 type SwapMarkPrice struct {
 	BizWarmTips
-	InstrumentId string `json:"instrument_id"`
-	MarkPrice    string `json:"mark_price"`
-	Timestamp    string `json:"timestamp"`
+	InstrumentId string  `json:"instrument_id"`
+	MarkPrice    Decimal `json:"mark_price"`
+	Timestamp    string  `json:"timestamp"`
 }
 
 type BaseHistoricalFundingRate struct {
-	InstrumentId string `json:"instrument_id"`
-	InterestRate string `json:"interest_rate"`
-	FundingRate  string `json:"funding_rate"`
-	FundingTime  string `json:"funding_time"`
-	RealizedRate string `json:"realized_rate"`
+	InstrumentId string  `json:"instrument_id"`
+	InterestRate Decimal `json:"interest_rate"`
+	FundingRate  Decimal `json:"funding_rate"`
+	FundingTime  string  `json:"funding_time"`
+	RealizedRate Decimal `json:"realized_rate"`
 }
 
 type SwapHistoricalFundingRateList []BaseHistoricalFundingRate
@@ -8,17 +8,17 @@ type WSSwapPositionResult struct {
 }
 
 type WSSwapPositionHolding struct {
-	AvailPosition    string    `json:"avail_position"`
-	AvgCost          string    `json:"avg_cost"`
-	Last             string    `json:"last"`
-	Leverage         string    `json:"leverage"`
-	LiquidationPrice string    `json:"liquidation_price"`
-	MaintMarginRatio string    `json:"maint_margin_ratio"`
-	Margin           string    `json:"margin"`
-	Position         string    `json:"position"`
-	RealizedPnl      string    `json:"realized_pnl"`
-	SettledPnl       string    `json:"settled_pnl"`
-	SettlementPrice  string    `json:"settlement_price"`
+	AvailPosition    Decimal   `json:"avail_position"`
+	AvgCost          Decimal   `json:"avg_cost"`
+	Last             Decimal   `json:"last"`
+	Leverage         Decimal   `json:"leverage"`
+	LiquidationPrice Decimal   `json:"liquidation_price"`
+	MaintMarginRatio Decimal   `json:"maint_margin_ratio"`
+	Margin           Decimal   `json:"margin"`
+	Position         Decimal   `json:"position"`
+	RealizedPnl      Decimal   `json:"realized_pnl"`
+	SettledPnl       Decimal   `json:"settled_pnl"`
+	SettlementPrice  Decimal   `json:"settlement_price"`
 	Side             string    `json:"side"`
 	Timestamp        time.Time `json:"timestamp"`
 }
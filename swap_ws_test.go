@@ -2,6 +2,7 @@ package okex
 
 import (
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 	"log"
 	"testing"
 )
@@ -45,3 +46,13 @@ func TestSwapWS_AllInOne(t *testing.T) {
 
 	select {}
 }
+
+func TestSwapWS_Simulated(t *testing.T) {
+	ws := NewSwapWS(WS_ENDPOINT, "", "", "", false, WithWSSimulated())
+	assert.Equal(t, DEMO_WS_ENDPOINT, ws.wsURL)
+	assert.Equal(t, "1", ws.wsHeader.Get(X_SIMULATED_TRADING))
+
+	ws = NewSwapWS(WS_ENDPOINT, "", "", "", false)
+	assert.Equal(t, WS_ENDPOINT, ws.wsURL)
+	assert.Empty(t, ws.wsHeader.Get(X_SIMULATED_TRADING))
+}
@@ -53,6 +53,11 @@ const (
 	FUTURES_INSTRUMENT_ORDER_CANCEL       = "/api/futures/v3/cancel_order/{instrument_id}/{order_id}"
 	FUTURES_INSTRUMENT_ORDER_BATCH_CANCEL = "/api/futures/v3/cancel_batch_orders/{instrument_id}"
 	FUTURES_FILLS                         = "/api/futures/v3/fills"
+	FUTURES_CLOSE_POSITION                = "/api/futures/v3/close_position"
+	FUTURES_ORDER_ALGO                    = "/api/futures/v3/order_algo"
+	FUTURES_CANCEL_ALGOS                  = "/api/futures/v3/cancel_algos"
+	FUTURES_INSTRUMENT_ORDER_ALGO         = "/api/futures/v3/order_algo/{instrument_id}"
+	FUTURES_AMEND_ORDER                   = "/api/futures/v3/amend_order/{instrument_id}"
 
 	MARGIN_ACCOUNTS                         = "/api/margin/v3/accounts"
 	MARGIN_ACCOUNTS_INSTRUMENT              = "/api/margin/v3/accounts/{instrument_id}"
@@ -71,6 +76,25 @@ const (
 	MARGIN_ORDERS_PENDING                   = "/api/margin/v3/orders_pending"
 	MARGIN_FILLS                            = "/api/margin/v3/fills"
 
+	OPTION_UNDERLYING          = "/api/option/v3/underlying"
+	OPTION_INSTRUMENTS         = "/api/option/v3/instruments/{underlying}"
+	OPTION_INSTRUMENTS_SUMMARY = "/api/option/v3/instruments/{underlying}/summary"
+	OPTION_INSTRUMENT_SUMMARY  = "/api/option/v3/instruments/{underlying}/summary/{instrument_id}"
+	OPTION_INSTRUMENT_BOOK     = "/api/option/v3/instruments/{instrument_id}/book"
+	OPTION_INSTRUMENT_TICKER   = "/api/option/v3/instruments/{instrument_id}/ticker"
+	OPTION_INSTRUMENT_TRADES   = "/api/option/v3/instruments/{instrument_id}/trades"
+	OPTION_INSTRUMENT_CANDLES  = "/api/option/v3/instruments/{instrument_id}/candles"
+	OPTION_ORDER               = "/api/option/v3/order"
+	OPTION_BATCH_ORDERS        = "/api/option/v3/batch_orders"
+	OPTION_CANCEL_ORDER        = "/api/option/v3/cancel_order/{underlying}/{order_client_id}"
+	OPTION_CANCEL_BATCH_ORDERS = "/api/option/v3/cancel_batch_orders/{underlying}"
+	OPTION_ORDERS              = "/api/option/v3/orders/{underlying}"
+	OPTION_ORDER_INFO          = "/api/option/v3/orders/{underlying}/{order_client_id}"
+	OPTION_FILLS               = "/api/option/v3/fills/{underlying}"
+	OPTION_POSITION            = "/api/option/v3/{underlying}/position"
+	OPTION_ACCOUNT             = "/api/option/v3/accounts/{underlying}"
+	OPTION_ACCOUNT_LEDGER      = "/api/option/v3/accounts/{underlying}/ledger"
+
 	SPOT_ACCOUNTS                 = "/api/spot/v3/accounts"
 	SPOT_ACCOUNTS_CURRENCY        = "/api/spot/v3/accounts/{currency}"
 	SPOT_ACCOUNTS_CURRENCY_LEDGER = "/api/spot/v3/accounts/{currency}/ledger"
@@ -87,6 +111,10 @@ const (
 	SPOT_INSTRUMENT_TICKER        = "/api/spot/v3/instruments/{instrument_id}/ticker"
 	SPOT_INSTRUMENT_TRADES        = "/api/spot/v3/instruments/{instrument_id}/trades"
 	SPOT_INSTRUMENT_CANDLES       = "/api/spot/v3/instruments/{instrument_id}/candles"
+	SPOT_ORDER_ALGO               = "/api/spot/v3/order_algo"
+	SPOT_CANCEL_BATCH_ALGOS       = "/api/spot/v3/cancel_batch_algos"
+	SPOT_ALGO                     = "/api/spot/v3/algo"
+	SPOT_AMEND_ORDER              = "/api/spot/v3/amend_order/{instrument_id}"
 
 	SWAP_INSTRUMENT_ACCOUNT                 = "/api/swap/v3/{instrument_id}/accounts"
 	SWAP_INSTRUMENT_POSITION                = "/api/swap/v3/{instrument_id}/position"
@@ -115,6 +143,11 @@ const (
 	SWAP_ORDER                              = "/api/swap/v3/order"
 	SWAP_ORDERS                             = "/api/swap/v3/orders"
 	SWAP_POSITION                           = "/api/swap/v3/position"
+	SWAP_CLOSE_POSITION                     = "/api/swap/v3/close_position"
+	SWAP_ORDER_ALGO                         = "/api/swap/v3/order_algo"
+	SWAP_CANCEL_ALGOS                       = "/api/swap/v3/cancel_algos"
+	SWAP_INSTRUMENT_ORDER_ALGO              = "/api/swap/v3/order_algo/{instrument_id}"
+	SWAP_AMEND_ORDER                        = "/api/swap/v3/amend_order/{instrument_id}"
 
 	SWAP_CANCEL_BATCH_ORDERS = "/api/swap/v3/cancel_batch_orders/{instrument_id}"
 	SWAP_CANCEL_ORDER        = "/api/swap/v3/cancel_order/{instrument_id}/{order_id}"
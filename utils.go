@@ -123,7 +123,11 @@ func T3O(condition bool, trueValue, falseValue interface{}) interface{} {
   eg: 1521221737.376
 */
 func EpochTime() string {
-	millisecond := time.Now().UnixNano() / 1000000
+	return epochTimeOf(time.Now())
+}
+
+func epochTimeOf(t time.Time) string {
+	millisecond := t.UnixNano() / 1000000
 	epoch := strconv.Itoa(int(millisecond))
 	epochBytes := []byte(epoch)
 	epoch = string(epochBytes[:10]) + "." + string(epochBytes[10:])
@@ -135,11 +139,11 @@ func EpochTime() string {
   eg: 2018-03-16T18:02:48.284Z
 */
 func IsoTime() string {
-	utcTime := time.Now().UTC()
-	iso := utcTime.String()
-	isoBytes := []byte(iso)
-	iso = string(isoBytes[:10]) + "T" + string(isoBytes[11:23]) + "Z"
-	return iso
+	return isoTimeOf(time.Now())
+}
+
+func isoTimeOf(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
 }
 
 /*
@@ -226,6 +230,7 @@ func ParseRequestParams(params interface{}) (string, *bytes.Reader, error) {
    OK-ACCESS-SIGN: (Use your setting, auto sign and add)
    OK-ACCESS-TIMESTAMP: (Auto add)
    OK-ACCESS-PASSPHRASE: Your setting
+   x-simulated-trading: 1      (Simulated only)
 */
 func Headers(request *http.Request, config Config, timestamp string, sign string) {
 	request.Header.Add(ACCEPT, APPLICATION_JSON)
@@ -235,6 +240,9 @@ func Headers(request *http.Request, config Config, timestamp string, sign string
 	request.Header.Add(OK_ACCESS_SIGN, sign)
 	request.Header.Add(OK_ACCESS_TIMESTAMP, timestamp)
 	request.Header.Add(OK_ACCESS_PASSPHRASE, config.Passphrase)
+	if config.Simulated {
+		request.Header.Add(X_SIMULATED_TRADING, "1")
+	}
 }
 
 /*
@@ -0,0 +1,444 @@
+package okex
+
+/*
+ The websocket engine shared by SwapWS, FuturesWS, SpotWS and OptionWS.
+
+ WSEngine owns the connection: login, subscriptions, resubscribing after a
+ reconnect and the dispatch of pushed tables. A market client is a WSEngine
+ with a WSHandler registered per table, which decodes the data and calls its
+ typed callback. A new channel only needs a handler:
+
+	engine := okex.NewWSEngine(okex.WS_ENDPOINT, "", "", "", false)
+	engine.Handle("index/ticker", func(table string, msg []byte) error {
+		var result struct {
+			Data []okex.IndexTicker `json:"data"`
+		}
+		if err := okex.JsonBytes2Struct(msg, &result); err != nil {
+			return err
+		}
+		...
+		return nil
+	})
+	engine.Subscribe("index_1", []string{"index/ticker:BTC-USD"})
+	engine.Start()
+*/
+
+import (
+	"context"
+	"fmt"
+	"github.com/recws-org/recws"
+	"github.com/tidwall/gjson"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Handles a pushed message of table, a returned error is logged
+type WSHandler func(table string, msg []byte) error
+
+// How long Login waits for the answer of the server
+var WSLoginTimeout = 5 * time.Second
+
+type WSEngine struct {
+	sync.RWMutex
+
+	wsURL      string
+	wsHeader   http.Header
+	accessKey  string
+	secretKey  string
+	passphrase string
+	debugMode  bool
+	clock      *Clock
+	logger     Logger
+	signer     Signer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	conn   recws.RecConn
+
+	subscriptions map[string]interface{}
+
+	handlers       map[string]WSHandler // by table
+	prefixHandlers map[string]WSHandler // by table prefix, eg: spot/candle
+
+	dobMap map[string]*DepthOrderBook
+
+	// answers of the login, true on success
+	loginResults chan bool
+	loggingIn    int32
+}
+
+// NewWSEngine 创建WS，需要 Handle 注册各频道的处理
+// wsURL:
+// wss://real.okex.com:8443/ws/v3
+func NewWSEngine(wsURL string, accessKey string, secretKey string, passphrase string, debugMode bool, options ...WSOption) *WSEngine {
+	opts := newWSOptions(options)
+	wsURL, wsHeader := opts.endpoint(wsURL)
+	ws := &WSEngine{
+		wsURL:          wsURL,
+		wsHeader:       wsHeader,
+		accessKey:      accessKey,
+		secretKey:      secretKey,
+		passphrase:     passphrase,
+		debugMode:      debugMode,
+		signer:         newWSSigner(opts, secretKey),
+		logger:         newWSLogger(opts, debugMode, accessKey, secretKey, passphrase),
+		subscriptions:  make(map[string]interface{}),
+		handlers:       make(map[string]WSHandler),
+		prefixHandlers: make(map[string]WSHandler),
+		dobMap:         make(map[string]*DepthOrderBook),
+		loginResults:   make(chan bool, 1),
+	}
+	ws.ctx, ws.cancel = context.WithCancel(context.Background())
+	ws.conn = recws.RecConn{
+		KeepAliveTimeout: 10 * time.Second,
+	}
+	ws.conn.SubscribeHandler = ws.subscribeHandler
+	return ws
+}
+
+/*
+ Handle the messages of table with handler, replacing the table's handler if any.
+ A table ending with "*" handles every table with that prefix, eg: spot/candle*,
+ a handler of the exact table takes precedence.
+*/
+func (ws *WSEngine) Handle(table string, handler WSHandler) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	if strings.HasSuffix(table, "*") {
+		ws.prefixHandlers[strings.TrimSuffix(table, "*")] = handler
+		return
+	}
+	ws.handlers[table] = handler
+}
+
+func (ws *WSEngine) handler(table string) (WSHandler, bool) {
+	ws.RLock()
+	defer ws.RUnlock()
+
+	if handler, ok := ws.handlers[table]; ok {
+		return handler, true
+	}
+	// the longest matching prefix
+	var handler WSHandler
+	matched := ""
+	for prefix, h := range ws.prefixHandlers {
+		if strings.HasPrefix(table, prefix) && len(prefix) >= len(matched) {
+			handler, matched = h, prefix
+		}
+	}
+	return handler, handler != nil
+}
+
+// SetProxy 设置代理地址
+// porxyURL:
+// socks5://127.0.0.1:1080
+// https://127.0.0.1:1080
+func (ws *WSEngine) SetProxy(proxyURL string) (err error) {
+	var purl *url.URL
+	purl, err = url.Parse(proxyURL)
+	if err != nil {
+		return
+	}
+	ws.logger.Log(LogLevelInfo, "proxy", F("url", purl))
+	ws.conn.Proxy = http.ProxyURL(purl)
+	return
+}
+
+// SetClock 使用校准后的时钟签名登录，一般传入 Client.Clock()
+func (ws *WSEngine) SetClock(clock *Clock) {
+	ws.clock = clock
+}
+
+// Subscribe 订阅
+func (ws *WSEngine) Subscribe(id string, args []string) error {
+	ws.Lock()
+	defer ws.Unlock()
+
+	op := BaseOp{
+		Op:   "subscribe",
+		Args: args,
+	}
+	ws.subscriptions[id] = op
+	return ws.sendWSMessage(op)
+}
+
+// Unsubscribe 取消订阅
+func (ws *WSEngine) Unsubscribe(id string) error {
+	ws.Lock()
+	defer ws.Unlock()
+
+	if _, ok := ws.subscriptions[id]; ok {
+		delete(ws.subscriptions, id)
+	}
+	return nil
+}
+
+/*
+ Log in and wait up to WSLoginTimeout for the answer, which is read by the
+ loop of Start: a login before Start times out.
+*/
+func (ws *WSEngine) Login() error {
+	if ws.accessKey == "" || ws.signer == nil || ws.passphrase == "" {
+		return fmt.Errorf("missing key")
+	}
+	timestamp := ws.clock.EpochTime()
+
+	preHash := PreHashString(timestamp, GET, "/users/self/verify", "")
+	sign, err := ws.signer.Sign(preHash)
+	if err != nil {
+		return err
+	}
+	op, err := loginOp(ws.accessKey, ws.passphrase, timestamp, sign)
+	if err != nil {
+		return err
+	}
+
+	// drop an answer of an earlier login
+	select {
+	case <-ws.loginResults:
+	default:
+	}
+	atomic.StoreInt32(&ws.loggingIn, 1)
+	defer atomic.StoreInt32(&ws.loggingIn, 0)
+
+	ws.logger.Log(LogLevelDebug, "send login", F("api_key", ws.accessKey), F("timestamp", timestamp), F("sign", sign))
+	if err = ws.sendWSMessage(op); err != nil {
+		return err
+	}
+	timer := time.NewTimer(WSLoginTimeout)
+	defer timer.Stop()
+	select {
+	case success := <-ws.loginResults:
+		if !success {
+			return fmt.Errorf("login rejected")
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("login timeout after %s", WSLoginTimeout)
+	case <-ws.ctx.Done():
+		return ws.ctx.Err()
+	}
+}
+
+// Pass the answer of a login to the waiting Login, if any
+func (ws *WSEngine) loginAnswered(success bool) {
+	if atomic.LoadInt32(&ws.loggingIn) == 0 {
+		return
+	}
+	select {
+	case ws.loginResults <- success:
+	default:
+	}
+}
+
+func (ws *WSEngine) subscribeHandler() error {
+	// reconnected after Close
+	if ws.ctx.Err() != nil {
+		ws.conn.Close()
+		return nil
+	}
+
+	// not locked while Login waits for the answer, subscriptions of private
+	// channels are resent after it
+	err := ws.Login()
+	if err != nil {
+		ws.logger.Log(LogLevelError, "login failed", F("error", err))
+		ws.logger.Log(LogLevelWarn, "resubscribing without login, private channels will fail")
+	}
+
+	ws.Lock()
+	defer ws.Unlock()
+
+	for _, v := range ws.subscriptions {
+		err := ws.sendWSMessage(v)
+		if err != nil {
+			ws.logger.Log(LogLevelError, "subscribe failed", F("error", err))
+		}
+	}
+	return nil
+}
+
+func (ws *WSEngine) sendWSMessage(msg interface{}) error {
+	return ws.conn.WriteJSON(msg)
+}
+
+func (ws *WSEngine) Start() {
+	ws.logger.Log(LogLevelInfo, "dial", F("url", ws.wsURL))
+	ws.conn.Dial(ws.wsURL, ws.wsHeader)
+	go ws.run()
+}
+
+// Close 关闭连接并停止读取，关闭后不能再次 Start
+func (ws *WSEngine) Close() {
+	ws.cancel()
+	ws.conn.Close()
+}
+
+func (ws *WSEngine) run() {
+	for {
+		select {
+		case <-ws.ctx.Done():
+			ws.logger.Log(LogLevelInfo, "websocket closed", F("url", ws.conn.GetURL()))
+			return
+		default:
+			messageType, msg, err := ws.conn.ReadMessage()
+			if err != nil {
+				if ws.ctx.Err() != nil {
+					continue
+				}
+				ws.logger.Log(LogLevelWarn, "read failed", F("error", err))
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+
+			msg, err = FlateUnCompress(msg)
+			if err != nil {
+				ws.logger.Log(LogLevelError, "decompress failed", F("error", err))
+				continue
+			}
+
+			ws.handleMsg(messageType, msg)
+		}
+	}
+}
+
+func (ws *WSEngine) handleMsg(messageType int, msg []byte) {
+	ret := gjson.ParseBytes(msg)
+	// 登录成功
+	// {"event":"login","success":true}
+
+	if tableValue := ret.Get("table"); tableValue.Exists() {
+		table := tableValue.String()
+		handler, ok := ws.handler(table)
+		if !ok {
+			ws.logger.Log(LogLevelDebug, "unhandled message", F("msg", string(msg)))
+			return
+		}
+		if err := handler(table, msg); err != nil {
+			ws.logger.Log(LogLevelError, "decode message failed", F("table", table), F("error", err))
+		}
+		return
+	}
+
+	if eventValue := ret.Get("event"); eventValue.Exists() {
+		event := eventValue.String()
+		if event == "error" {
+			ws.logger.Log(LogLevelError, "event error", F("msg", string(msg)))
+			// a rejected login is answered with an error event, eg: 30027 login failure
+			ws.loginAnswered(false)
+			return
+		}
+		if event == "login" {
+			ws.loginAnswered(ret.Get("success").Bool())
+		}
+		ws.logger.Log(LogLevelInfo, "event", F("msg", string(msg)))
+		return
+	}
+
+	ws.logger.Log(LogLevelDebug, "unhandled message", F("msg", string(msg)))
+}
+
+/*
+ Handler of a depth_l2_tbt table: passes the data to *depthL2TbtCallback and
+ the 20 best levels of the local book of each instrument to *depth20SnapshotCallback.
+ The callbacks are read per message, they may be set after registering.
+*/
+func (ws *WSEngine) depthL2TbtHandler(depthL2TbtCallback *func(action string, data []WSDepthL2Tbt),
+	depth20SnapshotCallback *func(ob *OrderBook)) WSHandler {
+	return func(table string, msg []byte) error {
+		var depthL2 WSDepthL2TbtResult
+		if err := json.Unmarshal(msg, &depthL2); err != nil {
+			return err
+		}
+
+		if *depthL2TbtCallback != nil {
+			(*depthL2TbtCallback)(depthL2.Action, depthL2.Data)
+		}
+
+		if *depth20SnapshotCallback != nil {
+			for _, v := range depthL2.Data {
+				ws.Lock()
+				dob, ok := ws.dobMap[v.InstrumentID]
+				if !ok {
+					dob = NewDepthOrderBook(v.InstrumentID)
+					ws.dobMap[v.InstrumentID] = dob
+				}
+				ws.Unlock()
+				if err := dob.Update(depthL2.Action, &v); err != nil {
+					ws.logger.Log(LogLevelError, "update depth failed", F("instrument_id", v.InstrumentID), F("error", err))
+					continue
+				}
+				ob := dob.GetOrderBook(20)
+				(*depth20SnapshotCallback)(&ob)
+			}
+		}
+		return nil
+	}
+}
+
+/*
+ Handlers of the tables shared by the swap and futures markets
+*/
+
+func wsTickerHandler(callback *func(tickers []WSTicker)) WSHandler {
+	return func(table string, msg []byte) error {
+		var result WSTickerResult
+		if err := json.Unmarshal(msg, &result); err != nil {
+			return err
+		}
+		if *callback != nil {
+			(*callback)(result.Data)
+		}
+		return nil
+	}
+}
+
+func wsTradeHandler(callback *func(trades []WSTrade)) WSHandler {
+	return func(table string, msg []byte) error {
+		var result WSTradeResult
+		if err := json.Unmarshal(msg, &result); err != nil {
+			return err
+		}
+		if *callback != nil {
+			(*callback)(result.Data)
+		}
+		return nil
+	}
+}
+
+func wsAccountHandler(callback *func(accounts []WSAccount)) WSHandler {
+	return func(table string, msg []byte) error {
+		var result WSAccountResult
+		if err := json.Unmarshal(msg, &result); err != nil {
+			return err
+		}
+		if *callback != nil {
+			var accounts []WSAccount
+			for _, v := range result.Data {
+				if account := v.account(); account != nil {
+					accounts = append(accounts, *account)
+				}
+			}
+			(*callback)(accounts)
+		}
+		return nil
+	}
+}
+
+func wsOrderHandler(callback *func(orders []WSOrder)) WSHandler {
+	return func(table string, msg []byte) error {
+		var result WSOrderResult
+		if err := json.Unmarshal(msg, &result); err != nil {
+			return err
+		}
+		if *callback != nil {
+			(*callback)(result.Data)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,213 @@
+package okex
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestWSEngine_Handle(t *testing.T) {
+	mem := &memoryLogger{}
+	ws := NewWSEngine(WS_ENDPOINT, "", "", "", false, WithWSLogger(mem))
+	var handled []string
+	record := func(name string) WSHandler {
+		return func(table string, msg []byte) error {
+			handled = append(handled, name+" "+table)
+			return nil
+		}
+	}
+	ws.Handle("spot/candle*", record("candle"))
+	ws.Handle("spot/candle60s", record("1m"))
+	ws.Handle("spot/*", record("spot"))
+	ws.Handle("spot/ticker", func(table string, msg []byte) error {
+		return errors.New("broken ticker")
+	})
+
+	ws.handleMsg(0, []byte(`{"table":"spot/candle60s","data":[]}`))
+	ws.handleMsg(0, []byte(`{"table":"spot/candle300s","data":[]}`))
+	ws.handleMsg(0, []byte(`{"table":"spot/trade","data":[]}`))
+	ws.handleMsg(0, []byte(`{"table":"swap/trade","data":[]}`))
+	ws.handleMsg(0, []byte(`{"table":"spot/ticker","data":[]}`))
+	assert.Equal(t, []string{"1m spot/candle60s", "candle spot/candle300s", "spot spot/trade"}, handled)
+	assert.Contains(t, mem.String(), "unhandled message")
+	assert.Contains(t, mem.String(), "decode message failed table=spot/ticker error=broken ticker")
+}
+
+func TestSwapWS_Handlers(t *testing.T) {
+	ws := NewSwapWS(WS_ENDPOINT, "", "", "", false)
+	var accounts []WSAccount
+	var positions []WSSwapPositionData
+	var levels []int
+	ws.SetAccountCallback(func(data []WSAccount) { accounts = data })
+	ws.SetPositionCallback(func(data []WSSwapPositionData) { positions = data })
+	ws.SetDepthL2TbtCallback(func(action string, data []WSDepthL2Tbt) { levels = append(levels, len(data[0].Asks)) })
+
+	ws.handleMsg(0, []byte(`{"table":"swap/account","data":[{"BTC":{"equity":"1.5","currency":"BTC"}},{"ETH":{"equity":"10","currency":"ETH"}}]}`))
+	assert.Equal(t, 2, len(accounts))
+	assert.Equal(t, Decimal("10"), accounts[1].Equity)
+
+	ws.handleMsg(0, []byte(`{"table":"swap/position","data":[{"instrument_id":"BTC-USD-SWAP","margin_mode":"crossed","holding":[{"position":"3","side":"long"}]}]}`))
+	assert.Equal(t, Decimal("3"), positions[0].Holding[0].Position)
+
+	ws.handleMsg(0, []byte(`{"table":"swap/depth_l2_tbt","action":"partial","data":[{"instrument_id":"BTC-USD-SWAP","asks":[["9000","1","0","1"]],"bids":[]}]}`))
+	assert.Equal(t, []int{1}, levels)
+}
+
+func TestFuturesWS_Handlers(t *testing.T) {
+	ws := NewFuturesWS(WS_ENDPOINT, "", "", "", false)
+	var positions []WSFuturesPosition
+	var books []OrderBook
+	ws.SetPositionCallback(func(data []WSFuturesPosition) { positions = data })
+	ws.SetDepth20SnapshotCallback(func(ob *OrderBook) { books = append(books, *ob) })
+
+	ws.handleMsg(0, []byte(`{"table":"futures/position","data":[{"instrument_id":"BTC-USD-200925","long_qty":"2","short_qty":"1"}]}`))
+	assert.Equal(t, Decimal("2"), positions[0].LongQty)
+
+	ws.handleMsg(0, []byte(`{"table":"futures/depth_l2_tbt","action":"partial","data":[{"instrument_id":"BTC-USD-200925",`+
+		`"asks":[["9001","1","0","1"]],"bids":[["9000","2","0","1"]]}]}`))
+	assert.Equal(t, 1, len(books))
+	assert.Equal(t, Decimal("9000"), books[0].Bids[0].Price)
+	assert.NotNil(t, ws.dobMap["BTC-USD-200925"])
+}
+
+// Answers like the server: compressed with deflate
+func writeFlate(t *testing.T, conn *websocket.Conn, msg string) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestSpeed)
+	assert.Nil(t, err)
+	_, err = w.Write([]byte(msg))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	assert.Nil(t, conn.WriteMessage(websocket.BinaryMessage, buf.Bytes()))
+}
+
+// A server answering the login with answer after delay, ops gets every op received
+func loginServer(t *testing.T, answer string, delay time.Duration, ops chan<- string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				ops <- "closed"
+				return
+			}
+			op := gjson.GetBytes(msg, "op").String()
+			ops <- op
+			if op == "login" {
+				// the only writer, reading goes on meanwhile
+				go func() {
+					time.Sleep(delay)
+					ops <- "answered"
+					writeFlate(t, conn, answer)
+				}()
+			}
+		}
+	}))
+}
+
+func TestWSEngine_LoginAndClose(t *testing.T) {
+	ops := make(chan string, 16)
+	server := loginServer(t, `{"event":"login","success":true}`, 300*time.Millisecond, ops)
+	defer server.Close()
+
+	ws := NewWSEngine("ws"+strings.TrimPrefix(server.URL, "http"), "key", "secret", "passphrase", false)
+	ws.conn.HandshakeTimeout = 200 * time.Millisecond
+	ws.conn.KeepAliveTimeout = 0
+	ws.conn.NonVerbose = true
+	go ws.Start()
+	assert.Equal(t, "login", <-ops)
+
+	// not blocked by the login wait
+	start := time.Now()
+	assert.Nil(t, ws.Subscribe("ticker", []string{"spot/ticker:BTC-USDT"}))
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+	assert.Equal(t, "subscribe", <-ops)
+
+	// resent once the login is answered
+	assert.Equal(t, "answered", <-ops)
+	assert.Equal(t, "subscribe", <-ops)
+	time.Sleep(200 * time.Millisecond)
+	ws.Close()
+	assert.Equal(t, "closed", <-ops)
+	// a reconnect after Close neither logs in nor subscribes
+	select {
+	case op := <-ops:
+		assert.Equal(t, "closed", op)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestWSEngine_LoginRejected(t *testing.T) {
+	ops := make(chan string, 16)
+	server := loginServer(t, `{"event":"error","message":"Invalid sign","errorCode":30013}`, 0, ops)
+	defer server.Close()
+
+	ws := NewWSEngine("ws"+strings.TrimPrefix(server.URL, "http"), "key", "secret", "passphrase", false)
+	ws.conn.HandshakeTimeout = 200 * time.Millisecond
+	ws.conn.KeepAliveTimeout = 0
+	ws.conn.NonVerbose = true
+	ws.subscriptions["ticker"] = BaseOp{Op: "subscribe", Args: []string{"spot/ticker:BTC-USDT"}}
+	start := time.Now()
+	go ws.Start()
+	defer ws.Close()
+
+	assert.Equal(t, "login", <-ops)
+	assert.Equal(t, "answered", <-ops)
+	// resubscribed without waiting for WSLoginTimeout
+	select {
+	case op := <-ops:
+		assert.Equal(t, "subscribe", op)
+		assert.True(t, time.Since(start) < WSLoginTimeout)
+	case <-time.After(WSLoginTimeout):
+		t.Fatal("not resubscribed")
+	}
+}
+
+func TestWSEngine_SimulatedHandshake(t *testing.T) {
+	headers := make(chan string, 1)
+	logins := make(chan []byte, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get(X_SIMULATED_TRADING)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, msg, err := conn.ReadMessage()
+		if err == nil {
+			logins <- msg
+		}
+	}))
+	defer server.Close()
+
+	// a non production url is kept
+	ws := NewWSEngine("ws"+strings.TrimPrefix(server.URL, "http"), "key", "secret", "passphrase", false, WithWSSimulated())
+	ws.conn.HandshakeTimeout = 200 * time.Millisecond
+	ws.conn.KeepAliveTimeout = 0
+	ws.conn.NonVerbose = true
+	go ws.Start()
+	defer ws.Close()
+
+	assert.Equal(t, "1", <-headers)
+	// the login carries no simulated flag
+	login := gjson.ParseBytes(<-logins)
+	assert.Equal(t, "login", login.Get("op").String())
+	assert.Equal(t, 4, len(login.Get("args").Array()))
+	assert.Equal(t, "key", login.Get("args.0").String())
+	assert.Equal(t, "passphrase", login.Get("args.1").String())
+}
@@ -0,0 +1,94 @@
+package okex
+
+/*
+ Optional settings of SwapWS and FuturesWS, eg:
+
+	ws := NewSwapWS(wsURL, accessKey, secretKey, passphrase, false, WithWSLogger(logger))
+*/
+
+import "net/http"
+
+type wsOptions struct {
+	logger     Logger
+	logSecrets bool
+	simulated  bool
+	signer     Signer
+}
+
+type WSOption func(*wsOptions)
+
+// WithWSLogger 使用自定义日志，默认输出到 stderr
+func WithWSLogger(logger Logger) WSOption {
+	return func(o *wsOptions) {
+		o.logger = logger
+	}
+}
+
+// WithWSLogSecrets 日志中不隐藏 key、passphrase、签名，仅用于本地调试
+func WithWSLogSecrets() WSOption {
+	return func(o *wsOptions) {
+		o.logSecrets = true
+	}
+}
+
+// WithWSSimulated 模拟盘：wsURL 为空或实盘地址时替换为 DEMO_WS_ENDPOINT，并在握手请求头中带上 x-simulated-trading: 1。
+// 登录消息不变，模拟盘仅由连接地址和请求头区分
+func WithWSSimulated() WSOption {
+	return func(o *wsOptions) {
+		o.simulated = true
+	}
+}
+
+// WithWSSigner 使用自定义签名登录，secretKey 可传空
+func WithWSSigner(signer Signer) WSOption {
+	return func(o *wsOptions) {
+		o.signer = signer
+	}
+}
+
+func newWSOptions(options []WSOption) wsOptions {
+	var opts wsOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+/*
+ The url to dial and the headers sent with the websocket handshake
+*/
+func (opts wsOptions) endpoint(wsURL string) (string, http.Header) {
+	header := http.Header{}
+	if opts.simulated {
+		if wsURL == "" || wsURL == WS_ENDPOINT {
+			wsURL = DEMO_WS_ENDPOINT
+		}
+		header.Set(X_SIMULATED_TRADING, "1")
+	}
+	return wsURL, header
+}
+
+/*
+ The configured signer, or HMAC over the secret key. nil without both.
+*/
+func newWSSigner(opts wsOptions, secretKey string) Signer {
+	if opts.signer == nil && secretKey == "" {
+		return nil
+	}
+	return newSigner(opts.signer, secretKey)
+}
+
+/*
+ The configured logger, or a stderr logger at debug level in debug mode.
+ Credentials are redacted unless WithWSLogSecrets is given.
+*/
+func newWSLogger(opts wsOptions, debugMode bool, accessKey, secretKey, passphrase string) Logger {
+	logger := opts.logger
+	if logger == nil {
+		logger = NewStdLogger(T3O(debugMode, LogLevelDebug, LogLevelInfo).(LogLevel))
+	}
+	if opts.logSecrets {
+		return logger
+	}
+	return newRedactingLogger(logger, accessKey, secretKey, passphrase)
+}
@@ -3,20 +3,20 @@ package okex
 import "time"
 
 type WSTicker struct {
-	Last           string    `json:"last"`
-	Open24H        string    `json:"open_24h"`
-	BestBid        string    `json:"best_bid"`
-	High24H        string    `json:"high_24h"`
-	Low24H         string    `json:"low_24h"`
-	Volume24H      string    `json:"volume_24h"`
-	VolumeToken24H string    `json:"volume_token_24h"`
-	BestAsk        string    `json:"best_ask"`
-	OpenInterest   string    `json:"open_interest"`
+	Last           Decimal   `json:"last"`
+	Open24H        Decimal   `json:"open_24h"`
+	BestBid        Decimal   `json:"best_bid"`
+	High24H        Decimal   `json:"high_24h"`
+	Low24H         Decimal   `json:"low_24h"`
+	Volume24H      Decimal   `json:"volume_24h"`
+	VolumeToken24H Decimal   `json:"volume_token_24h"`
+	BestAsk        Decimal   `json:"best_ask"`
+	OpenInterest   Decimal   `json:"open_interest"`
 	InstrumentID   string    `json:"instrument_id"`
 	Timestamp      time.Time `json:"timestamp"`
-	BestBidSize    string    `json:"best_bid_size"`
-	BestAskSize    string    `json:"best_ask_size"`
-	LastQty        string    `json:"last_qty"`
+	BestBidSize    Decimal   `json:"best_bid_size"`
+	BestAskSize    Decimal   `json:"best_ask_size"`
+	LastQty        Decimal   `json:"last_qty"`
 }
 
 type WSTickerResult struct {
@@ -27,8 +27,8 @@ type WSTickerResult struct {
 type WSTrade struct {
 	Side         string    `json:"side"`
 	TradeID      string    `json:"trade_id"`
-	Price        string    `json:"price"`
-	Qty          string    `json:"qty"`
+	Price        Decimal   `json:"price"`
+	Qty          Decimal   `json:"qty"`
 	InstrumentID string    `json:"instrument_id"`
 	Timestamp    time.Time `json:"timestamp"`
 }
@@ -58,23 +58,23 @@ type WSAccountResult struct {
 }
 
 type WSAccount struct {
-	Available         string    `json:"available"`
-	CanWithdraw       string    `json:"can_withdraw"`
+	Available         Decimal   `json:"available"`
+	CanWithdraw       Decimal   `json:"can_withdraw"`
 	Currency          string    `json:"currency"`
-	Equity            string    `json:"equity"`
+	Equity            Decimal   `json:"equity"`
 	LiquiMode         string    `json:"liqui_mode"`
-	MaintMarginRatio  string    `json:"maint_margin_ratio"`
-	Margin            string    `json:"margin"`
-	MarginForUnfilled string    `json:"margin_for_unfilled"`
-	MarginFrozen      string    `json:"margin_frozen"`
+	MaintMarginRatio  Decimal   `json:"maint_margin_ratio"`
+	Margin            Decimal   `json:"margin"`
+	MarginForUnfilled Decimal   `json:"margin_for_unfilled"`
+	MarginFrozen      Decimal   `json:"margin_frozen"`
 	MarginMode        string    `json:"margin_mode"`
-	MarginRatio       string    `json:"margin_ratio"`
-	OpenMax           string    `json:"open_max"`
-	RealizedPnl       string    `json:"realized_pnl"`
+	MarginRatio       Decimal   `json:"margin_ratio"`
+	OpenMax           Decimal   `json:"open_max"`
+	RealizedPnl       Decimal   `json:"realized_pnl"`
 	Timestamp         time.Time `json:"timestamp"`
-	TotalAvailBalance string    `json:"total_avail_balance"`
+	TotalAvailBalance Decimal   `json:"total_avail_balance"`
 	Underlying        string    `json:"underlying"`
-	UnrealizedPnl     string    `json:"unrealized_pnl"`
+	UnrealizedPnl     Decimal   `json:"unrealized_pnl"`
 }
 
 type WSAccountData struct {
@@ -89,24 +89,34 @@ type WSAccountData struct {
 	TRX *WSAccount `json:"TRX"`
 }
 
+// The account of the data, each row holds one currency
+func (d WSAccountData) account() *WSAccount {
+	for _, account := range []*WSAccount{d.BTC, d.ETH, d.ETC, d.XRP, d.EOS, d.BCH, d.BSV, d.TRX} {
+		if account != nil {
+			return account
+		}
+	}
+	return nil
+}
+
 type WSOrder struct {
-	Leverage     string    `json:"leverage"`
+	Leverage     Decimal   `json:"leverage"`
 	LastFillTime time.Time `json:"last_fill_time"`
-	FilledQty    string    `json:"filled_qty"`
-	Fee          string    `json:"fee"`
-	PriceAvg     string    `json:"price_avg"`
+	FilledQty    Decimal   `json:"filled_qty"`
+	Fee          Decimal   `json:"fee"`
+	PriceAvg     Decimal   `json:"price_avg"`
 	Type         string    `json:"type"`
 	ClientOid    string    `json:"client_oid"`
-	LastFillQty  string    `json:"last_fill_qty"`
+	LastFillQty  Decimal   `json:"last_fill_qty"`
 	InstrumentID string    `json:"instrument_id"`
-	LastFillPx   string    `json:"last_fill_px"`
-	Pnl          string    `json:"pnl"`
-	Size         string    `json:"size"`
-	Price        string    `json:"price"`
+	LastFillPx   Decimal   `json:"last_fill_px"`
+	Pnl          Decimal   `json:"pnl"`
+	Size         Decimal   `json:"size"`
+	Price        Decimal   `json:"price"`
 	LastFillID   string    `json:"last_fill_id"`
 	ErrorCode    string    `json:"error_code"`
 	State        string    `json:"state"`
-	ContractVal  string    `json:"contract_val"`
+	ContractVal  Decimal   `json:"contract_val"`
 	OrderID      string    `json:"order_id"`
 	OrderType    string    `json:"order_type"`
 	Timestamp    time.Time `json:"timestamp"`